@@ -0,0 +1,196 @@
+package fleet
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Selects the nodes a fleet command runs against
+var nodesFlag = cli.StringFlag{
+	Name:  "nodes, n",
+	Usage: "A comma-separated list of node profiles to run against (default: all of them)",
+}
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Manage and query several Rocket Pool nodes, local or remote over SSH, from one machine",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "add",
+				Aliases:   []string{"a"},
+				Usage:     "Add a node profile to the fleet",
+				UsageText: "rocketpool fleet add [options] name",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "config-path, c",
+						Usage: "The node's Rocket Pool user data path (on the remote host if --host is set)",
+						Value: "~/.rocketpool",
+					},
+					cli.StringFlag{
+						Name:  "host",
+						Usage: "The SSH destination of a remote node, in the form [user@]host[:port]; leave blank for a node on this machine",
+					},
+					cli.StringFlag{
+						Name:  "identity-file, i",
+						Usage: "The SSH private key to log in with (default: the keys in your SSH agent, or ~/.ssh/id_ed25519 and ~/.ssh/id_rsa)",
+					},
+					cli.StringFlag{
+						Name:  "cli-path",
+						Usage: "The path of the Rocket Pool CLI on the remote host, used by 'fleet exec'",
+						Value: "rocketpool",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return addNode(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "remove",
+				Aliases:   []string{"r"},
+				Usage:     "Remove a node profile from the fleet",
+				UsageText: "rocketpool fleet remove name",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return removeNode(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "list",
+				Aliases:   []string{"l"},
+				Usage:     "List the node profiles in the fleet",
+				UsageText: "rocketpool fleet list",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return listNodes(c)
+
+				},
+			},
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Get a summary of the status of every node in the fleet",
+				UsageText: "rocketpool fleet status [options]",
+				Flags:     []cli.Flag{nodesFlag},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getStatus(c)
+
+				},
+			},
+
+			{
+				Name:    "minipool",
+				Aliases: []string{"m"},
+				Usage:   "Query the minipools of every node in the fleet",
+				Subcommands: []cli.Command{
+					{
+						Name:      "status",
+						Aliases:   []string{"s"},
+						Usage:     "List the minipools of every node in the fleet",
+						UsageText: "rocketpool fleet minipool status [options]",
+						Flags:     []cli.Flag{nodesFlag},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getMinipoolStatus(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:    "service",
+				Aliases: []string{"v"},
+				Usage:   "Query the Rocket Pool service of every node in the fleet",
+				Subcommands: []cli.Command{
+					{
+						Name:      "version",
+						Aliases:   []string{"v"},
+						Usage:     "Get the Rocket Pool service and client versions of every node in the fleet",
+						UsageText: "rocketpool fleet service version [options]",
+						Flags:     []cli.Flag{nodesFlag},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getServiceVersion(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "exec",
+				Aliases:   []string{"x"},
+				Usage:     "Run a Rocket Pool CLI command on each node in the fleet, confirming before each one",
+				UsageText: "rocketpool fleet exec [options] -- command [args...]\n\n   For example: rocketpool fleet exec --nodes node1,node2 -- node claim-rewards",
+				// The command's own flags have to be passed through untouched
+				SkipArgReorder: true,
+				Flags: []cli.Flag{
+					nodesFlag,
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Run the command on every node without asking for confirmation first",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() == 0 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+
+					// Run
+					return execCommand(c, c.Args())
+
+				},
+			},
+		},
+	})
+}
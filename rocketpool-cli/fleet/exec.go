@@ -0,0 +1,237 @@
+package fleet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The default terminal type to request for remote commands
+const defaultTerm string = "xterm"
+
+// The result of running a command on a node
+type execResult struct {
+	Name   string          `json:"name"`
+	Status string          `json:"status"`
+	Error  string          `json:"error,omitempty"`
+	Output json.RawMessage `json:"output,omitempty"`
+}
+
+func execCommand(c *cli.Context, args []string) error {
+
+	// Get the nodes
+	fleet, err := loadFleet(c)
+	if err != nil {
+		return err
+	}
+	nodes, err := fleet.selectNodes(c.String("nodes"))
+	if err != nil {
+		return err
+	}
+	commandText := strings.Join(append([]string{"rocketpool"}, args...), " ")
+
+	// Run the command on each node, one at a time so its prompts can be answered.
+	// With machine-readable output, each node's CLI prints a JSON document which is collected instead.
+	succeeded := []string{}
+	failed := []string{}
+	skipped := []string{}
+	results := []execResult{}
+	stdin := newStdinReader(os.Stdin)
+	for _, node := range nodes {
+		fmt.Printf("=== %s (%s) ===\n", node.Name, node.location())
+		if !(c.Bool("yes") || cliutils.ConfirmFrom(stdin, fmt.Sprintf("Run `%s` on node '%s'?", commandText, node.Name))) {
+			fmt.Println("Skipped.")
+			fmt.Println()
+			skipped = append(skipped, node.Name)
+			results = append(results, execResult{Name: node.Name, Status: "skipped"})
+			continue
+		}
+
+		var nodeOutput bytes.Buffer
+		if node.isRemote() {
+			err = runRemoteCommand(node, args, stdin, &nodeOutput)
+		} else {
+			err = runLocalCommand(c, node, args, stdin, &nodeOutput)
+		}
+		result := execResult{Name: node.Name, Status: "succeeded"}
+		if output.IsStructured() {
+			result.Output = getCommandOutput(nodeOutput.Bytes())
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%sThe command failed on node '%s': %s%s\n", colorYellow, node.Name, err.Error(), colorReset)
+			failed = append(failed, node.Name)
+			result.Status = "failed"
+			result.Error = err.Error()
+		} else {
+			succeeded = append(succeeded, node.Name)
+		}
+		results = append(results, result)
+		fmt.Println()
+	}
+	output.Set("nodes", results)
+
+	// Print a summary
+	fmt.Println("=== Summary ===")
+	fmt.Printf("Succeeded: %s\n", joinOrNone(succeeded))
+	fmt.Printf("Failed:    %s\n", joinOrNone(failed))
+	fmt.Printf("Skipped:   %s\n", joinOrNone(skipped))
+	if len(failed) > 0 {
+		return fmt.Errorf("the command failed on %d node(s)", len(failed))
+	}
+	return nil
+
+}
+
+// Get the extra args that make a node's CLI print machine-readable output if this CLI is
+func getOutputArgs() []string {
+	if !output.IsStructured() {
+		return []string{}
+	}
+	return []string{"--output", string(output.Format_Json)}
+}
+
+// Get a node's machine-readable output for the fleet's document, keeping it as a string if it isn't valid JSON
+func getCommandOutput(commandOutput []byte) json.RawMessage {
+	commandOutput = bytes.TrimSpace(commandOutput)
+	if len(commandOutput) == 0 {
+		return nil
+	}
+	if json.Valid(commandOutput) {
+		return commandOutput
+	}
+	raw, _ := json.Marshal(string(commandOutput))
+	return raw
+}
+
+// Run a CLI command against a node on this machine with this binary
+func runLocalCommand(c *cli.Context, node nodeProfile, args []string, stdin *stdinReader, structuredOutput io.Writer) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error getting the path of the Rocket Pool CLI: %w", err)
+	}
+	cmdArgs := append([]string{"--config-path", node.ConfigPath}, getOutputArgs()...)
+	if c.GlobalBool("allow-root") {
+		cmdArgs = append(cmdArgs, "--allow-root")
+	}
+	cmd := exec.Command(executable, append(cmdArgs, args...)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if output.IsStructured() {
+		cmd.Stdout = structuredOutput
+	}
+
+	// Give the command this terminal, unless a read from an earlier node is still waiting for input
+	if stdin.isIdle() {
+		cmd.Stdin = os.Stdin
+		return cmd.Run()
+	}
+	stdinPipe, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("error getting the command's input: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	stopForwarding := stdin.forward(stdinPipe)
+	err = cmd.Wait()
+	stopForwarding()
+	return err
+}
+
+// Run a CLI command on a remote node with the CLI installed there, in a terminal so its prompts work
+func runRemoteCommand(node nodeProfile, args []string, stdin *stdinReader, structuredOutput io.Writer) error {
+	sshClient, err := connectNode(node)
+	if err != nil {
+		return err
+	}
+	defer sshClient.Close()
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return fmt.Errorf("error starting SSH session: %w", err)
+	}
+	defer session.Close()
+
+	// Forward this terminal to the remote command; there are no prompts to answer with machine-readable output
+	stdinFd := int(os.Stdin.Fd())
+	if !output.IsStructured() && term.IsTerminal(stdinFd) {
+		width, height, err := term.GetSize(stdinFd)
+		if err != nil {
+			width, height = 80, 24
+		}
+		termType := os.Getenv("TERM")
+		if termType == "" {
+			termType = defaultTerm
+		}
+		if err := session.RequestPty(termType, height, width, ssh.TerminalModes{ssh.ECHO: 1}); err != nil {
+			return fmt.Errorf("error requesting a terminal: %w", err)
+		}
+		state, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("error setting up the terminal: %w", err)
+		}
+		defer func() {
+			_ = term.Restore(stdinFd, state)
+		}()
+	}
+	stdout := io.Writer(os.Stdout)
+	if output.IsStructured() {
+		stdout = structuredOutput
+	}
+
+	// Build the remote command
+	cliPath := node.CliPath
+	if cliPath == "" {
+		cliPath = "rocketpool"
+	}
+	quotedArgs := []string{}
+	for _, arg := range append(getOutputArgs(), args...) {
+		quotedArgs = append(quotedArgs, shellescape.Quote(arg))
+	}
+	cmd := fmt.Sprintf("%s --config-path %s %s", rocketpool.RemoteShellPath(cliPath), rocketpool.RemoteShellPath(node.ConfigPath), strings.Join(quotedArgs, " "))
+
+	return runRemoteSession(session, cmd, stdin, stdout, os.Stderr)
+}
+
+// Run a command in an SSH session, only forwarding input to it while it runs so the next node's prompts still get theirs
+func runRemoteSession(session *ssh.Session, cmd string, stdin *stdinReader, stdout io.Writer, stderr io.Writer) error {
+	stdinPipe, err := session.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("error getting the session's input: %w", err)
+	}
+	session.Stdout = stdout
+	session.Stderr = stderr
+	if err := session.Start(cmd); err != nil {
+		return fmt.Errorf("error starting the command: %w", err)
+	}
+
+	stopForwarding := stdin.forward(stdinPipe)
+	err = session.Wait()
+	stopForwarding()
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("exited with code %d", exitErr.ExitStatus())
+	}
+	return err
+}
+
+// Join a list of node names for the summary
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
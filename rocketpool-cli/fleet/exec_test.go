@@ -0,0 +1,148 @@
+package fleet
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Start an SSH server on localhost whose commands print the first line of their input and exit, returning its address
+func startSshServer(t *testing.T) string {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSshConnection(conn, config)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// Serve the sessions of an SSH connection
+func serveSshConnection(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			for request := range channelRequests {
+				if request.Type != "exec" {
+					_ = request.Reply(false, nil)
+					continue
+				}
+				_ = request.Reply(true, nil)
+				go func() {
+					line, _ := bufio.NewReader(channel).ReadString('\n')
+					fmt.Fprintf(channel, "got %s", line)
+					_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+					channel.Close()
+				}()
+			}
+		}()
+	}
+}
+
+func TestRemoteSessionsInARow(t *testing.T) {
+	client, err := ssh.Dial("tcp", startSshServer(t), &ssh.ClientConfig{
+		User:            "test",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	input, user := io.Pipe()
+	stdin := newStdinReader(input)
+
+	// Run a session while the user types a line for it
+	runSession := func(line string) string {
+		session, err := client.NewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer session.Close()
+		go func() {
+			_, _ = user.Write([]byte(line))
+		}()
+		var sessionOutput bytes.Buffer
+		if err := runRemoteSession(session, "rocketpool node status", stdin, &sessionOutput, io.Discard); err != nil {
+			t.Fatal(err)
+		}
+		return sessionOutput.String()
+	}
+
+	if sessionOutput := runSession("first\n"); sessionOutput != "got first\n" {
+		t.Fatalf("expected the first session to get its input, got %q", sessionOutput)
+	}
+
+	// The first session left a read waiting for input, so the answer to the next prompt has to come through it
+	go func() {
+		_, _ = user.Write([]byte("y\n"))
+	}()
+	if !cliutils.ConfirmFrom(stdin, "Run the command on the next node?") {
+		t.Fatal("expected the prompt to get the user's answer")
+	}
+
+	if sessionOutput := runSession("second\n"); sessionOutput != "got second\n" {
+		t.Fatalf("expected the second session to get its input, got %q", sessionOutput)
+	}
+}
+
+func TestStdinReaderKeepsUnwrittenInput(t *testing.T) {
+	input, user := io.Pipe()
+	stdin := newStdinReader(input)
+
+	// A command that has already exited can't take the input, so it's kept for the next reader
+	closedEnd, destination := io.Pipe()
+	closedEnd.Close()
+	stopForwarding := stdin.forward(destination)
+	if _, err := user.Write([]byte("y\n")); err != nil {
+		t.Fatal(err)
+	}
+	stopForwarding()
+	if stdin.isIdle() {
+		t.Fatal("expected the unwritten input to be pending")
+	}
+	buffer := make([]byte, 16)
+	n, err := stdin.Read(buffer)
+	if err != nil || string(buffer[:n]) != "y\n" {
+		t.Fatalf("expected the unwritten input, got %q (%v)", buffer[:n], err)
+	}
+	if !stdin.isIdle() {
+		t.Error("expected the reader to be idle once the input was read")
+	}
+}
@@ -0,0 +1,225 @@
+package fleet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The file the fleet's node profiles are stored in, under the CLI's config path
+const fleetFile string = "fleet.yml"
+
+// The format of a valid profile name
+var profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// A node managed by the fleet commands
+type nodeProfile struct {
+	Name         string `yaml:"name" json:"name"`
+	ConfigPath   string `yaml:"configPath" json:"configPath"`
+	Host         string `yaml:"host,omitempty" json:"host,omitempty"`
+	IdentityFile string `yaml:"identityFile,omitempty" json:"identityFile,omitempty"`
+	CliPath      string `yaml:"cliPath,omitempty" json:"cliPath,omitempty"`
+}
+
+// The set of node profiles
+type fleetConfig struct {
+	Nodes []nodeProfile `yaml:"nodes"`
+}
+
+// Check if the node is on a remote host
+func (p nodeProfile) isRemote() bool {
+	return p.Host != ""
+}
+
+// Get a description of where the node is
+func (p nodeProfile) location() string {
+	if p.isRemote() {
+		return fmt.Sprintf("%s:%s", p.Host, p.ConfigPath)
+	}
+	return p.ConfigPath
+}
+
+// Get the path of the fleet file
+func getFleetFilePath(c *cli.Context) (string, error) {
+	configPath, err := homedir.Expand(os.ExpandEnv(c.GlobalString("config-path")))
+	if err != nil {
+		return "", fmt.Errorf("error expanding config path: %w", err)
+	}
+	return filepath.Join(configPath, fleetFile), nil
+}
+
+// Load the fleet's node profiles; a missing file is an empty fleet
+func loadFleet(c *cli.Context) (*fleetConfig, error) {
+	path, err := getFleetFilePath(c)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &fleetConfig{Nodes: []nodeProfile{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading fleet file %s: %w", path, err)
+	}
+
+	fleet := &fleetConfig{}
+	if err := yaml.Unmarshal(bytes, fleet); err != nil {
+		return nil, fmt.Errorf("error parsing fleet file %s: %w", path, err)
+	}
+	return fleet, nil
+}
+
+// Save the fleet's node profiles
+func saveFleet(c *cli.Context, fleet *fleetConfig) error {
+	path, err := getFleetFilePath(c)
+	if err != nil {
+		return err
+	}
+	bytes, err := yaml.Marshal(fleet)
+	if err != nil {
+		return fmt.Errorf("error serializing fleet: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("error saving fleet file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the profile with the given name
+func (f *fleetConfig) getNode(name string) (int, *nodeProfile) {
+	for i := range f.Nodes {
+		if f.Nodes[i].Name == name {
+			return i, &f.Nodes[i]
+		}
+	}
+	return -1, nil
+}
+
+// Get the profiles selected by a comma-separated list of names, or all of them if the list is empty
+func (f *fleetConfig) selectNodes(names string) ([]nodeProfile, error) {
+	if len(f.Nodes) == 0 {
+		return nil, fmt.Errorf("There are no nodes in the fleet yet. Add one with `rocketpool fleet add`.")
+	}
+	if strings.TrimSpace(names) == "" {
+		return f.Nodes, nil
+	}
+
+	nodes := []nodeProfile{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		_, node := f.getNode(name)
+		if node == nil {
+			return nil, fmt.Errorf("There is no node named '%s' in the fleet.", name)
+		}
+		nodes = append(nodes, *node)
+	}
+	return nodes, nil
+}
+
+func addNode(c *cli.Context, name string) error {
+
+	// Validate the profile
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("Invalid node name '%s': it can only contain letters, numbers, '.', '_' and '-'", name)
+	}
+	profile := nodeProfile{
+		Name:         name,
+		ConfigPath:   c.String("config-path"),
+		Host:         c.String("host"),
+		IdentityFile: c.String("identity-file"),
+		CliPath:      c.String("cli-path"),
+	}
+	if !profile.isRemote() && profile.IdentityFile != "" {
+		return fmt.Errorf("An identity file can only be used with a remote node; please set --host too.")
+	}
+	if profile.isRemote() {
+		if _, _, _, err := parseHost(profile.Host); err != nil {
+			return err
+		}
+	}
+
+	// Add it to the fleet
+	fleet, err := loadFleet(c)
+	if err != nil {
+		return err
+	}
+	if _, existing := fleet.getNode(name); existing != nil {
+		return fmt.Errorf("There is already a node named '%s' in the fleet.", name)
+	}
+	fleet.Nodes = append(fleet.Nodes, profile)
+	if err := saveFleet(c, fleet); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Added node '%s' (%s) to the fleet.\n", name, profile.location())
+	if profile.isRemote() {
+		fmt.Println("Run `rocketpool fleet status` to check that it can be reached.")
+	}
+	return nil
+
+}
+
+func removeNode(c *cli.Context, name string) error {
+
+	// Remove the node
+	fleet, err := loadFleet(c)
+	if err != nil {
+		return err
+	}
+	index, node := fleet.getNode(name)
+	if node == nil {
+		return fmt.Errorf("There is no node named '%s' in the fleet.", name)
+	}
+	fleet.Nodes = append(fleet.Nodes[:index], fleet.Nodes[index+1:]...)
+	if err := saveFleet(c, fleet); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Removed node '%s' from the fleet.\n", name)
+	return nil
+
+}
+
+func listNodes(c *cli.Context) error {
+
+	// Load the fleet
+	fleet, err := loadFleet(c)
+	if err != nil {
+		return err
+	}
+	output.Set("nodes", fleet.Nodes)
+	if len(fleet.Nodes) == 0 {
+		fmt.Println("There are no nodes in the fleet yet. Add one with `rocketpool fleet add`.")
+		return nil
+	}
+
+	// Print the profiles
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tHOST\tCONFIG PATH\tIDENTITY FILE")
+	for _, node := range fleet.Nodes {
+		host := node.Host
+		if host == "" {
+			host = "(local)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", node.Name, host, node.ConfigPath, node.IdentityFile)
+	}
+	return w.Flush()
+
+}
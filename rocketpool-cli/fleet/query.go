@@ -0,0 +1,114 @@
+package fleet
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Connect to each of the selected nodes.
+// This is done one node at a time since connecting may prompt for an SSH key passphrase.
+// A node that can't be reached gets a nil client and an error instead of aborting the whole fleet.
+func connectNodes(c *cli.Context) ([]nodeProfile, []*rocketpool.Client, []error, error) {
+	fleet, err := loadFleet(c)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nodes, err := fleet.selectNodes(c.String("nodes"))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	clients := make([]*rocketpool.Client, len(nodes))
+	errs := make([]error, len(nodes))
+	for i, node := range nodes {
+		clients[i], errs[i] = getNodeClient(c, node)
+	}
+	return nodes, clients, errs, nil
+}
+
+// Run a query against every selected node in parallel, returning the results in the fleet's order
+func queryNodes[T any](c *cli.Context, query func(node nodeProfile, rp *rocketpool.Client) (T, error)) ([]nodeProfile, []T, []error, error) {
+	nodes, clients, errs, err := connectNodes(c)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	results := make([]T, len(nodes))
+	var wg sync.WaitGroup
+	for i := range nodes {
+		if errs[i] != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer clients[i].Close()
+			results[i], errs[i] = query(nodes[i], clients[i])
+		}(i)
+	}
+	wg.Wait()
+	return nodes, results, errs, nil
+}
+
+// Check if a node's clients can serve API calls, using its fallbacks if its primary clients aren't ready.
+// Unlike WithReady, this doesn't print anything so the output of several nodes doesn't get mixed together.
+func checkNodeClients(rp *rocketpool.Client) (api.ClientStatusResponse, bool, error) {
+	status, err := rp.GetClientStatus()
+	if err != nil {
+		return status, false, err
+	}
+	ec := status.EcManagerStatus
+	bc := status.BcManagerStatus
+	if ec.PrimaryClientStatus.IsSynced && bc.PrimaryClientStatus.IsSynced {
+		rp.SetClientStatusFlags(true, false)
+		return status, true, nil
+	}
+	if ec.FallbackEnabled && bc.FallbackEnabled && ec.FallbackClientStatus.IsSynced && bc.FallbackClientStatus.IsSynced {
+		rp.SetClientStatusFlags(true, true)
+		return status, true, nil
+	}
+	return status, false, nil
+}
+
+// Get a short description of the state of a client manager
+func getClientSummary(status api.ClientManagerStatus) string {
+	if status.PrimaryClientStatus.IsSynced {
+		return "synced"
+	}
+	if status.FallbackEnabled && status.FallbackClientStatus.IsSynced {
+		return "fallback"
+	}
+	if status.PrimaryClientStatus.IsWorking {
+		return fmt.Sprintf("syncing (%.2f%%)", rocketpool.SyncRatioToPercent(status.PrimaryClientStatus.SyncProgress))
+	}
+	return "unavailable"
+}
+
+// Get the text of a node's error for a table, or a blank if it succeeded
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Get the error to exit with if any nodes failed, after all of the results have been printed
+func getFleetError(nodes []nodeProfile, errs []error) error {
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "%s%d of %d node(s) could not be queried.%s\n", colorYellow, failed, len(nodes), colorReset)
+	return fmt.Errorf("fleet query failed on %d node(s)", failed)
+}
@@ -0,0 +1,169 @@
+package fleet
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	defaultSshPort   int           = 22
+	sshDialTimeout   time.Duration = 15 * time.Second
+	knownHostsPath   string        = "~/.ssh/known_hosts"
+	sshAuthSocketEnv string        = "SSH_AUTH_SOCK"
+)
+
+// The keys to try when a profile doesn't have an identity file and there's no SSH agent
+var defaultIdentityFiles = []string{"~/.ssh/id_ed25519", "~/.ssh/id_ecdsa", "~/.ssh/id_rsa"}
+
+// Parse an SSH destination in the form [user@]host[:port]
+func parseHost(destination string) (string, string, int, error) {
+	username := ""
+	host := destination
+	if index := strings.LastIndex(destination, "@"); index >= 0 {
+		username = destination[:index]
+		host = destination[index+1:]
+	}
+	if username == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return "", "", 0, fmt.Errorf("error getting the current user for SSH host %s: %w", destination, err)
+		}
+		username = currentUser.Username
+	}
+
+	port := defaultSshPort
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		port, err = strconv.Atoi(p)
+		if err != nil || port <= 0 || port > 65535 {
+			return "", "", 0, fmt.Errorf("Invalid SSH port in '%s'", destination)
+		}
+		host = h
+	}
+	if host == "" {
+		return "", "", 0, fmt.Errorf("Invalid SSH destination '%s': it must be in the form [user@]host[:port]", destination)
+	}
+	return username, host, port, nil
+}
+
+// Get the ways to authenticate with a remote node
+func getAuthMethods(profile nodeProfile) ([]ssh.AuthMethod, error) {
+	methods := []ssh.AuthMethod{}
+
+	// Use the SSH agent if there is one
+	if socket := os.Getenv(sshAuthSocketEnv); socket != "" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	// Use the profile's identity file, or the default ones if there's no agent
+	identityFiles := []string{}
+	if profile.IdentityFile != "" {
+		identityFiles = append(identityFiles, profile.IdentityFile)
+	} else if len(methods) == 0 {
+		identityFiles = defaultIdentityFiles
+	}
+	signers := []ssh.Signer{}
+	for _, identityFile := range identityFiles {
+		path, err := homedir.Expand(identityFile)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding identity file path %s: %w", identityFile, err)
+		}
+		keyBytes, err := os.ReadFile(path)
+		if err != nil {
+			if profile.IdentityFile == "" && os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading identity file %s: %w", path, err)
+		}
+		signer, err := ssh.ParsePrivateKey(keyBytes)
+		var passphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseErr) {
+			passphrase := cliutils.PromptPassword(fmt.Sprintf("Please enter the passphrase for %s (node '%s'):", path, profile.Name), "^.*$", "")
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(keyBytes, []byte(passphrase))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error loading identity file %s: %w", path, err)
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no SSH credentials found for node '%s'; start an SSH agent or set an identity file with `rocketpool fleet add --identity-file`", profile.Name)
+	}
+	return methods, nil
+}
+
+// Connect to a remote node over SSH, checking its host key against the user's known hosts
+func connectNode(profile nodeProfile) (*ssh.Client, error) {
+	username, host, port, err := parseHost(profile.Host)
+	if err != nil {
+		return nil, err
+	}
+	authMethods, err := getAuthMethods(profile)
+	if err != nil {
+		return nil, err
+	}
+	knownHostsFile, err := homedir.Expand(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding known hosts path: %w", err)
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading known hosts from %s (connect to the node with ssh once to add its host key): %w", knownHostsFile, err)
+	}
+
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            username,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	})
+	if err != nil {
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return nil, fmt.Errorf("the host key of %s is not in %s; connect to it with ssh once to verify and add it", address, knownHostsFile)
+		}
+		return nil, fmt.Errorf("error connecting to %s: %w", address, err)
+	}
+	return client, nil
+}
+
+// Get a Rocket Pool client for a node in the fleet.
+// Nodes on this machine use the local filesystem and Docker, remote ones run everything over SSH.
+func getNodeClient(c *cli.Context, profile nodeProfile) (*rocketpool.Client, error) {
+	if !profile.isRemote() {
+		configPath, err := homedir.Expand(os.ExpandEnv(profile.ConfigPath))
+		if err != nil {
+			return nil, fmt.Errorf("error expanding config path %s: %w", profile.ConfigPath, err)
+		}
+		return rocketpool.NewClientForNode(c, filepath.Clean(configPath), nil), nil
+	}
+
+	sshClient, err := connectNode(profile)
+	if err != nil {
+		return nil, err
+	}
+	return rocketpool.NewClientForNode(c, profile.ConfigPath, sshClient), nil
+}
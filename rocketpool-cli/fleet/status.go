@@ -0,0 +1,301 @@
+package fleet
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// Color codes
+const (
+	colorReset  string = "\033[0m"
+	colorYellow string = "\033[33m"
+)
+
+// A summary of one node's status
+type nodeStatus struct {
+	Name             string          `json:"name"`
+	Location         string          `json:"location"`
+	Address          *common.Address `json:"address,omitempty"`
+	Registered       bool            `json:"registered"`
+	EthBalance       float64         `json:"ethBalance"`
+	RplBalance       float64         `json:"rplBalance"`
+	RplStake         float64         `json:"rplStake"`
+	BorrowedRatio    float64         `json:"borrowedCollateralRatio"`
+	Minipools        int             `json:"minipools"`
+	StakingMinipools int             `json:"stakingMinipools"`
+	ExecutionClient  string          `json:"executionClient"`
+	ConsensusClient  string          `json:"consensusClient"`
+	ActiveAlerts     int             `json:"activeAlerts"`
+	Error            string          `json:"error,omitempty"`
+}
+
+// A minipool of a node in the fleet
+type fleetMinipool struct {
+	Node           string         `json:"node"`
+	Address        common.Address `json:"address"`
+	Status         string         `json:"status"`
+	Bond           float64        `json:"bond"`
+	Fee            float64        `json:"fee"`
+	ValidatorIndex string         `json:"validatorIndex"`
+	Balance        float64        `json:"balance"`
+	Finalised      bool           `json:"finalised"`
+}
+
+// The minipools of one node
+type nodeMinipools struct {
+	Name      string          `json:"name"`
+	Location  string          `json:"location"`
+	Minipools []fleetMinipool `json:"minipools"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// The versions running on one node
+type nodeVersion struct {
+	Name            string `json:"name"`
+	Location        string `json:"location"`
+	Network         string `json:"network"`
+	ServiceVersion  string `json:"serviceVersion"`
+	ExecutionClient string `json:"executionClient"`
+	ConsensusClient string `json:"consensusClient"`
+	Error           string `json:"error,omitempty"`
+}
+
+// Convert an optional wei amount to ETH
+func weiToEth(wei *big.Int) float64 {
+	if wei == nil {
+		return 0
+	}
+	return eth.WeiToEth(wei)
+}
+
+func getStatus(c *cli.Context) error {
+
+	// Query every node
+	nodes, results, errs, err := queryNodes(c, func(node nodeProfile, rp *rocketpool.Client) (nodeStatus, error) {
+		status := nodeStatus{}
+		clientStatus, ready, err := checkNodeClients(rp)
+		if err != nil {
+			return status, err
+		}
+		status.ExecutionClient = getClientSummary(clientStatus.EcManagerStatus)
+		status.ConsensusClient = getClientSummary(clientStatus.BcManagerStatus)
+		if !ready {
+			return status, fmt.Errorf("clients not ready")
+		}
+
+		nodeStatusResponse, err := rp.NodeStatus()
+		if err != nil {
+			return status, err
+		}
+		address := nodeStatusResponse.AccountAddress
+		status.Address = &address
+		status.Registered = nodeStatusResponse.Registered
+		status.EthBalance = weiToEth(nodeStatusResponse.AccountBalances.ETH)
+		status.RplBalance = weiToEth(nodeStatusResponse.AccountBalances.RPL)
+		status.RplStake = weiToEth(nodeStatusResponse.RplStake)
+		status.BorrowedRatio = nodeStatusResponse.BorrowedCollateralRatio
+		status.Minipools = nodeStatusResponse.MinipoolCounts.Total - nodeStatusResponse.MinipoolCounts.Finalised
+		status.StakingMinipools = nodeStatusResponse.MinipoolCounts.Staking
+		for _, alert := range nodeStatusResponse.Alerts {
+			if alert.IsActive() {
+				status.ActiveAlerts++
+			}
+		}
+		return status, nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range nodes {
+		results[i].Name = nodes[i].Name
+		results[i].Location = nodes[i].location()
+		results[i].Error = errorText(errs[i])
+	}
+
+	// Print the results
+	if output.IsStructured() {
+		output.Set("nodes", results)
+		return getFleetError(nodes, errs)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tADDRESS\tETH\tRPL\tRPL STAKED\tBORROWED RATIO\tMINIPOOLS\tEC\tCC\tALERTS\tERROR")
+	for _, status := range results {
+		if status.Address == nil {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\t%s\t%s\t-\t%s\n", status.Name, orDash(status.ExecutionClient), orDash(status.ConsensusClient), status.Error)
+			continue
+		}
+		address := status.Address.Hex()
+		if !status.Registered {
+			address += " (unregistered)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.4f\t%.2f\t%.2f\t%.2f%%\t%d (%d staking)\t%s\t%s\t%d\t%s\n",
+			status.Name,
+			address,
+			status.EthBalance,
+			status.RplBalance,
+			status.RplStake,
+			status.BorrowedRatio*100,
+			status.Minipools,
+			status.StakingMinipools,
+			status.ExecutionClient,
+			status.ConsensusClient,
+			status.ActiveAlerts,
+			status.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return getFleetError(nodes, errs)
+
+}
+
+func getMinipoolStatus(c *cli.Context) error {
+
+	// Query every node
+	nodes, results, errs, err := queryNodes(c, func(node nodeProfile, rp *rocketpool.Client) (nodeMinipools, error) {
+		result := nodeMinipools{Minipools: []fleetMinipool{}}
+		if _, ready, err := checkNodeClients(rp); err != nil {
+			return result, err
+		} else if !ready {
+			return result, fmt.Errorf("clients not ready")
+		}
+		response, err := rp.MinipoolStatus()
+		if err != nil {
+			return result, err
+		}
+		for _, mp := range response.Minipools {
+			result.Minipools = append(result.Minipools, fleetMinipool{
+				Node:           node.Name,
+				Address:        mp.Address,
+				Status:         mp.Status.Status.String(),
+				Bond:           weiToEth(mp.Node.DepositBalance),
+				Fee:            mp.Node.Fee,
+				ValidatorIndex: mp.Validator.Index,
+				Balance:        weiToEth(mp.Validator.Balance),
+				Finalised:      mp.Finalised,
+			})
+		}
+		return result, nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range nodes {
+		results[i].Name = nodes[i].Name
+		results[i].Location = nodes[i].location()
+		results[i].Error = errorText(errs[i])
+	}
+
+	// Print the results
+	if output.IsStructured() {
+		output.Set("nodes", results)
+		return getFleetError(nodes, errs)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tMINIPOOL\tSTATUS\tBOND\tFEE\tVALIDATOR\tBALANCE\tERROR")
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\t%s\n", result.Name, result.Error)
+			continue
+		}
+		if len(result.Minipools) == 0 {
+			fmt.Fprintf(w, "%s\t(none)\t\t\t\t\t\t\n", result.Name)
+			continue
+		}
+		for _, mp := range result.Minipools {
+			status := mp.Status
+			if mp.Finalised {
+				status += " (finalised)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%.0f ETH\t%.2f%%\t%s\t%.6f ETH\t\n", mp.Node, mp.Address.Hex(), status, mp.Bond, mp.Fee*100, orDash(mp.ValidatorIndex), mp.Balance)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return getFleetError(nodes, errs)
+
+}
+
+func getServiceVersion(c *cli.Context) error {
+
+	// Query every node
+	nodes, results, errs, err := queryNodes(c, func(node nodeProfile, rp *rocketpool.Client) (nodeVersion, error) {
+		result := nodeVersion{}
+		cfg, isNew, err := rp.LoadConfig()
+		if err != nil {
+			return result, err
+		}
+		if isNew {
+			return result, fmt.Errorf("the node has not been configured yet")
+		}
+		result.Network = string(cfg.GetNetwork())
+
+		if cfg.IsNativeMode {
+			result.ExecutionClient = "native mode"
+			result.ConsensusClient = "native mode"
+		} else {
+			result.ExecutionClient = "external"
+			if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+				if tag, err := cfg.GetECContainerTag(); err == nil {
+					result.ExecutionClient = tag
+				}
+			}
+			result.ConsensusClient = "external"
+			if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+				if tag, err := cfg.GetBeaconContainerTag(); err == nil {
+					result.ConsensusClient = tag
+				}
+			}
+		}
+
+		result.ServiceVersion, err = rp.GetServiceVersion()
+		return result, err
+	})
+	if err != nil {
+		return err
+	}
+	for i := range nodes {
+		results[i].Name = nodes[i].Name
+		results[i].Location = nodes[i].location()
+		results[i].Error = errorText(errs[i])
+	}
+
+	// Print the results
+	if output.IsStructured() {
+		output.Set("nodes", results)
+		return getFleetError(nodes, errs)
+	}
+
+	fmt.Printf("Rocket Pool client version: %s\n\n", c.App.Version)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tNETWORK\tSERVICE\tEXECUTION CLIENT\tCONSENSUS CLIENT\tERROR")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", result.Name, orDash(result.Network), orDash(result.ServiceVersion), orDash(result.ExecutionClient), orDash(result.ConsensusClient), result.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return getFleetError(nodes, errs)
+
+}
+
+// Replace a blank table cell with a dash
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
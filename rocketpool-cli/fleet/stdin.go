@@ -0,0 +1,141 @@
+package fleet
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Returned by stdin reads that were stopped before any input arrived
+var errStdinStopped = errors.New("stopped reading stdin")
+
+// The result of a read from the source
+type stdinChunk struct {
+	data []byte
+	err  error
+}
+
+// Reads this terminal's input on behalf of every node the fleet command runs on.
+// A read from the terminal can't be cancelled, so when a node's command finishes while a read is still waiting,
+// that read is kept here and whatever it returns goes to the next reader (a prompt or the next node) instead of being lost.
+type stdinReader struct {
+	source  io.Reader
+	chunks  chan stdinChunk
+	lock    sync.Mutex
+	pending []byte
+	err     error
+	reading bool
+}
+
+// Create a reader for the given source, which should only be read through it from now on
+func newStdinReader(source io.Reader) *stdinReader {
+	return &stdinReader{
+		source: source,
+		chunks: make(chan stdinChunk, 1),
+	}
+}
+
+// Read input, waiting for it as long as it takes
+func (r *stdinReader) Read(p []byte) (int, error) {
+	return r.read(p, nil)
+}
+
+// Read input, waiting for it until stop is closed
+func (r *stdinReader) read(p []byte, stop <-chan struct{}) (int, error) {
+	for {
+		r.lock.Lock()
+		if len(r.pending) > 0 {
+			n := copy(p, r.pending)
+			r.pending = r.pending[n:]
+			r.lock.Unlock()
+			return n, nil
+		}
+		if r.err != nil {
+			err := r.err
+			r.lock.Unlock()
+			return 0, err
+		}
+		if !r.reading {
+			r.reading = true
+			go r.readSource()
+		}
+		r.lock.Unlock()
+
+		// Keep what the source returns even if this read was stopped in the meantime, so the next one gets it
+		select {
+		case chunk := <-r.chunks:
+			r.lock.Lock()
+			r.reading = false
+			r.pending = append(r.pending, chunk.data...)
+			r.err = chunk.err
+			r.lock.Unlock()
+		case <-stop:
+			return 0, errStdinStopped
+		}
+		select {
+		case <-stop:
+			return 0, errStdinStopped
+		default:
+		}
+	}
+}
+
+// Put input back so the next read gets it
+func (r *stdinReader) unread(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pending = append(append([]byte{}, data...), r.pending...)
+}
+
+// Check if there's no input waiting to be read and no read from the source is in progress,
+// in which case a command can be given the source directly
+func (r *stdinReader) isIdle() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.pending) == 0 && !r.reading
+}
+
+// Make a single read from the source
+func (r *stdinReader) readSource() {
+	buffer := make([]byte, 4096)
+	n, err := r.source.Read(buffer)
+	r.chunks <- stdinChunk{
+		data: buffer[:n],
+		err:  err,
+	}
+}
+
+// Copy input to a command until the returned function is called, which waits for the copy to stop.
+// The destination is closed once the input ends; anything that couldn't be written to it is kept for the next reader.
+func (r *stdinReader) forward(destination io.WriteCloser) func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		buffer := make([]byte, 4096)
+		for {
+			n, err := r.read(buffer, stop)
+			if n > 0 {
+				written, writeErr := destination.Write(buffer[:n])
+				if writeErr != nil {
+					r.unread(buffer[written:n])
+					return
+				}
+			}
+			if errors.Is(err, errStdinStopped) {
+				return
+			}
+			if err != nil {
+				_ = destination.Close()
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
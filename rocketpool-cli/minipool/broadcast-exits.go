@@ -0,0 +1,183 @@
+package minipool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// How many times to ask for a bundle's password before giving up
+const signedExitBundlePasswordAttempts int = 3
+
+func broadcastExits(c *cli.Context, path string) error {
+
+	// Load the signed exits
+	exits, err := loadSignedExits(path)
+	if err != nil {
+		return err
+	}
+	if len(exits) == 0 {
+		fmt.Printf("No signed exits were found in %s.\n", path)
+		return nil
+	}
+
+	// Print the exits
+	fmt.Printf("Found %d signed exit(s):\n", len(exits))
+	for _, exit := range exits {
+		if exit.Minipool != (common.Address{}) {
+			fmt.Printf("\tValidator %s (minipool %s)\n", exit.Exit.Message.ValidatorIndex, exit.Minipool.Hex())
+		} else {
+			fmt.Printf("\tValidator %s\n", exit.Exit.Message.ValidatorIndex)
+		}
+	}
+	fmt.Println()
+
+	// Show a warning message
+	fmt.Printf("%sNOTE:\n", colorYellow)
+	fmt.Println("You are about to exit these validators. This will tell each one to stop all activities on the Beacon Chain.")
+	fmt.Printf("Please continue to run your validators until each one you've exited has been processed by the exit queue.%s\n\n", colorReset)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("Are you sure you want to broadcast %d exit(s)? This action cannot be undone!", len(exits)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the broadcaster; a Beacon node URL lets this run on a machine without the Smartnode
+	var broadcast func(validatorIndex string, epoch string, signature string) error
+	if beaconUrl := c.String("beacon-url"); beaconUrl != "" {
+		bc := client.NewStandardHttpClient(beaconUrl)
+		broadcast = func(validatorIndex string, epoch string, signature string) error {
+			epochNum, err := cliutils.ValidateUint("epoch", epoch)
+			if err != nil {
+				return err
+			}
+			sig, err := cliutils.ValidateValidatorSignature("signature", signature)
+			if err != nil {
+				return err
+			}
+			return bc.ExitValidator(validatorIndex, epochNum, sig)
+		}
+	} else {
+		rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+		if err != nil {
+			return err
+		}
+		defer rp.Close()
+		broadcast = func(validatorIndex string, epoch string, signature string) error {
+			_, err := rp.BroadcastExit(validatorIndex, epoch, signature)
+			return err
+		}
+	}
+
+	// Broadcast the exits
+	for _, exit := range exits {
+		message := exit.Exit.Message
+		if err := broadcast(message.ValidatorIndex, message.Epoch, exit.Exit.Signature); err != nil {
+			fmt.Printf("Could not broadcast the exit for validator %s: %s.\n", message.ValidatorIndex, err)
+		} else {
+			fmt.Printf("Successfully broadcast the exit for validator %s.\n", message.ValidatorIndex)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Load the signed exits from a file or a folder of files, decrypting any bundles
+func loadSignedExits(path string) ([]api.SignedMinipoolExit, error) {
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	filenames := []string{path}
+	if info.IsDir() {
+		filenames, err = filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("error listing the files in %s: %w", path, err)
+		}
+	}
+
+	exits := []api.SignedMinipoolExit{}
+	for _, filename := range filenames {
+		if strings.HasSuffix(filename, signedExitMetadataSuffix) {
+			continue
+		}
+		fileExits, err := loadSignedExitFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		exits = append(exits, fileExits...)
+	}
+	return exits, nil
+
+}
+
+// Load the signed exits from a single file, which can be an encrypted bundle or a standard Beacon API exit
+func loadSignedExitFile(filename string) ([]api.SignedMinipoolExit, error) {
+
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", filename, err)
+	}
+
+	// Check for an encrypted bundle
+	var bundle signedExitBundle
+	if err := json.Unmarshal(bytes, &bundle); err == nil && bundle.Crypto != nil {
+		if bundle.Version != signedExitBundleVersion {
+			return nil, fmt.Errorf("signed exit bundle %s has unsupported version %d", filename, bundle.Version)
+		}
+		for attempt := 1; attempt <= signedExitBundlePasswordAttempts; attempt++ {
+			password := cliutils.PromptPassword(fmt.Sprintf("Please enter the password for the signed exit bundle %s:", filename), "^.*$", "")
+			exitBytes, err := eth2ks.New().Decrypt(bundle.Crypto, password)
+			if err != nil {
+				fmt.Printf("Could not decrypt the bundle: %s\n", err.Error())
+				continue
+			}
+			var exits []api.SignedMinipoolExit
+			if err := json.Unmarshal(exitBytes, &exits); err != nil {
+				return nil, fmt.Errorf("error deserializing the signed exits in bundle %s: %w", filename, err)
+			}
+			return exits, nil
+		}
+		return nil, fmt.Errorf("could not decrypt the signed exit bundle %s after %d attempts", filename, signedExitBundlePasswordAttempts)
+	}
+
+	// Otherwise it's a standard Beacon API exit
+	var exit api.SignedMinipoolExit
+	if err := json.Unmarshal(bytes, &exit.Exit); err != nil {
+		return nil, fmt.Errorf("error deserializing signed exit %s: %w", filename, err)
+	}
+	if exit.Exit.Signature == "" || exit.Exit.Message.ValidatorIndex == "" {
+		return nil, fmt.Errorf("%s is not a signed exit", filename)
+	}
+
+	// Add the minipool details from its sidecar file if there is one
+	metadataBytes, err := os.ReadFile(getSignedExitMetadataPath(filename))
+	if err == nil {
+		var metadata signedExitMetadata
+		if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+			return nil, fmt.Errorf("error deserializing the details for signed exit %s: %w", filename, err)
+		}
+		exit.Minipool = metadata.Minipool
+		exit.Pubkey = metadata.Pubkey
+		exit.Domain = metadata.Domain
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading the details for signed exit %s: %w", filename, err)
+	}
+	return []api.SignedMinipoolExit{exit}, nil
+
+}
@@ -358,6 +358,78 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-signed-exits",
+				Aliases:   []string{"xe"},
+				Usage:     "Sign voluntary exits for staking minipools and save them to disk without broadcasting them, so they can be submitted later",
+				UsageText: "rocketpool minipool export-signed-exits [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm exporting the signed exits",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to export signed exits for (address or 'all')",
+					},
+					cli.StringFlag{
+						Name:  "output-dir, o",
+						Usage: "The folder to save the signed exits to",
+						Value: "signed-exits",
+					},
+					cli.BoolFlag{
+						Name:  "encrypt, e",
+						Usage: "Save the signed exits to a single bundle encrypted with a password instead of one plain file per validator",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return exportSignedExits(c)
+
+				},
+			},
+
+			{
+				Name:      "broadcast-exits",
+				Aliases:   []string{"be"},
+				Usage:     "Broadcast previously exported signed exits to the Beacon Chain; this doesn't need the node wallet",
+				UsageText: "rocketpool minipool broadcast-exits [options] path",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm broadcasting the exits",
+					},
+					cli.StringFlag{
+						Name:  "beacon-url, b",
+						Usage: "The URL of a Beacon node to broadcast the exits to directly, instead of using the Smartnode's",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return broadcastExits(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "close",
 				Aliases:   []string{"c"},
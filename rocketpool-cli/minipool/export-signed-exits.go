@@ -0,0 +1,243 @@
+package minipool
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The name of the encrypted signed exit bundle in the output folder
+const signedExitBundleFilename string = "signed-exits-bundle.json"
+
+// The version of the encrypted signed exit bundle format
+const signedExitBundleVersion int = 1
+
+// The name of each validator's signed exit file in the output folder, and the suffix of the sidecar file with its minipool details
+const (
+	signedExitFilenameFormat string = "exit-%s.json"
+	signedExitMetadataSuffix string = ".metadata.json"
+)
+
+// An encrypted collection of signed exits; the crypto section uses the EIP-2335 keystore format
+type signedExitBundle struct {
+	Version int                    `json:"version"`
+	Crypto  map[string]interface{} `json:"crypto"`
+}
+
+// The details of a signed exit's minipool, which are saved next to the exit so the exit file itself stays in the standard format
+type signedExitMetadata struct {
+	Minipool common.Address        `json:"minipool"`
+	Pubkey   types.ValidatorPubkey `json:"pubkey"`
+	Domain   string                `json:"domain"`
+}
+
+func exportSignedExits(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	// Get minipools with a validator on the Beacon Chain
+	exportableMinipools := []api.MinipoolDetails{}
+	for _, minipool := range status.Minipools {
+		if minipool.Status.Status == types.Staking && minipool.Validator.Exists {
+			exportableMinipools = append(exportableMinipools, minipool)
+		}
+	}
+
+	// Check for exportable minipools
+	if len(exportableMinipools) == 0 {
+		fmt.Println("No minipools have a validator on the Beacon Chain that can be exited.")
+		return nil
+	}
+
+	// Get selected minipools
+	var selectedMinipools []api.MinipoolDetails
+	if c.String("minipool") == "" {
+
+		// Prompt for minipool selection
+		options := make([]string, len(exportableMinipools)+1)
+		options[0] = "All available minipools"
+		for mi, minipool := range exportableMinipools {
+			options[mi+1] = fmt.Sprintf("%s (validator %s, staking since %s)", minipool.Address.Hex(), minipool.Validator.Index, minipool.Status.StatusTime.Format(TimeFormat))
+		}
+		selected, _ := cliutils.Select("Please select a minipool to export a signed exit for:", options)
+
+		// Get minipools
+		if selected == 0 {
+			selectedMinipools = exportableMinipools
+		} else {
+			selectedMinipools = []api.MinipoolDetails{exportableMinipools[selected-1]}
+		}
+
+	} else {
+
+		// Get matching minipools
+		if c.String("minipool") == "all" {
+			selectedMinipools = exportableMinipools
+		} else {
+			selectedAddress := common.HexToAddress(c.String("minipool"))
+			for _, minipool := range exportableMinipools {
+				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+					selectedMinipools = []api.MinipoolDetails{minipool}
+					break
+				}
+			}
+			if selectedMinipools == nil {
+				return fmt.Errorf("The minipool %s does not have a validator that can be exited.", selectedAddress.Hex())
+			}
+		}
+
+	}
+
+	// Check the output folder
+	outputDir := c.String("output-dir")
+	err = os.MkdirAll(outputDir, 0700)
+	if err != nil {
+		return fmt.Errorf("error creating output folder [%s]: %w", outputDir, err)
+	}
+
+	// Show a warning message
+	fmt.Printf("%sNOTE:\n", colorYellow)
+	fmt.Println("Signed exits never expire. Anyone who has one can use it to exit your validator at any time, and this cannot be undone.")
+	fmt.Printf("Please store them as carefully as you store your node wallet, and only hand them to someone you trust completely.%s\n\n", colorReset)
+
+	// Get the bundle password
+	var password string
+	if c.Bool("encrypt") {
+		password = promptBundlePassword()
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to export signed exits for %d minipool(s) to %s?", len(selectedMinipools), outputDir))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign the exits
+	addresses := make([]common.Address, len(selectedMinipools))
+	for i, minipool := range selectedMinipools {
+		addresses[i] = minipool.Address
+	}
+	response, err := rp.GetSignedExits(addresses)
+	if err != nil {
+		return err
+	}
+
+	// Save them in an encrypted bundle
+	if c.Bool("encrypt") {
+		path := filepath.Join(outputDir, signedExitBundleFilename)
+		err = saveSignedExitBundle(path, response.Exits, password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Saved %d signed exit(s) to the encrypted bundle %s.\n", len(response.Exits), path)
+		fmt.Println("You'll need the bundle password to broadcast them with `rocketpool minipool broadcast-exits`.")
+		return nil
+	}
+
+	// Save them one file per validator in the standard SignedVoluntaryExit format that other tools accept, with the minipool details in a sidecar file
+	for _, exit := range response.Exits {
+		path := filepath.Join(outputDir, fmt.Sprintf(signedExitFilenameFormat, exit.Exit.Message.ValidatorIndex))
+		err = saveJsonFile(path, exit.Exit)
+		if err != nil {
+			return fmt.Errorf("error saving signed exit for minipool %s: %w", exit.Minipool.Hex(), err)
+		}
+		err = saveJsonFile(getSignedExitMetadataPath(path), signedExitMetadata{
+			Minipool: exit.Minipool,
+			Pubkey:   exit.Pubkey,
+			Domain:   exit.Domain,
+		})
+		if err != nil {
+			return fmt.Errorf("error saving signed exit details for minipool %s: %w", exit.Minipool.Hex(), err)
+		}
+		fmt.Printf("Saved the signed exit for minipool %s (validator %s) to %s.\n", exit.Minipool.Hex(), exit.Exit.Message.ValidatorIndex, path)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Prompt for the password to encrypt a signed exit bundle with
+func promptBundlePassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to encrypt the signed exit bundle with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("The password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm the password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+// Encrypt signed exits with a password and save them to a bundle file
+func saveSignedExitBundle(path string, exits []api.SignedMinipoolExit, password string) error {
+
+	exitBytes, err := json.Marshal(exits)
+	if err != nil {
+		return fmt.Errorf("error serializing signed exits: %w", err)
+	}
+	crypto, err := eth2ks.New(eth2ks.WithCipher("scrypt")).Encrypt(exitBytes, password)
+	if err != nil {
+		return fmt.Errorf("error encrypting signed exits: %w", err)
+	}
+	bundleBytes, err := json.MarshalIndent(signedExitBundle{
+		Version: signedExitBundleVersion,
+		Crypto:  crypto,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing signed exit bundle: %w", err)
+	}
+	err = os.WriteFile(path, bundleBytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error saving signed exit bundle to %s: %w", path, err)
+	}
+	return nil
+
+}
+
+// Get the path of the sidecar file with a signed exit's minipool details
+func getSignedExitMetadataPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + signedExitMetadataSuffix
+}
+
+// Save a value to a file as indented JSON that only the owner can read
+func saveJsonFile(path string, value interface{}) error {
+	bytes, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing %s: %w", path, err)
+	}
+	err = os.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -63,6 +64,16 @@ func getStatus(c *cli.Context) error {
 
 	}
 
+	// Values computed for machine-readable output
+	statusCounts := map[string]int{}
+	for statusName, minipools := range statusMinipools {
+		statusCounts[statusName] = len(minipools)
+	}
+	output.Set("statusCounts", statusCounts)
+	output.Set("finalisedCount", len(finalisedMinipools))
+	output.Set("refundableMinipools", getMinipoolAddresses(refundableMinipools))
+	output.Set("closeableMinipools", getMinipoolAddresses(closeableMinipools))
+
 	// Return if there aren't any minipools
 	if len(status.Minipools) == 0 {
 		fmt.Println("The node does not have any minipools yet.")
@@ -135,6 +146,15 @@ func getStatus(c *cli.Context) error {
 
 }
 
+// Get the addresses of a list of minipools
+func getMinipoolAddresses(minipools []api.MinipoolDetails) []common.Address {
+	addresses := make([]common.Address, 0, len(minipools))
+	for _, minipool := range minipools {
+		addresses = append(addresses, minipool.Address)
+	}
+	return addresses
+}
+
 func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Address) {
 
 	fmt.Printf("--------------------\n")
@@ -100,6 +100,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "index",
 						Usage: "The index of the rewards interval you want to generate the tree for",
 					},
+					cli.BoolFlag{
+						Name:  "record-fixture",
+						Usage: "Also record every Beacon and Execution client response used during generation into a fixture file, so the generation can be replayed offline in tests",
+					},
 					cli.BoolFlag{
 						Name:  "yes, y",
 						Usage: "Automatically confirm any questions about tree generation",
@@ -118,6 +122,42 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-rewards-tree",
+				Aliases:   []string{"v"},
+				Usage:     "Regenerate the rewards tree for a past interval and compare it against the canonical tree, node by node and minipool by minipool.\nExits with a non-zero code if the trees don't match.",
+				UsageText: "rocketpool network verify-rewards-tree [options] interval",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "wait, w",
+						Usage: "Wait for the verification to finish and print the results",
+					},
+					cli.BoolFlag{
+						Name:  "report-only, r",
+						Usage: "Print the results of the last verification of this interval without starting a new one",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm any questions about tree verification",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return verifyRewardsTree(c, index)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -72,9 +72,17 @@ func generateRewardsTree(c *cli.Context) error {
 	}
 
 	// Create the generation request
-	_, err = rp.GenerateRewardsTree(index)
-	if err != nil {
-		return err
+	if c.Bool("record-fixture") {
+		_, err = rp.RecordRewardsFixture(index)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("The client responses used during generation will be saved to %s.\n", cfg.Smartnode.GetRewardsFixturePath(index, false))
+	} else {
+		_, err = rp.GenerateRewardsTree(index)
+		if err != nil {
+			return err
+		}
 	}
 
 	fmt.Printf("Your request to generate the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
@@ -0,0 +1,165 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	colorRed string = "\033[31m"
+
+	verificationPollInterval time.Duration = 30 * time.Second
+)
+
+func verifyRewardsTree(c *cli.Context, index uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Just print the existing report if requested
+	if c.Bool("report-only") {
+		return printRewardsTreeVerification(rp, index)
+	}
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+
+	// Print archive node info
+	archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+	if archiveEcUrl == "" {
+		fmt.Printf("%sNOTE: in order to regenerate the Merkle rewards tree for a past rewards interval, you will likely need to have access to an Execution client with archival state.\nPlease specify the URL of an archive-capable EC in the Smartnode section of the `rocketpool service config` Terminal UI.%s\n\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("%sYou have an archive EC specified at [%s]. This will be used for tree generation.%s\n\n", colorGreen, archiveEcUrl, colorReset)
+	}
+
+	// Check if verification will work
+	canResponse, err := rp.CanVerifyRewardsTree(index)
+	if err != nil {
+		return err
+	}
+	if canResponse.CurrentIndex <= index {
+		return fmt.Errorf("The current active rewards period is interval %d. You cannot verify the tree for interval %d until the active interval is past it.", canResponse.CurrentIndex, index)
+	}
+
+	// Get the canonical tree to compare against
+	if !canResponse.TreeFileExists {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("You don't have the rewards tree for interval %d yet. Would you like to download it so it can be verified?", index))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		_, err = rp.DownloadRewardsFile(index)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Downloaded the rewards tree for interval %d.\n", index)
+	}
+
+	// Reuse the existing report if the user doesn't want a new one
+	if canResponse.ReportExists && !c.Bool("yes") && !cliutils.Confirm(fmt.Sprintf("You already have a verification report for interval %d. Would you like to run the verification again?", index)) {
+		return printRewardsTreeVerification(rp, index)
+	}
+
+	// Create the verification request
+	_, err = rp.VerifyRewardsTree(index)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Your request to verify the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
+
+	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the watchtower container now, so it starts verifying the tree immediately?") {
+		container := fmt.Sprintf("%s_watchtower", cfg.Smartnode.ProjectName.Value.(string))
+		response, err := rp.RestartContainer(container)
+		if err != nil {
+			return fmt.Errorf("Error restarting watchtower: %w", err)
+		}
+		if response != container {
+			return fmt.Errorf("Unexpected output while restarting watchtower: %s", response)
+		}
+		fmt.Println("Done!")
+	}
+
+	if !c.Bool("wait") {
+		fmt.Printf("Once the verification is complete, run %s`rocketpool network verify-rewards-tree --report-only %d`%s to see the results.\n", colorGreen, index, colorReset)
+		return nil
+	}
+
+	// Wait for the report
+	fmt.Println("Waiting for the verification to complete. This can take several hours...")
+	for {
+		response, err := rp.GetRewardsTreeVerification(index)
+		if err != nil {
+			return err
+		}
+		if response.ReportExists {
+			break
+		}
+		time.Sleep(verificationPollInterval)
+	}
+	return printRewardsTreeVerification(rp, index)
+
+}
+
+// Print the verification report for an interval, returning an error that exits with a non-zero code if the tree didn't match
+func printRewardsTreeVerification(rp *rocketpool.Client, index uint64) error {
+
+	response, err := rp.GetRewardsTreeVerification(index)
+	if err != nil {
+		return err
+	}
+	if !response.ReportExists {
+		fmt.Printf("There is no verification report for interval %d yet.\n", index)
+		return nil
+	}
+	report := response.Report
+
+	if report.GenerationError != "" {
+		return cli.NewExitError(fmt.Sprintf("%sThe rewards tree for interval %d could not be regenerated: %s%s", colorRed, index, report.GenerationError, colorReset), 1)
+	}
+
+	fmt.Printf("Interval %d verification (completed %s)\n", report.Index, report.VerifiedAt.Local().Format(time.RFC1123))
+	fmt.Printf("On-chain Merkle root:  %s\n", report.OnChainMerkleRoot)
+	fmt.Printf("Canonical Merkle root: %s\n", report.CanonicalMerkleRoot)
+	fmt.Printf("Generated Merkle root: %s\n\n", report.GeneratedMerkleRoot)
+
+	printRewardsDiffs("Node", report.NodeDifferences)
+	if report.MinipoolsCompared {
+		printRewardsDiffs("Minipool", report.MinipoolDifferences)
+	} else {
+		fmt.Println("The canonical minipool performance file was not available, so minipools were not compared.")
+	}
+	fmt.Printf("The full report has been saved to %s.\n\n", response.ReportPath)
+
+	if !report.Matches {
+		return cli.NewExitError(fmt.Sprintf("%sThe regenerated rewards tree for interval %d does NOT match the canonical tree.%s", colorRed, index, colorReset), 1)
+	}
+	fmt.Printf("%sThe regenerated rewards tree for interval %d matches the canonical tree and the on-chain Merkle root.%s\n", colorGreen, index, colorReset)
+	return nil
+
+}
+
+// Print the differences found for nodes or minipools
+func printRewardsDiffs(kind string, diffs []rprewards.RewardsDiffEntry) {
+	if len(diffs) == 0 {
+		fmt.Printf("%s rewards: no differences.\n\n", kind)
+		return
+	}
+	fmt.Printf("%s rewards: %d difference(s)\n", kind, len(diffs))
+	for _, diff := range diffs {
+		fmt.Printf("\t%s  %-22s canonical: %s, generated: %s\n", diff.Address.Hex(), diff.Field, diff.Canonical, diff.Generated)
+	}
+	fmt.Println()
+}
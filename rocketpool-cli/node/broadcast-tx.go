@@ -0,0 +1,66 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func broadcastTransaction(c *cli.Context, source string) error {
+
+	// Load the signed transaction
+	tx, from, err := rocketpool.LoadSignedTransaction(source)
+	if err != nil {
+		return err
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Print the transaction
+	fmt.Println("Signed transaction:")
+	fmt.Printf("\tHash:  %s\n", tx.Hash().Hex())
+	fmt.Printf("\tFrom:  %s\n", from.Hex())
+	if tx.To() != nil {
+		fmt.Printf("\tTo:    %s\n", tx.To().Hex())
+	}
+	fmt.Printf("\tValue: %.6f ETH\n", eth.WeiToEth(tx.Value()))
+	fmt.Printf("\tNonce: %d\n", tx.Nonce())
+	fmt.Printf("\tMax fee: %.2f gwei (max priority fee %.2f gwei)\n", eth.WeiToGwei(tx.GasFeeCap()), eth.WeiToGwei(tx.GasTipCap()))
+	fmt.Println()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to broadcast this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Broadcast the transaction
+	payload, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error serializing signed transaction: %w", err)
+	}
+	response, err := rp.BroadcastTransaction(payload)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Broadcasting the transaction...")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("The transaction was successfully included in a block.")
+	return nil
+
+}
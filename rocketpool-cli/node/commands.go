@@ -819,6 +819,179 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "pending-txs",
+				Aliases:   []string{"ptx"},
+				Usage:     "List the node's transactions that haven't been included in a block yet",
+				UsageText: "rocketpool node pending-txs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getPendingTransactions(c)
+
+				},
+			},
+
+			{
+				Name:      "cancel-tx",
+				Aliases:   []string{"ctx"},
+				Usage:     "Cancel a pending transaction by replacing it with an empty transfer to the node address. Use the global --maxFee and --maxPrioFee flags to choose its fees.",
+				UsageText: "rocketpool node cancel-tx [-y] nonce",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the cancellation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return cancelTransaction(c, nonce)
+
+				},
+			},
+
+			{
+				Name:      "broadcast-tx",
+				Aliases:   []string{"btx"},
+				Usage:     "Broadcast a transaction that was exported with --export-unsigned and signed offline with `rocketpool wallet sign-tx`",
+				UsageText: "rocketpool node broadcast-tx [-y] signed-tx-file-or-payload",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the broadcast",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return broadcastTransaction(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "export-income",
+				Aliases:   []string{"ei"},
+				Usage:     "Export every ETH and RPL inflow to the node with its block, timestamp, transaction and the RPL price at the time, for tax and accounting",
+				UsageText: "rocketpool node export-income [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--format csv|json] [--output file]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "from",
+						Usage: "The first day to export, in YYYY-MM-DD format (UTC). Omit it to start from the Rocket Pool deployment.",
+					},
+					cli.StringFlag{
+						Name:  "to",
+						Usage: "The last day to export, in YYYY-MM-DD format (UTC). Omit it to export up to now.",
+					},
+					cli.StringFlag{
+						Name:  "format",
+						Usage: "The export format: 'csv' or 'json'",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The `file` to write the export to; omit it to print to the terminal",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportIncome(c)
+
+				},
+			},
+
+			{
+				Name:      "history",
+				Aliases:   []string{"hi"},
+				Usage:     "Show the node's recorded history of RPL stake, collateral, rewards and minipool balances",
+				UsageText: "rocketpool node history [--from time] [--to time] [--step duration] [--minipool address]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "from, f",
+						Usage: "The start of the range: a YYYY-MM-DD date, an RFC 3339 timestamp, or a duration ago such as 12h or 30d. Set it to an empty string to show all recorded history.",
+						Value: "7d",
+					},
+					cli.StringFlag{
+						Name:  "to, t",
+						Usage: "The end of the range, in the same formats as --from. Omit it to show up to now.",
+					},
+					cli.StringFlag{
+						Name:  "step, s",
+						Usage: "Show at most one sample per step, such as 1h or 1d; 'auto' picks a step that shows at most 200 rows",
+						Value: "auto",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "Show the beacon balance and node share history of a single minipool `address`",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "dashboard",
+				Aliases:   []string{"db"},
+				Usage:     "Get a link to the node daemon's read-only web dashboard",
+				UsageText: "rocketpool node dashboard [--reset-token]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "reset-token",
+						Usage: "Replace the dashboard's token, which invalidates every existing link",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm replacing the token",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDashboard(c)
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,75 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+func getDashboard(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the dashboard info
+	response, err := rp.NodeDashboard()
+	if err != nil {
+		return err
+	}
+	if !response.Enabled {
+		fmt.Println("The web dashboard is disabled. Enable it in the Smartnode section of `rocketpool service config`.")
+		return nil
+	}
+
+	// Replace the token if requested
+	token := response.Token
+	if c.Bool("reset-token") {
+		if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to replace the dashboard token? Every browser that's logged in with the old one will need the new link.")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		resetResponse, err := rp.ResetDashboardToken()
+		if err != nil {
+			return err
+		}
+		token = resetResponse.Token
+		fmt.Println("The dashboard token has been replaced.")
+		fmt.Println()
+	}
+
+	// Print the link
+	url := fmt.Sprintf("http://localhost:%d/?token=%s", response.Port, token)
+	output.Set("url", url)
+	fmt.Println("Open this link in a browser on this machine to view the web dashboard:")
+	fmt.Println()
+	fmt.Println(url)
+	fmt.Println()
+	fmt.Printf("%sKeep this link private; anyone with it can see your node's details. Run `rocketpool node dashboard --reset-token` to replace it.%s\n", colorYellow, colorReset)
+
+	// Explain where the dashboard can be reached from
+	switch {
+	case response.PortMode == cfgtypes.RPC_OpenExternal:
+		fmt.Println("The dashboard is open to your local network, so you can also use this link from another machine by replacing `localhost` with this machine's IP address.")
+	case !cfg.IsNativeMode && !response.PortMode.Open():
+		fmt.Printf("%sThe dashboard's port isn't exposed outside of Docker, so it can't be reached from this machine. Set \"Expose Web Dashboard Port\" in the Smartnode section of `rocketpool service config` to open it.%s\n", colorYellow, colorReset)
+	default:
+		fmt.Printf("The dashboard only accepts connections from this machine. To view it from another one, forward its port with SSH (e.g. `ssh -L %d:localhost:%d <your node>`).\n", response.Port, response.Port)
+	}
+	return nil
+
+}
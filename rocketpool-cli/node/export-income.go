@@ -0,0 +1,144 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The date format for the export range
+const incomeDateFormat string = "2006-01-02"
+
+// The columns of the CSV income export
+var incomeCsvHeader = []string{"timestamp", "block", "tx_hash", "type", "interval", "source", "token", "amount", "rpl_price_eth", "eth_value", "note"}
+
+func exportIncome(c *cli.Context) error {
+
+	// Parse the date range; dates are in UTC and the end date is inclusive
+	var from, to time.Time
+	var err error
+	if c.String("from") != "" {
+		from, err = time.Parse(incomeDateFormat, c.String("from"))
+		if err != nil {
+			return fmt.Errorf("Invalid from date '%s': it must be in YYYY-MM-DD format", c.String("from"))
+		}
+	}
+	if c.String("to") != "" {
+		to, err = time.Parse(incomeDateFormat, c.String("to"))
+		if err != nil {
+			return fmt.Errorf("Invalid to date '%s': it must be in YYYY-MM-DD format", c.String("to"))
+		}
+		to = to.Add(24*time.Hour - time.Second)
+	}
+	format := c.String("format")
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("Invalid format '%s': it must be 'csv' or 'json'", format)
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the income history
+	fmt.Fprintln(os.Stderr, "Reconstructing the node's income history from the chain; this can take several minutes...")
+	response, err := rp.ExportIncome(from, to)
+	if err != nil {
+		return err
+	}
+	for _, warning := range response.Warnings {
+		fmt.Fprintf(os.Stderr, "%sWARNING: %s%s\n", colorYellow, warning, colorReset)
+	}
+
+	// Get the output
+	var output io.Writer = os.Stdout
+	if path := c.String("output"); path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", path, err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	// Write the export
+	switch format {
+	case "json":
+		bytes, err := json.MarshalIndent(response.Events, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error serializing income history: %w", err)
+		}
+		_, err = fmt.Fprintln(output, string(bytes))
+		if err != nil {
+			return fmt.Errorf("error writing income history: %w", err)
+		}
+	case "csv":
+		if err := writeIncomeCsv(output, response.Events); err != nil {
+			return err
+		}
+	}
+
+	// Log & return
+	if c.String("output") != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d income event(s) between blocks %d and %d to %s.\n", len(response.Events), response.FromBlock, response.ToBlock, c.String("output"))
+	}
+	return nil
+
+}
+
+// Write income events as CSV, with amounts and prices as exact decimals in ETH / RPL units
+func writeIncomeCsv(output io.Writer, events []api.NodeIncomeEvent) error {
+
+	writer := csv.NewWriter(output)
+	if err := writer.Write(incomeCsvHeader); err != nil {
+		return fmt.Errorf("error writing income history: %w", err)
+	}
+	for _, event := range events {
+		interval := ""
+		if event.Interval != nil {
+			interval = strconv.FormatUint(*event.Interval, 10)
+		}
+		record := []string{
+			event.Timestamp.UTC().Format(time.RFC3339),
+			strconv.FormatUint(event.Block, 10),
+			event.TxHash.Hex(),
+			event.Type,
+			interval,
+			event.Source.Hex(),
+			event.Token,
+			formatWeiDecimal(event.Amount),
+			formatWeiDecimal(event.RplPrice),
+			formatWeiDecimal(event.EthValue),
+			event.Note,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error writing income history: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing income history: %w", err)
+	}
+	return nil
+
+}
+
+// Format a wei amount as an exact decimal, or a blank if it's unknown
+func formatWeiDecimal(wei *big.Int) string {
+	if wei == nil {
+		return ""
+	}
+	return new(big.Rat).SetFrac(wei, big.NewInt(1e18)).FloatString(18)
+}
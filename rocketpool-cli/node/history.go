@@ -0,0 +1,206 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The most rows to show when the step is picked automatically
+const historyMaxRows int = 200
+
+// The steps to choose from when the step is picked automatically
+var historyAutoSteps = []time.Duration{
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	4 * time.Hour,
+	12 * time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+}
+
+func getHistory(c *cli.Context) error {
+
+	// Parse the range and step
+	now := time.Now()
+	from, err := parseHistoryTime(c.String("from"), now)
+	if err != nil {
+		return fmt.Errorf("Invalid from time '%s': %w", c.String("from"), err)
+	}
+	to, err := parseHistoryTime(c.String("to"), now)
+	if err != nil {
+		return fmt.Errorf("Invalid to time '%s': %w", c.String("to"), err)
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return fmt.Errorf("The to time must be after the from time.")
+	}
+	var step time.Duration
+	if c.String("step") != "auto" {
+		step, err = parseHistoryDuration(c.String("step"))
+		if err != nil || step <= 0 {
+			return fmt.Errorf("Invalid step '%s': it must be a duration such as 30m, 6h or 1d, or 'auto'", c.String("step"))
+		}
+	}
+	var minipoolAddress *common.Address
+	if c.String("minipool") != "" {
+		if !common.IsHexAddress(c.String("minipool")) {
+			return fmt.Errorf("Invalid minipool address '%s'", c.String("minipool"))
+		}
+		address := common.HexToAddress(c.String("minipool"))
+		minipoolAddress = &address
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Pick a step that keeps the table readable
+	if step == 0 {
+		end := to
+		if end.IsZero() {
+			end = now
+		}
+		step = historyAutoSteps[len(historyAutoSteps)-1]
+		for _, candidate := range historyAutoSteps {
+			if !from.IsZero() && end.Sub(from)/candidate <= time.Duration(historyMaxRows) {
+				step = candidate
+				break
+			}
+		}
+	}
+
+	// Get the history
+	response, err := rp.NodeHistory(from, to, step)
+	if err != nil {
+		return err
+	}
+	if response.RetentionDays == 0 {
+		fmt.Printf("%sHistory recording is disabled. Enable it by setting a history retention period in the Smartnode section of `rocketpool service config`.%s\n\n", colorYellow, colorReset)
+	}
+	if len(response.Samples) == 0 {
+		fmt.Println("No history has been recorded for this range yet. The node daemon records a sample every update cycle.")
+		return nil
+	}
+
+	// Print the history
+	if minipoolAddress != nil {
+		return printMinipoolHistory(response.Samples, *minipoolAddress)
+	}
+	printNodeHistory(response.Samples)
+	return nil
+
+}
+
+// Print the node-wide history
+func printNodeHistory(samples []history.Sample) {
+
+	output.Set("samples", samples)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Time (UTC)\tBlock\tRPL Stake\tEffective Stake\tRPL Price\tBorrowed Ratio\tBonded Ratio\tBeacon Share (ETH)\tUnclaimed RPL\tUnclaimed ETH\tClaimed RPL\tClaimed ETH")
+	for _, sample := range samples {
+		fmt.Fprintf(w, "%s\t%d\t%.4f\t%.4f\t%.6f\t%.2f%%\t%.2f%%\t%.6f\t%.4f\t%.6f\t%.4f\t%.6f\n",
+			sample.Time.UTC().Format("2006-01-02 15:04"),
+			sample.Block,
+			sample.RplStake,
+			sample.EffectiveRplStake,
+			sample.RplPrice,
+			sample.BorrowedCollateralRatio*100,
+			sample.BondedCollateralRatio*100,
+			sample.BeaconShare,
+			sample.UnclaimedRplRewards,
+			sample.UnclaimedEthRewards,
+			sample.ClaimedRplRewards,
+			sample.ClaimedEthRewards,
+		)
+	}
+	w.Flush()
+
+}
+
+// The history of a single minipool
+type minipoolHistorySample struct {
+	Time          time.Time `json:"time"`
+	Block         uint64    `json:"block"`
+	BeaconBalance float64   `json:"beaconBalance"`
+	NodeShare     float64   `json:"nodeShare"`
+}
+
+// Print the history of a single minipool
+func printMinipoolHistory(samples []history.Sample, address common.Address) error {
+
+	minipoolSamples := []minipoolHistorySample{}
+	for _, sample := range samples {
+		for _, minipool := range sample.Minipools {
+			if minipool.Address == address {
+				minipoolSamples = append(minipoolSamples, minipoolHistorySample{
+					Time:          sample.Time,
+					Block:         sample.Block,
+					BeaconBalance: minipool.BeaconBalance,
+					NodeShare:     minipool.NodeShare,
+				})
+				break
+			}
+		}
+	}
+	if len(minipoolSamples) == 0 {
+		return fmt.Errorf("No history has been recorded for minipool %s in this range.", address.Hex())
+	}
+	output.Set("samples", minipoolSamples)
+
+	fmt.Printf("History of minipool %s:\n\n", address.Hex())
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Time (UTC)\tBlock\tBeacon Balance (ETH)\tNode Share (ETH)")
+	for _, sample := range minipoolSamples {
+		fmt.Fprintf(w, "%s\t%d\t%.6f\t%.6f\n", sample.Time.UTC().Format("2006-01-02 15:04"), sample.Block, sample.BeaconBalance, sample.NodeShare)
+	}
+	w.Flush()
+	return nil
+
+}
+
+// Parse a time given as a YYYY-MM-DD date, an RFC 3339 timestamp, or a duration before now such as 7d.
+// An empty string is a zero time.
+func parseHistoryTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(incomeDateFormat, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if duration, err := parseHistoryDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	return time.Time{}, fmt.Errorf("it must be a YYYY-MM-DD date, an RFC 3339 timestamp, or a duration ago such as 12h or 7d")
+}
+
+// Parse a duration, which also supports a number of days or weeks such as 7d or 2w
+func parseHistoryDuration(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if count, found := strings.CutSuffix(value, suffix); found {
+			number, err := strconv.ParseUint(count, 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(number) * unit, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,92 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getPendingTransactions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the pending transactions
+	response, err := rp.GetPendingTransactions()
+	if err != nil {
+		return err
+	}
+
+	// Print the tracked transactions
+	untracked := int(response.PendingNonce-response.LatestNonce) - len(response.Transactions)
+	if len(response.Transactions) == 0 && untracked <= 0 {
+		fmt.Println("The node doesn't have any pending transactions.")
+		return nil
+	}
+	for _, tx := range response.Transactions {
+		fmt.Printf("%sNonce %d%s\n", colorGreen, tx.Nonce, colorReset)
+		fmt.Printf("Hash:            %s\n", tx.Hash.Hex())
+		fmt.Printf("Sent by:         %s\n", tx.Owner)
+		if tx.To != nil {
+			fmt.Printf("To:              %s\n", tx.To.Hex())
+		}
+		fmt.Printf("Max fee:         %.2f gwei\n", eth.WeiToGwei(tx.MaxFee))
+		fmt.Printf("Priority fee:    %.2f gwei\n", eth.WeiToGwei(tx.MaxPriorityFee))
+		fmt.Printf("First submitted: %s (%s ago)\n", tx.FirstSubmitted.Format(time.RFC1123), time.Since(tx.FirstSubmitted).Round(time.Second))
+		if len(tx.ReplacedHashes) > 0 {
+			fmt.Printf("Replaced:        %d time(s), last at %s\n", len(tx.ReplacedHashes), tx.LastSubmitted.Format(time.RFC1123))
+		}
+		fmt.Println()
+	}
+
+	// Print the transactions the Smartnode isn't tracking
+	if untracked > 0 {
+		fmt.Printf("%sThe Execution client has %d pending transaction(s) from the node that the Smartnode isn't tracking, such as ones sent from the CLI or another wallet.%s\n", colorYellow, untracked, colorReset)
+		fmt.Printf("Their nonces are between %d and %d.\n\n", response.LatestNonce, response.PendingNonce-1)
+	}
+	fmt.Println("You can cancel a pending transaction with `rocketpool node cancel-tx <nonce>`.")
+	return nil
+
+}
+
+func cancelTransaction(c *cli.Context, nonce uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to cancel the pending transaction with nonce %d? It will be replaced with an empty transfer to your node address, which still costs gas.", nonce))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Cancel the transaction
+	response, err := rp.CancelTransaction(nonce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cancelling the transaction with nonce %d...\n", nonce)
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully cancelled the transaction with nonce %d.\n", nonce)
+	return nil
+
+}
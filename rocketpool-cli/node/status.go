@@ -14,6 +14,7 @@ import (
 	"github.com/rocket-pool/smartnode/addons/rescue_node"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -68,6 +69,9 @@ func getStatus(c *cli.Context) error {
 	}
 
 	// rp.NodeStatus() will fail with an error, but we can short-circuit it here.
+	if walletStatus.WalletLocked {
+		return errors.New("The node wallet is locked. Run `rocketpool wallet unlock` to unlock it.")
+	}
 	if !walletStatus.WalletInitialized {
 		return errors.New("The node wallet is not initialized.")
 	}
@@ -84,6 +88,20 @@ func getStatus(c *cli.Context) error {
 		return fmt.Errorf("error checking if Houston Hotfix has been deployed: %w", err)
 	}
 
+	// Values computed for machine-readable output
+	activeAlerts := 0
+	for _, alert := range status.Alerts {
+		if alert.IsActive() {
+			activeAlerts++
+		}
+	}
+	output.Set("network", cfg.GetNetwork())
+	output.Set("activeMinipools", status.MinipoolCounts.Total-status.MinipoolCounts.Finalised)
+	output.Set("undercollateralized", status.BorrowedCollateralRatio > 0 && status.RplStake.Cmp(status.MinimumRplStake) < 0)
+	output.Set("primaryWithdrawalAddressIsNode", status.PrimaryWithdrawalAddress == status.AccountAddress)
+	output.Set("activeAlerts", activeAlerts)
+	output.Set("houstonHotfixDeployed", hotfix.IsHoustonHotfixDeployed)
+
 	// Account address & balances
 	fmt.Printf("%s=== Account and Balances ===%s\n", colorGreen, colorReset)
 	fmt.Printf(
@@ -339,6 +357,8 @@ func getStatus(c *cli.Context) error {
 			if remainingFor16EB < 0 {
 				remainingFor16EB = 0
 			}
+			output.Set("remaining8EthMinipools", remainingFor8EB)
+			output.Set("remaining16EthMinipools", remainingFor16EB)
 			fmt.Printf("The node has enough RPL staked to make %d more 8-ETH minipools (or %d more 16-ETH minipools).\n\n", remainingFor8EB, remainingFor16EB)
 		}
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool-cli/auction"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/fleet"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/minipool"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/network"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
@@ -18,7 +20,9 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
 	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 // Run
@@ -50,6 +54,9 @@ A special thanks to the Rocket Pool community for all their contributions.
 	// Initialize app metadata
 	app.Metadata = make(map[string]interface{})
 
+	// Commands that need a specific exit code return a cli.ExitCoder; exit with it below, after the output is printed
+	app.ExitErrHandler = func(c *cli.Context, err error) {}
+
 	// Set application flags
 	app.Flags = []cli.Flag{
 		cli.BoolFlag{
@@ -81,6 +88,27 @@ A special thanks to the Rocket Pool community for all their contributions.
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Simulate any transactions this command would submit instead of submitting them, and show their expected results",
+		},
+		cli.StringFlag{
+			Name:  "export-unsigned",
+			Usage: "Export the transactions this command would submit to unsigned transaction files instead of submitting them, so they can be signed offline with `rocketpool wallet sign-tx`. The first is saved to `file` and the rest are numbered after it.",
+		},
+		cli.StringFlag{
+			Name:  "export-unsigned-node",
+			Usage: "With --export-unsigned, the node `address` to build the transactions for, so the node wallet doesn't need to be on this machine",
+		},
+		cli.StringFlag{
+			Name:  "export-unsigned-from",
+			Usage: "With --export-unsigned, the `address` that will sign the transactions if it isn't the node address, such as the node's withdrawal address",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "The output `format`: 'table' for text, or 'json' / 'yaml' to print one machine-readable document per command with interactive prompts disabled",
+			Value: string(output.Format_Table),
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
@@ -94,6 +122,7 @@ A special thanks to the Rocket Pool community for all their contributions.
 
 	// Register commands
 	auction.RegisterCommands(app, "auction", []string{"a"})
+	fleet.RegisterCommands(app, "fleet", []string{"f"})
 	minipool.RegisterCommands(app, "minipool", []string{"m"})
 	network.RegisterCommands(app, "network", []string{"e"})
 	node.RegisterCommands(app, "node", []string{"n"})
@@ -112,6 +141,22 @@ A special thanks to the Rocket Pool community for all their contributions.
 			os.Exit(1)
 		}
 
+		// Set the output format; this has to happen before anything is printed
+		if err := output.Init(c.GlobalString("output"), output.CommandName(c.App, c.Args())); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+
+		// Validate the addresses for exported transactions
+		for _, flag := range []string{"export-unsigned-node", "export-unsigned-from"} {
+			if value := c.GlobalString(flag); value != "" {
+				if _, err := cliutils.ValidateAddress(flag, value); err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+			}
+		}
+
 		// If set, validate custom nonce
 		customNonce := c.GlobalString("nonce")
 		if customNonce != "" {
@@ -130,9 +175,20 @@ A special thanks to the Rocket Pool community for all their contributions.
 
 	// Run application
 	fmt.Println("")
-	if err := app.Run(os.Args); err != nil {
+	err := app.Run(os.Args)
+	if interceptErr := rocketpool.FinishInterceptedTransactions(err); err == nil {
+		err = interceptErr
+	}
+	if output.IsStructured() {
+		output.Exit(err)
+	}
+	if err != nil {
 		cliutils.PrettyPrintError(err)
 	}
 	fmt.Println("")
+	var exitCoder cli.ExitCoder
+	if errors.As(err, &exitCoder) {
+		os.Exit(exitCoder.ExitCode())
+	}
 
 }
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// Backup passphrases protect the node wallet, so they must be reasonably long
+const (
+	backupPassphraseFormat string = "^.{12,}$"
+	backupPassphraseError  string = "Your passphrase must be at least 12 characters long. Please try again:"
+)
+
+// A file to add to a backup
+type backupSource struct {
+	archivePath string
+	sourcePath  string
+	required    bool
+}
+
+// Create an encrypted backup of the node
+func backupNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+	if rp.IsRemote() {
+		return fmt.Errorf("Backups can't be created over SSH; please run this command on the node itself.")
+	}
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+
+	// Get the node's details
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		return fmt.Errorf("The node wallet is not initialized, so there is nothing to back up.")
+	}
+	manifest := backup.Manifest{
+		SmartnodeVersion: shared.RocketPoolVersion,
+		CreatedAt:        time.Now().UTC(),
+		Network:          string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		NodeAddress:      status.AccountAddress,
+		MinipoolPubkeys:  []types.ValidatorPubkey{},
+		IncludesPassword: c.Bool("include-password"),
+	}
+	pubkeys, err := rp.NodeMinipoolPubkeys(status.AccountAddress)
+	minipoolsKnown := (err == nil)
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't get your minipools, so the backup won't list them: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	} else {
+		manifest.MinipoolPubkeys = pubkeys.Pubkeys
+	}
+
+	// Get the output file
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("rocketpool-backup-%s-%s%s", manifest.Network, manifest.CreatedAt.Format("20060102-150405"), backup.FileExtension)
+	}
+	outputPath, err = homedir.Expand(outputPath)
+	if err != nil {
+		return fmt.Errorf("error expanding backup path: %w", err)
+	}
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists; please choose a different file.", outputPath)
+	}
+
+	// Get the paths to back up
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	dataPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.DataPath.Value.(string)))
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+
+	// Export the slashing protection database
+	var slashingProtectionFile string
+	skipReason := ""
+	switch {
+	case c.Bool("skip-slashing-protection"):
+		skipReason = "it was skipped with --skip-slashing-protection"
+	case cfg.IsNativeMode:
+		skipReason = "it can't be exported automatically in Native mode"
+	case cfg.EnableRemoteSigner.Value == true:
+		skipReason = "your remote signer manages it"
+	case minipoolsKnown && len(manifest.MinipoolPubkeys) == 0:
+		skipReason = "you don't have any minipools"
+	}
+	if skipReason == "" {
+		fmt.Println("Your validator client will be stopped for a few seconds to export its slashing protection database.")
+		if !(c.Bool("yes") || cliutils.Confirm("Do you want to continue?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		tempFolder, err := os.MkdirTemp("", "rocketpool-backup-")
+		if err != nil {
+			return fmt.Errorf("error creating temporary folder: %w", err)
+		}
+		defer os.RemoveAll(tempFolder)
+		slashingProtectionFile = filepath.Join(tempFolder, backup.SlashingProtectionPath)
+		client, err := exportSlashingProtection(rp, cfg, slashingProtectionFile, true)
+		if err != nil {
+			return fmt.Errorf("error exporting slashing protection: %w", err)
+		}
+		manifest.SlashingProtectionClient = string(client)
+		fmt.Printf("Exported the slashing protection database from %s.\n\n", client)
+	} else {
+		fmt.Printf("%sNOTE: The backup won't include your slashing protection database because %s.%s\n\n", colorYellow, skipReason, colorReset)
+	}
+
+	// Get the passphrase
+	passphrase := cliutils.PromptPassword("Please enter a passphrase to encrypt the backup with:", backupPassphraseFormat, backupPassphraseError)
+	confirmation := cliutils.PromptPassword("Please enter the passphrase again:", "^.*$", "")
+	if passphrase != confirmation {
+		return fmt.Errorf("The passphrases don't match.")
+	}
+	fmt.Println("Deriving the encryption key, this may take a few seconds...")
+
+	// Write the backup to a temporary file first so a failed backup doesn't leave a partial archive behind
+	tempPath := outputPath + ".tmp"
+	file, err := os.OpenFile(tempPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", tempPath, err)
+	}
+	defer os.Remove(tempPath)
+	err = writeBackup(file, passphrase, manifest, configPath, dataPath, slashingProtectionFile)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tempPath, outputPath)
+	if err != nil {
+		return fmt.Errorf("error saving %s: %w", outputPath, err)
+	}
+
+	output.Set("file", outputPath)
+	fmt.Printf("%sThe backup was saved to %s.%s\n", colorGreen, outputPath, colorReset)
+	fmt.Println("Keep it and its passphrase somewhere safe and separate; anyone with both has full control of your node wallet.")
+	if !manifest.IncludesPassword {
+		fmt.Println("It doesn't include your wallet password, so you'll need that too when you restore it.")
+	}
+	fmt.Println("The slashing protection in it is only current as of now; don't restore it anywhere while this node is still validating.")
+	return nil
+
+}
+
+// Add each part of the node to a backup
+func writeBackup(file *os.File, passphrase string, manifest backup.Manifest, configPath string, dataPath string, slashingProtectionFile string) error {
+
+	writer, err := backup.NewWriter(file, passphrase)
+	if err != nil {
+		return err
+	}
+
+	// The wallet and settings are required, everything else is only backed up if it exists
+	files := []backupSource{
+		{backup.WalletPath, filepath.Join(dataPath, backup.WalletPath), true},
+		{backup.SettingsPath, filepath.Join(configPath, rocketpool.SettingsFile), true},
+		{backup.CustomKeyPasswordsPath, filepath.Join(dataPath, backup.CustomKeyPasswordsPath), false},
+	}
+	if manifest.IncludesPassword {
+		files = append(files, backupSource{backup.PasswordPath, filepath.Join(dataPath, backup.PasswordPath), true})
+	}
+	if slashingProtectionFile != "" {
+		files = append(files, backupSource{backup.SlashingProtectionPath, slashingProtectionFile, true})
+	}
+	for _, file := range files {
+		if _, err := os.Stat(file.sourcePath); os.IsNotExist(err) && !file.required {
+			continue
+		}
+		fmt.Printf("Adding %s...\n", file.archivePath)
+		if err := writer.AddFile(file.archivePath, file.sourcePath); err != nil {
+			return err
+		}
+	}
+
+	folders := map[string]string{
+		backup.OverrideFolder:     filepath.Join(configPath, backup.OverrideFolder),
+		backup.CustomKeysFolder:   filepath.Join(dataPath, backup.CustomKeysFolder),
+		backup.RewardsTreesFolder: filepath.Join(dataPath, config.RewardsTreesFolder),
+		backup.WatchtowerFolder:   filepath.Join(dataPath, config.WatchtowerFolder),
+	}
+	for _, archivePath := range []string{backup.OverrideFolder, backup.CustomKeysFolder, backup.RewardsTreesFolder, backup.WatchtowerFolder} {
+		fmt.Printf("Adding %s...\n", archivePath)
+		if err := writer.AddFolder(archivePath, folders[archivePath]); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close(manifest)
+
+}
+
+// Export the slashing protection database of the VC that is currently deployed, which may not be the one in the settings.
+// The VC is stopped for the export; if restart is set and it was running, it's started again afterwards.
+func exportSlashingProtection(rp *rocketpool.Client, cfg *config.RocketPoolConfig, targetFile string, restart bool) (cfgtypes.ConsensusClient, error) {
+
+	// Get the current validator client
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return cfgtypes.ConsensusClient_Unknown, fmt.Errorf("Error getting validator container prefix: %w", err)
+	}
+	validatorContainerName := prefix + ValidatorContainerSuffix
+	image, err := rp.GetDockerImage(validatorContainerName)
+	if err != nil {
+		return cfgtypes.ConsensusClient_Unknown, fmt.Errorf("Error getting current validator image: %w", err)
+	}
+	client, err := rocketpool.GetConsensusClientFromImage(image)
+	if err != nil {
+		return cfgtypes.ConsensusClient_Unknown, err
+	}
+
+	// Stop it so the database doesn't change during the export
+	status, err := rp.GetDockerStatus(validatorContainerName)
+	if err != nil {
+		return client, fmt.Errorf("Error getting container [%s] status: %w", validatorContainerName, err)
+	}
+	if status == "running" {
+		response, err := rp.StopContainer(validatorContainerName)
+		if err != nil {
+			return client, fmt.Errorf("Error stopping container [%s]: %w", validatorContainerName, err)
+		}
+		if response != validatorContainerName {
+			return client, fmt.Errorf("Unexpected response when stopping container [%s]: %s", validatorContainerName, response)
+		}
+		if restart {
+			defer func() {
+				if _, err := rp.StartContainer(validatorContainerName); err != nil {
+					fmt.Printf("%sWARNING: Couldn't restart container [%s]: %s%s\n", colorYellow, validatorContainerName, err.Error(), colorReset)
+				}
+			}()
+		}
+	}
+
+	// Export it
+	err = rp.ExportSlashingProtection(cfg, client, rocketpool.GetSlashingProtectionImage(cfg, client, image), targetFile)
+	if err != nil {
+		return client, err
+	}
+	return client, nil
+
+}
@@ -164,6 +164,111 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return configureService(c)
 
 				},
+				Subcommands: []cli.Command{
+					{
+						Name:      "apply",
+						Aliases:   []string{"a"},
+						Usage:     "Apply a declarative config file, which only needs the settings it changes, and restart the containers they affect",
+						UsageText: "rocketpool service config apply -f file [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "file, f",
+								Usage: "The config file to apply; its layout matches user-settings.yml",
+							},
+							cli.BoolFlag{
+								Name:  "check",
+								Usage: "Only print the changes the file would make, exiting with code 1 if there are any or 2 if the file or its result is invalid",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm applying the changes and restarting containers",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+							if c.String("file") == "" {
+								return fmt.Errorf("Please provide the config file to apply with --file.")
+							}
+
+							// Run command
+							return applyConfig(c)
+
+						},
+					},
+					{
+						Name:      "history",
+						Aliases:   []string{"h"},
+						Usage:     "List the saved versions of the Smart Node's settings",
+						UsageText: "rocketpool service config history",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return configHistory(c)
+
+						},
+					},
+					{
+						Name:      "diff",
+						Aliases:   []string{"d"},
+						Usage:     "Show the changes between two saved versions of the Smart Node's settings",
+						UsageText: "rocketpool service config diff a b",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+							fromID, err := cliutils.ValidateUint("snapshot ID", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+							toID, err := cliutils.ValidateUint("snapshot ID", c.Args().Get(1))
+							if err != nil {
+								return err
+							}
+
+							// Run command
+							return configDiff(c, fromID, toID)
+
+						},
+					},
+					{
+						Name:      "rollback",
+						Aliases:   []string{"r"},
+						Usage:     "Restore a saved version of the Smart Node's settings and restart the containers it affects",
+						UsageText: "rocketpool service config rollback id [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the rollback and restarting containers",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							id, err := cliutils.ValidateUint("snapshot ID", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run command
+							return configRollback(c, id)
+
+						},
+					},
+				},
 			},
 
 			{
@@ -192,7 +297,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Flags: []cli.Flag{
 					cli.BoolFlag{
 						Name:  "ignore-slash-timer",
-						Usage: "Bypass the safety timer that forces a delay when switching to a new ETH2 client",
+						Usage: "Bypass the safety timer that forces a delay when switching to a new ETH2 client and its slashing protection database couldn't be moved",
 					},
 					cli.BoolFlag{
 						Name:  "yes, y",
@@ -485,6 +590,86 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "doctor",
+				Usage:     "Run health and misconfiguration checks on the node and suggest fixes for any problems they find; use the global --output json flag to share the results",
+				UsageText: "rocketpool service doctor",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return runDoctor(c)
+
+				},
+			},
+
+			{
+				Name:      "backup",
+				Aliases:   []string{"b"},
+				Usage:     "Create an encrypted backup of the node wallet, settings, custom keys, rewards trees, watchtower state, and validator slashing protection",
+				UsageText: "rocketpool service backup [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to save the backup to (defaults to a timestamped file in the current directory)",
+					},
+					cli.BoolFlag{
+						Name:  "include-password",
+						Usage: "Include the node wallet's password in the backup",
+					},
+					cli.BoolFlag{
+						Name:  "skip-slashing-protection",
+						Usage: "Don't stop the validator client to export its slashing protection database",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the validator client during the export",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return backupNode(c)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Restore the node from a backup created with `rocketpool service backup`, after checking it against the chain",
+				UsageText: "rocketpool service restore [options] backup-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "skip-chain-check",
+						Usage: "Don't check the backup's node and minipools against the chain (use this if the Smart Node isn't running yet)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm that the old node is offline and that an existing wallet can be replaced",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return restoreNode(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "terminate",
 				Aliases:   []string{"t"},
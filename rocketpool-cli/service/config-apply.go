@@ -0,0 +1,270 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The section of a declarative config file that holds the top-level settings, matching the settings file
+const rootSectionName string = "root"
+
+// Exit codes for applying a config file, so scripts using --check can tell drift apart from a broken file
+const (
+	configApplyExitCode_Drift   int = 1
+	configApplyExitCode_Invalid int = 2
+)
+
+// A setting that a declarative config file changes
+type configChange struct {
+	Section  string `json:"section" yaml:"section"`
+	Setting  string `json:"setting" yaml:"setting"`
+	OldValue string `json:"oldValue" yaml:"oldValue"`
+	NewValue string `json:"newValue" yaml:"newValue"`
+}
+
+// Apply a partial declarative config file to the node, restarting only the containers that its changes affect
+func applyConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the current config
+	oldCfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node before applying a config file to it.")
+	}
+	isUpdate, err := rp.IsFirstRun()
+	if err != nil {
+		return fmt.Errorf("error checking for first-run status: %w", err)
+	}
+	if isUpdate {
+		return fmt.Errorf("The Smart Node was upgraded but hasn't been started since. Please run `rocketpool service start` to finish upgrading before applying a config file.")
+	}
+
+	// Apply the file to a copy of it
+	path, err := homedir.Expand(c.String("file"))
+	if err != nil {
+		return fmt.Errorf("error expanding config file path: %w", err)
+	}
+	cfg := oldCfg.CreateCopy()
+	err = applyConfigFile(cfg, path)
+	if err != nil {
+		return cli.NewExitError(err.Error(), configApplyExitCode_Invalid)
+	}
+
+	// Validate it
+	errors := cfg.Validate()
+	output.Set("valid", len(errors) == 0)
+	if len(errors) > 0 {
+		output.Set("errors", errors)
+		fmt.Printf("%sThe resulting configuration has errors. You must correct the following in %s before it can be applied:\n\n", colorRed, path)
+		for _, err := range errors {
+			fmt.Printf("%s\n\n", err)
+		}
+		fmt.Print(colorReset)
+		return cli.NewExitError(fmt.Sprintf("%s is not valid", path), configApplyExitCode_Invalid)
+	}
+
+	// Print the plan
+	changedSettings, affectedContainers, changeNetworks := cfg.GetChanges(oldCfg)
+	changes, containers := printConfigPlan(cfg, changedSettings, affectedContainers)
+	output.Set("changes", changes)
+	output.Set("containers", containers)
+	if changeNetworks {
+		return fmt.Errorf("%s changes the network, which deletes your chain data, wallet, and validator keys. Please use `rocketpool service config` to change networks.", path)
+	}
+	if len(changes) == 0 {
+		fmt.Println("The node's configuration already matches the file; there's nothing to apply.")
+		return nil
+	}
+	if c.Bool("check") {
+		return cli.NewExitError(fmt.Sprintf("The node's configuration differs from %s in %d setting(s).", path, len(changes)), configApplyExitCode_Drift)
+	}
+
+	// Save it
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to apply these changes?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Println("Your changes have been saved!")
+	if c.GlobalIsSet("daemon-path") {
+		fmt.Println("Please restart your daemon service for them to take effect.")
+		return nil
+	}
+	return restartAffectedContainers(c, rp, cfg, containers)
+
+}
+
+// Stop the containers that a config change affects and start the service again, which redeploys them with the new config
+func restartAffectedContainers(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, containers []cfgtypes.ContainerID) error {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	prefix := fmt.Sprint(cfg.Smartnode.ProjectName.Value)
+	fmt.Println()
+	for _, container := range containers {
+		fullName := fmt.Sprintf("%s_%s", prefix, container)
+		fmt.Printf("Stopping %s... ", fullName)
+		_, err := rp.StopContainer(fullName)
+		if err != nil {
+			fmt.Print("failed!\n")
+			return fmt.Errorf("Your changes were saved, but %s couldn't be stopped to apply them: %w\nPlease run `rocketpool service start` to restart it with the new settings.", fullName, err)
+		}
+		fmt.Print("done!\n")
+	}
+	fmt.Println()
+	fmt.Println("Applying changes and restarting containers...")
+	return startService(c, true)
+}
+
+// Set the parameters in a declarative config file. Its layout matches the settings file, but it only needs the settings it changes.
+func applyConfigFile(cfg *config.RocketPoolConfig, path string) error {
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading config file %s: %w", path, err)
+	}
+	sections := map[string]map[string]interface{}{}
+	err = yaml.Unmarshal(bytes, &sections)
+	if err != nil {
+		return fmt.Errorf("error deserializing config file %s: %w", path, err)
+	}
+
+	// Get every section's parameters
+	params := map[string][]*cfgtypes.Parameter{
+		rootSectionName: cfg.GetParameters(),
+	}
+	for name, subconfig := range cfg.GetSubconfigs() {
+		params[name] = subconfig.GetParameters()
+	}
+
+	// Set them, collecting every problem so they can all be fixed at once
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	problems := []string{}
+	for sectionName, settings := range sections {
+		sectionParams, exists := params[sectionName]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("unknown section [%s]", sectionName))
+			continue
+		}
+		for id, value := range settings {
+			// These are managed by the Smartnode, so a copy of the whole settings file can be used as a starting point
+			if sectionName == rootSectionName && (id == "rpDir" || id == "isNative" || id == "version") {
+				continue
+			}
+			var param *cfgtypes.Parameter
+			for _, sectionParam := range sectionParams {
+				if sectionParam.ID == id {
+					param = sectionParam
+					break
+				}
+			}
+			if param == nil {
+				problems = append(problems, fmt.Sprintf("unknown setting [%s.%s]", sectionName, id))
+				continue
+			}
+			serializedValue := ""
+			if value != nil {
+				serializedValue = fmt.Sprint(value)
+			}
+			err := setConfigParam(param, serializedValue, network)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("[%s.%s]: %s", sectionName, id, err.Error()))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%s has errors:\n\t%s", path, strings.Join(problems, "\n\t"))
+	}
+	return nil
+
+}
+
+// Set a parameter from its serialized value, making sure choices are one of the parameter's options
+func setConfigParam(param *cfgtypes.Parameter, value string, network cfgtypes.Network) error {
+	if param.Type == cfgtypes.ParameterType_Choice {
+		options := []string{}
+		for _, option := range param.Options {
+			options = append(options, fmt.Sprint(option.Value))
+		}
+		found := false
+		for _, option := range options {
+			if option == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("[%s] is not one of the valid options (%s)", value, strings.Join(options, ", "))
+		}
+	}
+	return param.Deserialize(map[string]string{param.ID: value}, network)
+}
+
+// Print the settings a config change modifies and the containers it affects, in a stable order so plans can be compared
+func printConfigPlan(cfg *config.RocketPoolConfig, changedSettings map[string][]cfgtypes.ChangedSetting, affectedContainers map[cfgtypes.ContainerID]bool) ([]configChange, []cfgtypes.ContainerID) {
+
+	sections := []string{}
+	for section, settings := range changedSettings {
+		if len(settings) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	sort.Strings(sections)
+
+	changes := []configChange{}
+	for _, section := range sections {
+		fmt.Printf("%s%s%s\n", colorLightBlue, section, colorReset)
+		for _, setting := range changedSettings[section] {
+			fmt.Printf("\t%s: %s => %s\n", setting.Name, setting.OldValue, setting.NewValue)
+			changes = append(changes, configChange{
+				Section:  section,
+				Setting:  setting.Name,
+				OldValue: setting.OldValue,
+				NewValue: setting.NewValue,
+			})
+		}
+		fmt.Println()
+	}
+
+	containers := []cfgtypes.ContainerID{}
+	for container := range affectedContainers {
+		containers = append(containers, container)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i] < containers[j]
+	})
+	if len(containers) > 0 {
+		fmt.Println("The following containers must be restarted for these changes to take effect:")
+		prefix := fmt.Sprint(cfg.Smartnode.ProjectName.Value)
+		for _, container := range containers {
+			fmt.Printf("\t%s_%s\n", prefix, container)
+		}
+		fmt.Println()
+	}
+
+	return changes, containers
+
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// List the snapshots in the config history
+func configHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	snapshots, skipped, err := rputils.LoadConfigHistory(configPath)
+	if err != nil {
+		return err
+	}
+	for _, err := range skipped {
+		fmt.Printf("%sSkipping a snapshot that couldn't be loaded: %s%s\n", colorYellow, err.Error(), colorReset)
+	}
+	if len(skipped) > 0 {
+		fmt.Println()
+	}
+	output.Set("snapshots", snapshots)
+	if len(snapshots) == 0 {
+		fmt.Println("The config history is empty. Your settings will be recorded in it the next time they're saved.")
+		return nil
+	}
+
+	// Print them, newest first
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tSaved\tVersion\tChanges")
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snapshot := snapshots[i]
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", snapshot.ID, snapshot.Time.Local().Format("2006-01-02 15:04:05"), snapshot.Version, snapshot.Summary)
+	}
+	writer.Flush()
+	fmt.Println()
+	fmt.Println("Compare two of them with `rocketpool service config diff <a> <b>`, or restore one with `rocketpool service config rollback <id>`.")
+	return nil
+
+}
+
+// Print the changes between two snapshots in the config history
+func configDiff(c *cli.Context, fromID uint64, toID uint64) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	fromCfg, err := loadConfigSnapshot(configPath, fromID)
+	if err != nil {
+		return err
+	}
+	toCfg, err := loadConfigSnapshot(configPath, toID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Changes from snapshot %d to snapshot %d:\n\n", fromID, toID)
+	changedSettings, affectedContainers, _ := toCfg.GetChanges(fromCfg)
+	changes, containers := printConfigPlan(toCfg, changedSettings, affectedContainers)
+	output.Set("changes", changes)
+	output.Set("containers", containers)
+	if len(changes) == 0 {
+		fmt.Println("<No changes>")
+	}
+	return nil
+
+}
+
+// Restore a snapshot from the config history and redeploy the containers it affects
+func configRollback(c *cli.Context, id uint64) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the current config and the snapshot
+	currentCfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	snapshot, err := rputils.LoadConfigSnapshot(configPath, id)
+	if err != nil {
+		return err
+	}
+	cfg, err := snapshot.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	// Snapshots from other versions have that version's container tags and other upgrade-managed settings, so use this version's instead
+	currentVersion := fmt.Sprintf("v%s", shared.RocketPoolVersion)
+	if snapshot.Version != currentVersion {
+		err = cfg.UpdateDefaults()
+		if err != nil {
+			return fmt.Errorf("error upgrading snapshot %d with the latest parameters: %w", id, err)
+		}
+		fmt.Printf("%sSnapshot %d was saved by Smart Node %s, so settings that are replaced on upgrade (such as container versions) will use the %s defaults instead.%s\n\n", colorYellow, id, snapshot.Version, currentVersion, colorReset)
+	}
+
+	// Validate it
+	errors := cfg.Validate()
+	if len(errors) > 0 {
+		fmt.Printf("%sSnapshot %d has errors with this version of the Smart Node. You must correct the following in order to use it:\n\n", colorRed, id)
+		for _, err := range errors {
+			fmt.Printf("%s\n\n", err)
+		}
+		fmt.Print(colorReset)
+		return fmt.Errorf("snapshot %d is not valid", id)
+	}
+
+	// Print the plan
+	fmt.Printf("Rolling back to snapshot %d will make the following changes:\n\n", id)
+	changedSettings, affectedContainers, changeNetworks := cfg.GetChanges(currentCfg)
+	changes, containers := printConfigPlan(cfg, changedSettings, affectedContainers)
+	output.Set("changes", changes)
+	output.Set("containers", containers)
+	if changeNetworks {
+		return fmt.Errorf("Snapshot %d is for a different network. Please use `rocketpool service config` to change networks.", id)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("Your configuration already matches snapshot %d; there's nothing to roll back.\n", id)
+		return nil
+	}
+
+	// Save it
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to roll back to snapshot %d?", id))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Println("Your settings have been rolled back!")
+	if c.GlobalIsSet("daemon-path") {
+		fmt.Println("Please restart your daemon service for them to take effect.")
+		return nil
+	}
+	return restartAffectedContainers(c, rp, cfg, containers)
+
+}
+
+// Load the config held by a snapshot in the config history
+func loadConfigSnapshot(configPath string, id uint64) (*config.RocketPoolConfig, error) {
+	snapshot, err := rputils.LoadConfigSnapshot(configPath, id)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.GetConfig()
+}
@@ -19,6 +19,12 @@ var alertingParametersNativeMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_MissedAttestations":          nil,
+	"alertEnabled_MissedProposal":              nil,
+	"alertEnabled_SyncCommitteeDuty":           nil,
+	"alertEnabled_LowNodeBalance":              nil,
+	"missedAttestationsWindow":                 nil,
+	"lowNodeBalanceThreshold":                  nil,
 }
 
 var alertingParametersDockerMode map[string]interface{} = map[string]interface{}{
@@ -43,6 +49,12 @@ var alertingParametersDockerMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_MissedAttestations":          nil,
+	"alertEnabled_MissedProposal":              nil,
+	"alertEnabled_SyncCommitteeDuty":           nil,
+	"alertEnabled_LowNodeBalance":              nil,
+	"missedAttestationsWindow":                 nil,
+	"lowNodeBalanceThreshold":                  nil,
 }
 
 // The page wrapper for the alerting config
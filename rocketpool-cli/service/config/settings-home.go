@@ -19,8 +19,10 @@ type settingsHome struct {
 	fallbackPage     *FallbackConfigPage
 	ccPage           *ConsensusConfigPage
 	mevBoostPage     *MevBoostConfigPage
+	signerPage       *RemoteSignerConfigPage
 	metricsPage      *MetricsConfigPage
 	alertingPage     *AlertingConfigPage
+	notifyPage       *NotificationsConfigPage
 	addonsPage       *AddonsPage
 	categoryList     *tview.List
 	settingsSubpages []settingsPage
@@ -45,8 +47,10 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.ccPage = NewConsensusConfigPage(home)
 	home.fallbackPage = NewFallbackConfigPage(home)
 	home.mevBoostPage = NewMevBoostConfigPage(home)
+	home.signerPage = NewRemoteSignerConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
 	home.alertingPage = NewAlertingConfigPage(home)
+	home.notifyPage = NewNotificationsConfigPage(home)
 	home.addonsPage = NewAddonsPage(home)
 	settingsSubpages := []settingsPage{
 		home.smartnodePage,
@@ -54,8 +58,10 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.ccPage,
 		home.fallbackPage,
 		home.mevBoostPage,
+		home.signerPage,
 		home.metricsPage,
 		home.alertingPage,
+		home.notifyPage,
 		home.addonsPage,
 	}
 	home.settingsSubpages = settingsSubpages
@@ -234,6 +240,10 @@ func (home *settingsHome) refresh() {
 		home.mevBoostPage.layout.refresh()
 	}
 
+	if home.signerPage != nil {
+		home.signerPage.layout.refresh()
+	}
+
 	if home.metricsPage != nil {
 		home.metricsPage.layout.refresh()
 	}
@@ -241,4 +251,8 @@ func (home *settingsHome) refresh() {
 	if home.alertingPage != nil {
 		home.alertingPage.layout.refresh()
 	}
+
+	if home.notifyPage != nil {
+		home.notifyPage.layout.refresh()
+	}
 }
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the notification backend config
+type NotificationsConfigPage struct {
+	mainDisplay  *mainDisplay
+	homePage     *page
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+}
+
+// Creates a new page for the notification backend settings
+func NewNotificationsConfigPage(home *settingsHome) *NotificationsConfigPage {
+	configPage := &NotificationsConfigPage{
+		mainDisplay:  home.md,
+		homePage:     home.homePage,
+		masterConfig: home.md.Config,
+	}
+
+	configPage.createContent()
+	configPage.initPage(false)
+
+	return configPage
+}
+
+// Creates a new page for the notification backend settings in Native mode
+func NewNotificationsConfigPageForNative(home *settingsNativeHome) *NotificationsConfigPage {
+	configPage := &NotificationsConfigPage{
+		mainDisplay:  home.md,
+		homePage:     home.homePage,
+		masterConfig: home.md.Config,
+	}
+
+	configPage.createContent()
+	configPage.initPage(true)
+
+	return configPage
+}
+
+func (configPage *NotificationsConfigPage) initPage(isNative bool) {
+	id := "settings-notifications"
+	if isNative {
+		id = "settings-notifications-native"
+	}
+	configPage.page = newPage(
+		configPage.homePage,
+		id,
+		"Notifications",
+		"Select this to configure where the Smartnode's alerts are sent, such as webhooks, Discord / Slack, email, or ntfy / Gotify. These don't require metrics to be enabled.",
+		configPage.layout.grid,
+	)
+}
+
+// Get the underlying page
+func (configPage *NotificationsConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the UI form items of the notifications config page
+func (configPage *NotificationsConfigPage) createContent() {
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Notification Settings")
+	configPage.layout.setupEscapeReturnHomeHandler(configPage.mainDisplay, configPage.homePage)
+
+	// Set up the UI components
+	formItems := createParameterizedFormItems(configPage.masterConfig.Notifications.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(formItems...)
+	configPage.layout.addFormItems(formItems)
+	configPage.layout.refresh()
+}
+
+// Handle a bulk redraw request
+func (configPage *NotificationsConfigPage) handleLayoutChanged() {
+	configPage.layout.refresh()
+}
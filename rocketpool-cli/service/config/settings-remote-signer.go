@@ -0,0 +1,82 @@
+package config
+
+import (
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the remote signer config
+type RemoteSignerConfigPage struct {
+	home                  *settingsHome
+	page                  *page
+	layout                *standardLayout
+	masterConfig          *config.RocketPoolConfig
+	enableRemoteSignerBox *parameterizedFormItem
+	remoteSignerItems     []*parameterizedFormItem
+}
+
+// Creates a new page for the remote signer settings
+func NewRemoteSignerConfigPage(home *settingsHome) *RemoteSignerConfigPage {
+
+	configPage := &RemoteSignerConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-remote-signer",
+		"Remote Signer",
+		"Select this to keep your validator keys in a Web3Signer-compatible remote signer instead of on this machine.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *RemoteSignerConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the remote signer settings page
+func (configPage *RemoteSignerConfigPage) createContent() {
+
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Remote Signer Settings")
+	configPage.layout.setupEscapeReturnHomeHandler(configPage.home.md, configPage.home.homePage)
+
+	// Set up the form items
+	configPage.enableRemoteSignerBox = createParameterizedCheckbox(&configPage.masterConfig.EnableRemoteSigner)
+	configPage.remoteSignerItems = createParameterizedFormItems(configPage.masterConfig.RemoteSigner.GetParameters(), configPage.layout.descriptionBox)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enableRemoteSignerBox)
+	configPage.layout.mapParameterizedFormItems(configPage.remoteSignerItems...)
+
+	// Set up the setting callbacks
+	configPage.enableRemoteSignerBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if configPage.masterConfig.EnableRemoteSigner.Value == checked {
+			return
+		}
+		configPage.masterConfig.EnableRemoteSigner.Value = checked
+		configPage.handleLayoutChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the Enable Remote Signer box has changed
+func (configPage *RemoteSignerConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.form.AddFormItem(configPage.enableRemoteSignerBox.item)
+
+	if configPage.masterConfig.EnableRemoteSigner.Value == true {
+		configPage.layout.addFormItems(configPage.remoteSignerItems)
+	}
+
+	configPage.layout.refresh()
+}
@@ -0,0 +1,627 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// Thresholds for the doctor's checks
+const (
+	doctorMinEcPeers        uint64        = 5
+	doctorMinBcPeers        uint64        = 10
+	doctorClockWarnOffset   float64       = 0.5
+	doctorClockFailOffset   float64       = 2
+	doctorMaxHeadLag        float64       = 3
+	doctorMinFreeSpace      uint64        = 10 * 1024 * 1024 * 1024
+	doctorTypicalTxGas      int64         = 300000
+	doctorTransactionBuffer int64         = 10
+	doctorRelayTimeout      time.Duration = 10 * time.Second
+	doctorRelayStatusPath   string        = "/eth/v1/builder/status"
+)
+
+// The result of a check
+type doctorResult string
+
+const (
+	doctorResult_Pass doctorResult = "pass"
+	doctorResult_Warn doctorResult = "warn"
+	doctorResult_Fail doctorResult = "fail"
+	doctorResult_Skip doctorResult = "skip"
+)
+
+// A check run by the doctor, with a suggestion for fixing it if it didn't pass
+type doctorCheck struct {
+	Name    string       `json:"name" yaml:"name"`
+	Result  doctorResult `json:"result" yaml:"result"`
+	Message string       `json:"message" yaml:"message"`
+	Fix     string       `json:"fix,omitempty" yaml:"fix,omitempty"`
+}
+
+// A port that the config can publish on the host
+type doctorPort struct {
+	name      string
+	container string
+	port      uint16
+	mode      cfgtypes.RPCMode
+}
+
+// Run a suite of health and misconfiguration checks against the node
+func runDoctor(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+
+	// Get the node daemon's view of the node; the local checks can still run without it
+	checks := []doctorCheck{}
+	var status *api.ServiceDoctorResponse
+	response, err := rp.GetDoctorStatus()
+	if err != nil {
+		checks = append(checks, doctorCheck{
+			Name:    "Node daemon",
+			Result:  doctorResult_Fail,
+			Message: err.Error(),
+			Fix:     "Make sure the Smart Node is running with `rocketpool service status`, and start it with `rocketpool service start` if it isn't.",
+		})
+	} else {
+		status = &response
+	}
+
+	// Run the checks
+	if status != nil {
+		checks = append(checks, checkClientManager("Execution client", status.EcManagerStatus, doctorMinEcPeers, "eth1")...)
+		checks = append(checks, checkClientManager("Beacon node", status.BcManagerStatus, doctorMinBcPeers, "eth2")...)
+		checks = append(checks, checkClock(status))
+	}
+	checks = append(checks, checkDiskSpace(rp, cfg)...)
+	checks = append(checks, checkPorts(rp, cfg)...)
+	if status != nil {
+		checks = append(checks, checkFeeRecipient(status))
+		checks = append(checks, checkValidatorKeys(status))
+	}
+	checks = append(checks, checkMevRelays(cfg)...)
+	if status != nil {
+		checks = append(checks, checkGasBalance(status))
+	}
+
+	// Print them
+	output.Set("checks", checks)
+	counts := map[doctorResult]int{}
+	for _, check := range checks {
+		counts[check.Result]++
+		color := colorGreen
+		switch check.Result {
+		case doctorResult_Warn:
+			color = colorYellow
+		case doctorResult_Fail:
+			color = colorRed
+		case doctorResult_Skip:
+			color = colorLightBlue
+		}
+		fmt.Printf("%s[%s]%s %s: %s\n", color, check.Result, colorReset, check.Name, check.Message)
+		if check.Fix != "" {
+			fmt.Printf("       Fix: %s\n", check.Fix)
+		}
+	}
+	fmt.Println()
+	fmt.Printf("%d passed, %d warnings, %d failed, %d skipped.\n", counts[doctorResult_Pass], counts[doctorResult_Warn], counts[doctorResult_Fail], counts[doctorResult_Skip])
+	fmt.Println("To share these results in a support ticket, run `rocketpool --output json service doctor`.")
+	return nil
+
+}
+
+// Check the sync status and peers of a client and its fallback
+func checkClientManager(name string, status api.ClientManagerStatus, minPeers uint64, container string) []doctorCheck {
+	checks := []doctorCheck{
+		checkClient(name, status.PrimaryClientStatus, minPeers, fmt.Sprintf("Check its logs with `rocketpool service logs %s`, or the logs of your externally managed client.", container)),
+	}
+	if !status.FallbackEnabled {
+		checks = append(checks, doctorCheck{
+			Name:    fmt.Sprintf("Fallback %s", name),
+			Result:  doctorResult_Skip,
+			Message: "No fallback is configured.",
+		})
+		return checks
+	}
+	checks = append(checks, checkClient(fmt.Sprintf("Fallback %s", name), status.FallbackClientStatus, 0, "Make sure the fallback's URL in `rocketpool service config` is correct and that it's reachable from this machine."))
+	return checks
+}
+
+// Check the sync status and peers of a client
+func checkClient(name string, status api.ClientStatus, minPeers uint64, fix string) doctorCheck {
+	check := doctorCheck{Name: name}
+	switch {
+	case !status.IsWorking:
+		check.Result = doctorResult_Fail
+		check.Message = fmt.Sprintf("It isn't responding: %s", status.Error)
+		check.Fix = fix
+	case !status.IsSynced:
+		check.Result = doctorResult_Warn
+		check.Message = fmt.Sprintf("It's still syncing (%.2f%%).", status.SyncProgress*100)
+		if status.Error != "" {
+			check.Message = fmt.Sprintf("It isn't synced: %s", status.Error)
+		}
+		check.Fix = "Wait for it to finish syncing; you can follow its progress with `rocketpool node sync`."
+	default:
+		check.Result = doctorResult_Pass
+		check.Message = "It's synced."
+	}
+	if status.PeerCount != nil && status.IsWorking {
+		check.Message += fmt.Sprintf(" It has %d peer(s).", *status.PeerCount)
+		if *status.PeerCount < minPeers && check.Result == doctorResult_Pass {
+			check.Result = doctorResult_Warn
+			check.Fix = "Make sure your router forwards the client's P2P port to this machine and that your firewall allows it; the ports are in `rocketpool service config`."
+		}
+	}
+	return check
+}
+
+// Check the local clock against a time server, and against the Beacon chain's slot clock as a second opinion.
+// The slot clock can only show drift of a second or more, so it's the only check if the time server can't be reached.
+func checkClock(status *api.ServiceDoctorResponse) doctorCheck {
+	check := doctorCheck{Name: "Clock"}
+	clock := status.Clock
+	fix := "Make sure the system clock is synchronized, e.g. with chrony or `timedatectl set-ntp true` on systemd-based systems."
+
+	// Compare the clock with the time server
+	timeServerMessage := ""
+	if clock.TimeServerError == "" {
+		drift := math.Abs(clock.TimeServerOffset)
+		direction := "behind"
+		if clock.TimeServerOffset < 0 {
+			direction = "ahead of"
+		}
+		switch {
+		case drift > doctorClockFailOffset:
+			check.Result = doctorResult_Fail
+		case drift > doctorClockWarnOffset:
+			check.Result = doctorResult_Warn
+		}
+		if check.Result != "" {
+			check.Message = fmt.Sprintf("The clock is %.3f seconds %s %s.", drift, direction, clock.TimeServer)
+			check.Fix = fix
+			return check
+		}
+		timeServerMessage = fmt.Sprintf("The clock is within %.3f seconds of %s", drift, clock.TimeServer)
+	}
+
+	// Compare it with the Beacon chain's head
+	slotClockMessage := ""
+	switch {
+	case clock.Error != "" || clock.SecondsPerSlot == 0:
+		slotClockMessage = fmt.Sprintf("the Beacon node's slot clock isn't available: %s", clock.Error)
+	case !status.BcManagerStatus.PrimaryClientStatus.IsSynced && !status.BcManagerStatus.FallbackClientStatus.IsSynced:
+		slotClockMessage = "the Beacon node isn't synced, so its head can't be compared with the clock"
+	}
+	if slotClockMessage != "" {
+		if timeServerMessage == "" {
+			check.Result = doctorResult_Skip
+			check.Message = fmt.Sprintf("Couldn't reach the time server (%s), and %s.", clock.TimeServerError, slotClockMessage)
+			return check
+		}
+		check.Result = doctorResult_Pass
+		check.Message = fmt.Sprintf("%s; %s.", timeServerMessage, slotClockMessage)
+		return check
+	}
+
+	// A head block can't be seen before its slot starts, so a head that starts in the future means the clock is behind
+	headStart := time.Unix(int64(clock.GenesisTime+clock.HeadSlot*clock.SecondsPerSlot), 0)
+	offset := headStart.Sub(clock.SystemTime).Seconds()
+	lag := -offset / float64(clock.SecondsPerSlot)
+	switch {
+	case offset > doctorClockFailOffset:
+		check.Result = doctorResult_Fail
+		check.Message = fmt.Sprintf("The clock is at least %.1f seconds behind the Beacon chain.", offset)
+		check.Fix = fix
+	case offset > doctorClockWarnOffset:
+		check.Result = doctorResult_Warn
+		check.Message = fmt.Sprintf("The clock is at least %.1f seconds behind the Beacon chain.", offset)
+		check.Fix = fix
+	case lag > doctorMaxHeadLag:
+		check.Result = doctorResult_Warn
+		check.Message = fmt.Sprintf("The Beacon node's head (slot %d) is %.1f slots behind the clock, so either the clock is ahead or the Beacon node is falling behind.", clock.HeadSlot, lag)
+		check.Fix = fix + " If it is, check the Beacon node's peers and logs."
+	case timeServerMessage == "":
+		check.Result = doctorResult_Warn
+		check.Message = fmt.Sprintf("The clock agrees with the Beacon chain (head slot %d), but that can't show drift of less than a second, and the time server couldn't be reached: %s", clock.HeadSlot, clock.TimeServerError)
+		check.Fix = "Allow outgoing NTP traffic (UDP port 123) so the clock can be checked precisely."
+	default:
+		check.Result = doctorResult_Pass
+		check.Message = fmt.Sprintf("%s and agrees with the Beacon chain (head slot %d).", timeServerMessage, clock.HeadSlot)
+	}
+	return check
+}
+
+// Check the free space on the disks that hold the client data volumes
+func checkDiskSpace(rp *rocketpool.Client, cfg *config.RocketPoolConfig) []doctorCheck {
+	if cfg.IsNativeMode || rp.IsRemote() {
+		return []doctorCheck{{
+			Name:    "Disk space",
+			Result:  doctorResult_Skip,
+			Message: "Client volumes can only be checked for Docker-mode nodes on this machine.",
+		}}
+	}
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return []doctorCheck{{
+			Name:    "Disk space",
+			Result:  doctorResult_Skip,
+			Message: fmt.Sprintf("Couldn't get the container prefix: %s", err.Error()),
+		}}
+	}
+
+	clients := []struct {
+		name      string
+		container string
+		local     bool
+	}{
+		{"Execution client disk space", prefix + ExecutionContainerSuffix, cfg.ExecutionClientLocal()},
+		{"Beacon node disk space", prefix + BeaconContainerSuffix, cfg.ConsensusClientLocal()},
+	}
+	checks := []doctorCheck{}
+	for _, client := range clients {
+		if !client.local {
+			continue
+		}
+		check := doctorCheck{Name: client.name}
+		volumePath, err := rp.GetClientVolumeSource(client.container, clientDataVolumeName)
+		if err != nil || volumePath == "" {
+			check.Result = doctorResult_Skip
+			check.Message = fmt.Sprintf("Couldn't find the data volume of %s; it may not be deployed.", client.container)
+			checks = append(checks, check)
+			continue
+		}
+		freeSpace, err := getPartitionFreeSpace(rp, volumePath)
+		if err != nil {
+			check.Result = doctorResult_Skip
+			check.Message = fmt.Sprintf("Couldn't get the free space for %s: %s", volumePath, err.Error())
+			checks = append(checks, check)
+			continue
+		}
+		check.Message = fmt.Sprintf("%s free", humanize.IBytes(freeSpace))
+		volume, err := rp.GetClientVolumeName(client.container, clientDataVolumeName)
+		if err == nil {
+			if used, err := getVolumeSpaceUsed(rp, volume); err == nil {
+				check.Message += fmt.Sprintf(", %s used by %s", humanize.IBytes(used), volume)
+			}
+		}
+		check.Message += "."
+		fix := "Free some space, prune your Execution client with `rocketpool service prune-eth1`, or move your chain data to a larger disk."
+		switch {
+		case freeSpace < doctorMinFreeSpace:
+			check.Result = doctorResult_Fail
+			check.Fix = fix
+		case freeSpace < PruneFreeSpaceRequired:
+			check.Result = doctorResult_Warn
+			check.Message += fmt.Sprintf(" That's less than the %s needed to prune.", humanize.IBytes(PruneFreeSpaceRequired))
+			check.Fix = fix
+		default:
+			check.Result = doctorResult_Pass
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// Check that the ports the containers publish match the RPC modes in the config
+func checkPorts(rp *rocketpool.Client, cfg *config.RocketPoolConfig) []doctorCheck {
+	if cfg.IsNativeMode {
+		return []doctorCheck{{
+			Name:    "Port exposure",
+			Result:  doctorResult_Skip,
+			Message: "Ports are managed by your own services in Native mode.",
+		}}
+	}
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return []doctorCheck{{
+			Name:    "Port exposure",
+			Result:  doctorResult_Skip,
+			Message: fmt.Sprintf("Couldn't get the container prefix: %s", err.Error()),
+		}}
+	}
+
+	// Compare the ports the config can publish with what's actually published
+	bindingsByContainer := map[string]map[string][]rocketpool.DockerPortBinding{}
+	checks := []doctorCheck{}
+	for _, port := range getDoctorPorts(cfg) {
+		containerName := fmt.Sprintf("%s_%s", prefix, port.container)
+		bindings, exists := bindingsByContainer[containerName]
+		if !exists {
+			bindings, err = rp.GetContainerPortBindings(containerName)
+			if err != nil {
+				checks = append(checks, doctorCheck{
+					Name:    fmt.Sprintf("%s port %d", port.name, port.port),
+					Result:  doctorResult_Skip,
+					Message: fmt.Sprintf("Couldn't inspect %s; it may not be deployed.", containerName),
+				})
+				continue
+			}
+			bindingsByContainer[containerName] = bindings
+		}
+		checks = append(checks, checkPortExposure(port, bindings[fmt.Sprintf("%d/tcp", port.port)]))
+	}
+	return checks
+}
+
+// Get the ports the config can publish
+func getDoctorPorts(cfg *config.RocketPoolConfig) []doctorPort {
+	ports := []doctorPort{}
+	if cfg.ExecutionClientLocal() {
+		mode := cfg.ExecutionCommon.OpenRpcPorts.Value.(cfgtypes.RPCMode)
+		ports = append(ports,
+			doctorPort{"Execution client HTTP RPC", config.Eth1ContainerName, cfg.ExecutionCommon.HttpPort.Value.(uint16), mode},
+			doctorPort{"Execution client WebSocket RPC", config.Eth1ContainerName, cfg.ExecutionCommon.WsPort.Value.(uint16), mode},
+		)
+	}
+	if cfg.ConsensusClientLocal() {
+		ports = append(ports, doctorPort{"Beacon node API", config.Eth2ContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16), cfg.ConsensusCommon.OpenApiPort.Value.(cfgtypes.RPCMode)})
+		if cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient) == cfgtypes.ConsensusClient_Prysm {
+			ports = append(ports, doctorPort{"Prysm gRPC", config.Eth2ContainerName, cfg.Prysm.RpcPort.Value.(uint16), cfg.Prysm.OpenRpcPort.Value.(cfgtypes.RPCMode)})
+		}
+	}
+	if cfg.EnableMetrics.Value == true {
+		ports = append(ports, doctorPort{"Prometheus", config.PrometheusContainerName, cfg.Prometheus.Port.Value.(uint16), cfg.Prometheus.OpenPort.Value.(cfgtypes.RPCMode)})
+	}
+	if cfg.Smartnode.EnableDashboard.Value == true {
+		ports = append(ports, doctorPort{"Dashboard", config.NodeContainerName, cfg.Smartnode.DashboardPort.Value.(uint16), cfg.Smartnode.OpenDashboardPort.Value.(cfgtypes.RPCMode)})
+	}
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		ports = append(ports, doctorPort{"MEV-Boost", config.MevBoostContainerName, cfg.MevBoost.Port.Value.(uint16), cfg.MevBoost.OpenRpcPort.Value.(cfgtypes.RPCMode)})
+	}
+	return ports
+}
+
+// Compare the host addresses a port is published on with its RPC mode
+func checkPortExposure(port doctorPort, bindings []rocketpool.DockerPortBinding) doctorCheck {
+	check := doctorCheck{Name: fmt.Sprintf("%s port %d", port.name, port.port)}
+	restartFix := "Run `rocketpool service start` to redeploy the container with your settings, and check that none of your override files publish the port."
+
+	// Find the most exposed host address it's published on
+	published := false
+	external := false
+	for _, binding := range bindings {
+		published = true
+		if binding.HostIp != "127.0.0.1" && binding.HostIp != "::1" {
+			external = true
+		}
+	}
+
+	switch {
+	case external && port.mode != cfgtypes.RPC_OpenExternal:
+		check.Result = doctorResult_Fail
+		check.Message = fmt.Sprintf("It's published to external hosts, but it's set to [%s].", port.mode)
+		check.Fix = restartFix
+	case published && !port.mode.Open():
+		check.Result = doctorResult_Fail
+		check.Message = fmt.Sprintf("It's published to this host, but it's set to [%s].", port.mode)
+		check.Fix = restartFix
+	case !published && port.mode.Open():
+		check.Result = doctorResult_Warn
+		check.Message = fmt.Sprintf("It's set to [%s], but it isn't published.", port.mode)
+		check.Fix = restartFix
+	case external:
+		check.Result = doctorResult_Warn
+		check.Message = "It's open to external hosts, as set in the config."
+		check.Fix = "Make sure a firewall only lets trusted hosts reach it, or set it to Open to Localhost in `rocketpool service config`."
+	default:
+		check.Result = doctorResult_Pass
+		check.Message = fmt.Sprintf("Its exposure matches its setting [%s].", port.mode)
+	}
+	return check
+}
+
+// Check the fee recipient file against the node's smoothing pool state
+func checkFeeRecipient(status *api.ServiceDoctorResponse) doctorCheck {
+	check := doctorCheck{Name: "Fee recipient"}
+	if skip, message := getChainCheckSkipReason(status); skip {
+		check.Result = doctorResult_Skip
+		check.Message = message
+		return check
+	}
+	feeRecipient := status.FeeRecipient
+	if feeRecipient.Error != "" {
+		check.Result = doctorResult_Warn
+		check.Message = feeRecipient.Error
+		return check
+	}
+
+	recipientName := "your fee distributor"
+	if feeRecipient.IsInSmoothingPool {
+		recipientName = "the Smoothing Pool"
+	} else if feeRecipient.IsInOptOutCooldown {
+		recipientName = "the Smoothing Pool until your opt-out finalizes"
+	}
+	fix := "The node container updates it automatically within a few minutes; if this persists, check `rocketpool service logs node`."
+	switch {
+	case !feeRecipient.FileExists:
+		check.Result = doctorResult_Fail
+		check.Message = fmt.Sprintf("The fee recipient file is missing; it should be %s (%s).", recipientName, feeRecipient.ExpectedRecipient.Hex())
+		check.Fix = fix
+	case !feeRecipient.FileCorrect:
+		check.Result = doctorResult_Fail
+		check.Message = fmt.Sprintf("The fee recipient file doesn't match your Smoothing Pool state; it should be %s (%s).", recipientName, feeRecipient.ExpectedRecipient.Hex())
+		check.Fix = fix
+	default:
+		check.Result = doctorResult_Pass
+		check.Message = fmt.Sprintf("The fee recipient file is set to %s (%s).", recipientName, feeRecipient.ExpectedRecipient.Hex())
+	}
+	return check
+}
+
+// Check that there's a validator key for each validating minipool
+func checkValidatorKeys(status *api.ServiceDoctorResponse) doctorCheck {
+	check := doctorCheck{Name: "Validator keys"}
+	if skip, message := getChainCheckSkipReason(status); skip {
+		check.Result = doctorResult_Skip
+		check.Message = message
+		return check
+	}
+	keys := status.ValidatorKeys
+	switch {
+	case keys.Error != "":
+		check.Result = doctorResult_Warn
+		check.Message = keys.Error
+	case len(keys.MissingKeys) > 0:
+		check.Result = doctorResult_Fail
+		check.Message = fmt.Sprintf("%d of your %d validating minipools don't have a key in %s:", len(keys.MissingKeys), keys.MinipoolCount, keys.Source)
+		for _, pubkey := range keys.MissingKeys {
+			check.Message += fmt.Sprintf(" %s", pubkey.Hex())
+		}
+		check.Fix = "Restore them with `rocketpool wallet rebuild`, or import any custom keys into the custom-keys folder and run it again."
+	case keys.MinipoolCount == 0:
+		check.Result = doctorResult_Pass
+		check.Message = "You don't have any validating minipools."
+	default:
+		check.Result = doctorResult_Pass
+		check.Message = fmt.Sprintf("All %d of your validating minipools have keys in %s.", keys.MinipoolCount, keys.Source)
+	}
+	return check
+}
+
+// Check that each enabled MEV-Boost relay is reachable
+func checkMevRelays(cfg *config.RocketPoolConfig) []doctorCheck {
+	if cfg.EnableMevBoost.Value != true {
+		return []doctorCheck{{
+			Name:    "MEV-Boost relays",
+			Result:  doctorResult_Skip,
+			Message: "MEV-Boost is disabled.",
+		}}
+	}
+	if cfg.MevBoost.Mode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		return []doctorCheck{{
+			Name:    "MEV-Boost relays",
+			Result:  doctorResult_Skip,
+			Message: "Your externally managed MEV-Boost client chooses its own relays.",
+		}}
+	}
+	relays := cfg.MevBoost.GetEnabledMevRelays()
+	if len(relays) == 0 {
+		return []doctorCheck{{
+			Name:    "MEV-Boost relays",
+			Result:  doctorResult_Fail,
+			Message: "MEV-Boost is enabled, but none of its relays are.",
+			Fix:     "Enable at least one relay in the MEV-Boost section of `rocketpool service config`.",
+		}}
+	}
+
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	client := http.Client{Timeout: doctorRelayTimeout}
+	checks := []doctorCheck{}
+	for _, relay := range relays {
+		check := doctorCheck{Name: fmt.Sprintf("MEV-Boost relay %s", relay.Name)}
+		err := checkMevRelay(&client, relay.Urls[network])
+		if err != nil {
+			check.Result = doctorResult_Fail
+			check.Message = fmt.Sprintf("It isn't reachable: %s", err.Error())
+			check.Fix = "Check this machine's internet connection and DNS, or disable the relay in `rocketpool service config` if it's been shut down."
+		} else {
+			check.Result = doctorResult_Pass
+			check.Message = "It's reachable."
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// Query a relay's status endpoint
+func checkMevRelay(client *http.Client, relayUrl string) error {
+	target, err := url.Parse(relayUrl)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	// Relay URLs include the relay's pubkey as their user, which isn't needed for the status endpoint
+	target.User = nil
+	target.Path = doctorRelayStatusPath
+	response, err := client.Get(target.String())
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// Check that the node wallet has enough ETH to pay for transactions
+func checkGasBalance(status *api.ServiceDoctorResponse) doctorCheck {
+	check := doctorCheck{Name: "Gas balance"}
+	switch {
+	case !status.WalletInitialized:
+		check.Result = doctorResult_Fail
+		check.Message = "The node wallet isn't initialized."
+		check.Fix = "Create one with `rocketpool wallet init` or restore one with `rocketpool wallet recover`."
+		return check
+	case status.WalletLocked:
+		check.Result = doctorResult_Fail
+		check.Message = "The node wallet is locked."
+		check.Fix = "Unlock it with `rocketpool wallet unlock`."
+		return check
+	case status.ChainError != "":
+		check.Result = doctorResult_Skip
+		check.Message = status.ChainError
+		return check
+	case status.Gas.Error != "" || status.Gas.NodeBalance == nil || status.Gas.BaseFee == nil:
+		check.Result = doctorResult_Warn
+		check.Message = fmt.Sprintf("Couldn't get the node wallet's balance: %s", status.Gas.Error)
+		return check
+	}
+
+	// Compare the balance with the cost of a typical transaction at the current base fee
+	txCost := new(big.Int).Mul(status.Gas.BaseFee, big.NewInt(doctorTypicalTxGas))
+	bufferCost := new(big.Int).Mul(txCost, big.NewInt(doctorTransactionBuffer))
+	check.Message = fmt.Sprintf("The node wallet has %.6f ETH; a typical transaction costs about %.6f ETH at the current base fee of %.2f gwei.", eth.WeiToEth(status.Gas.NodeBalance), eth.WeiToEth(txCost), eth.WeiToGwei(status.Gas.BaseFee))
+	fix := fmt.Sprintf("Send some ETH to the node wallet (%s).", status.AccountAddress.Hex())
+	switch {
+	case status.Gas.NodeBalance.Cmp(txCost) < 0:
+		check.Result = doctorResult_Fail
+		check.Fix = fix
+	case status.Gas.NodeBalance.Cmp(bufferCost) < 0:
+		check.Result = doctorResult_Warn
+		check.Message += fmt.Sprintf(" That's less than %d transactions' worth.", doctorTransactionBuffer)
+		check.Fix = fix
+	default:
+		check.Result = doctorResult_Pass
+	}
+	return check
+}
+
+// Get the reason the checks that need the node's on-chain state can't run, if there is one
+func getChainCheckSkipReason(status *api.ServiceDoctorResponse) (bool, string) {
+	switch {
+	case !status.WalletInitialized:
+		return true, "The node wallet isn't initialized."
+	case status.WalletLocked:
+		return true, "The node wallet is locked."
+	case status.ChainError != "":
+		return true, status.ChainError
+	case !status.Registered:
+		return true, "The node isn't registered with Rocket Pool."
+	}
+	return false, ""
+}
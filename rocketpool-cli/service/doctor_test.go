@@ -0,0 +1,194 @@
+package service
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get a doctor status with a synced Beacon node whose head slot started the given number of seconds before the clock
+func newClockStatus(timeServerOffset float64, timeServerError string, headAge int64) *api.ServiceDoctorResponse {
+	status := &api.ServiceDoctorResponse{}
+	status.BcManagerStatus.PrimaryClientStatus.IsSynced = true
+	status.Clock = api.DoctorClockStatus{
+		SystemTime:       time.Unix(1000000+headAge, 0),
+		TimeServer:       "pool.ntp.org",
+		TimeServerOffset: timeServerOffset,
+		TimeServerError:  timeServerError,
+		GenesisTime:      1000000 - 12*100,
+		SecondsPerSlot:   12,
+		HeadSlot:         100,
+	}
+	return status
+}
+
+func TestCheckClock(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   *api.ServiceDoctorResponse
+		expected doctorResult
+	}{
+		{"in sync", newClockStatus(0.05, "", 4), doctorResult_Pass},
+		{"sub-second drift behind", newClockStatus(0.8, "", 4), doctorResult_Warn},
+		{"sub-second drift ahead", newClockStatus(-0.8, "", 4), doctorResult_Warn},
+		{"large drift", newClockStatus(-3, "", 4), doctorResult_Fail},
+		{"behind the Beacon head", newClockStatus(0, "", -3), doctorResult_Fail},
+		{"far ahead of the Beacon head", newClockStatus(0, "", 60), doctorResult_Warn},
+		{"no time server", newClockStatus(0, "timeout", 4), doctorResult_Warn},
+		{"no time server or Beacon node", func() *api.ServiceDoctorResponse {
+			status := newClockStatus(0, "timeout", 4)
+			status.Clock.Error = "The Beacon node isn't available"
+			return status
+		}(), doctorResult_Skip},
+		{"no Beacon node", func() *api.ServiceDoctorResponse {
+			status := newClockStatus(0.1, "", 4)
+			status.BcManagerStatus.PrimaryClientStatus.IsSynced = false
+			return status
+		}(), doctorResult_Pass},
+	}
+	for _, test := range tests {
+		check := checkClock(test.status)
+		if check.Result != test.expected {
+			t.Errorf("%s: expected %s, got %s (%s)", test.name, test.expected, check.Result, check.Message)
+		}
+		if check.Result != doctorResult_Pass && check.Result != doctorResult_Skip && check.Fix == "" {
+			t.Errorf("%s: expected a fix for a %s result", test.name, check.Result)
+		}
+	}
+}
+
+func TestCheckPortExposure(t *testing.T) {
+	localhost := []rocketpool.DockerPortBinding{{HostIp: "127.0.0.1", HostPort: "8545"}}
+	external := []rocketpool.DockerPortBinding{{HostIp: "127.0.0.1", HostPort: "8545"}, {HostIp: "0.0.0.0", HostPort: "8545"}}
+	tests := []struct {
+		name     string
+		mode     cfgtypes.RPCMode
+		bindings []rocketpool.DockerPortBinding
+		expected doctorResult
+	}{
+		{"closed and unpublished", cfgtypes.RPC_Closed, nil, doctorResult_Pass},
+		{"closed but published locally", cfgtypes.RPC_Closed, localhost, doctorResult_Fail},
+		{"closed but published externally", cfgtypes.RPC_Closed, external, doctorResult_Fail},
+		{"localhost and published locally", cfgtypes.RPC_OpenLocalhost, localhost, doctorResult_Pass},
+		{"localhost but published externally", cfgtypes.RPC_OpenLocalhost, external, doctorResult_Fail},
+		{"localhost but unpublished", cfgtypes.RPC_OpenLocalhost, nil, doctorResult_Warn},
+		{"external and published externally", cfgtypes.RPC_OpenExternal, external, doctorResult_Warn},
+		{"external but published locally", cfgtypes.RPC_OpenExternal, localhost, doctorResult_Pass},
+		{"external but unpublished", cfgtypes.RPC_OpenExternal, nil, doctorResult_Warn},
+	}
+	for _, test := range tests {
+		check := checkPortExposure(doctorPort{"Execution client HTTP RPC", "eth1", 8545, test.mode}, test.bindings)
+		if check.Result != test.expected {
+			t.Errorf("%s: expected %s, got %s (%s)", test.name, test.expected, check.Result, check.Message)
+		}
+	}
+}
+
+// Get a doctor status for a node wallet with the given balance at a base fee of 10 gwei
+func newGasStatus(balance float64) *api.ServiceDoctorResponse {
+	status := &api.ServiceDoctorResponse{WalletInitialized: true}
+	status.Gas.NodeBalance = eth.EthToWei(balance)
+	status.Gas.BaseFee = eth.GweiToWei(10)
+	return status
+}
+
+func TestCheckGasBalance(t *testing.T) {
+	// A typical transaction costs 300,000 gas * 10 gwei = 0.003 ETH
+	tests := []struct {
+		name     string
+		status   *api.ServiceDoctorResponse
+		expected doctorResult
+	}{
+		{"plenty", newGasStatus(1), doctorResult_Pass},
+		{"less than ten transactions", newGasStatus(0.01), doctorResult_Warn},
+		{"less than one transaction", newGasStatus(0.001), doctorResult_Fail},
+		{"empty", newGasStatus(0), doctorResult_Fail},
+		{"uninitialized wallet", &api.ServiceDoctorResponse{}, doctorResult_Fail},
+		{"locked wallet", &api.ServiceDoctorResponse{WalletInitialized: true, WalletLocked: true}, doctorResult_Fail},
+		{"no chain", &api.ServiceDoctorResponse{WalletInitialized: true, ChainError: "None of your Execution clients are synced"}, doctorResult_Skip},
+		{"no balance", func() *api.ServiceDoctorResponse {
+			status := newGasStatus(1)
+			status.Gas.NodeBalance = nil
+			status.Gas.Error = "Error getting node balance"
+			return status
+		}(), doctorResult_Warn},
+	}
+	for _, test := range tests {
+		check := checkGasBalance(test.status)
+		if check.Result != test.expected {
+			t.Errorf("%s: expected %s, got %s (%s)", test.name, test.expected, check.Result, check.Message)
+		}
+	}
+
+	// Exactly enough ETH for one transaction is enough to pass the lower threshold
+	status := newGasStatus(0)
+	status.Gas.NodeBalance = new(big.Int).Mul(status.Gas.BaseFee, big.NewInt(doctorTypicalTxGas))
+	if check := checkGasBalance(status); check.Result != doctorResult_Warn {
+		t.Errorf("exactly one transaction: expected %s, got %s", doctorResult_Warn, check.Result)
+	}
+}
+
+func TestCheckMevRelay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != doctorRelayStatusPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.User != nil || r.Header.Get("Authorization") != "" {
+			t.Errorf("the relay's pubkey shouldn't be sent")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := server.Client()
+
+	// Relay URLs include the relay's pubkey as their user
+	relayUrl := "http://0xac6e77dfe25ecd6110b8e780608cce0dab71fdd5ebea22a16c0205200f2f8e2e3ad3b71d3499c54ad14d6c21b41a37ae@" + server.Listener.Addr().String()
+	if err := checkMevRelay(client, relayUrl); err != nil {
+		t.Errorf("expected the relay to be reachable, got %s", err.Error())
+	}
+	if err := checkMevRelay(client, server.URL+"/some/path"); err != nil {
+		t.Errorf("expected the status path to replace the relay's path, got %s", err.Error())
+	}
+
+	// Relays that are down or return an error status
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	if err := checkMevRelay(client, failing.URL); err == nil {
+		t.Error("expected an error for a relay that returns 503")
+	}
+	failing.Close()
+	if err := checkMevRelay(client, failing.URL); err == nil {
+		t.Error("expected an error for a relay that's down")
+	}
+	if err := checkMevRelay(client, "://not a url"); err == nil {
+		t.Error("expected an error for an invalid URL")
+	}
+}
+
+func TestCheckValidatorKeys(t *testing.T) {
+	status := &api.ServiceDoctorResponse{WalletInitialized: true, Registered: true}
+	status.ValidatorKeys.MinipoolCount = 2
+	status.ValidatorKeys.Source = "the remote signer"
+	if check := checkValidatorKeys(status); check.Result != doctorResult_Pass {
+		t.Errorf("expected %s with no missing keys, got %s (%s)", doctorResult_Pass, check.Result, check.Message)
+	}
+	status.ValidatorKeys.MissingKeys = append(status.ValidatorKeys.MissingKeys, [48]byte{1})
+	if check := checkValidatorKeys(status); check.Result != doctorResult_Fail || check.Fix == "" {
+		t.Errorf("expected %s with a fix for a missing key, got %s (%s)", doctorResult_Fail, check.Result, check.Message)
+	}
+	status.ValidatorKeys.Error = "Error checking validator keys"
+	if check := checkValidatorKeys(status); check.Result != doctorResult_Warn {
+		t.Errorf("expected %s when the keys couldn't be checked, got %s", doctorResult_Warn, check.Result)
+	}
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Restore the node from an encrypted backup
+func restoreNode(c *cli.Context, backupPath string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+	if rp.IsRemote() {
+		return fmt.Errorf("Backups can't be restored over SSH; please run this command on the node itself.")
+	}
+
+	// Get the current config, if there is one
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Decrypt the backup
+	backupPath, err = homedir.Expand(backupPath)
+	if err != nil {
+		return fmt.Errorf("error expanding backup path: %w", err)
+	}
+	backupFile, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("error opening backup: %w", err)
+	}
+	defer backupFile.Close()
+	tempFolder, err := os.MkdirTemp("", "rocketpool-restore-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary folder: %w", err)
+	}
+	defer os.RemoveAll(tempFolder)
+	passphrase := cliutils.PromptPassword("Please enter the backup's passphrase:", "^.+$", "")
+	fmt.Println("Decrypting the backup, this may take a few seconds...")
+	manifest, err := backup.Extract(backupFile, passphrase, tempFolder)
+	if err != nil {
+		return fmt.Errorf("error reading backup: %w", err)
+	}
+
+	// Print a summary
+	output.Set("manifest", manifest)
+	fmt.Printf("Backup of node %s%s%s on %s, created %s by Smartnode v%s:\n", colorLightBlue, manifest.NodeAddress.Hex(), colorReset, manifest.Network, manifest.CreatedAt.Local().Format("2006-01-02 15:04:05 MST"), manifest.SmartnodeVersion)
+	fmt.Printf("\t%d minipool(s)\n", len(manifest.MinipoolPubkeys))
+	fmt.Printf("\tWallet password included: %t\n", manifest.IncludesPassword)
+	if manifest.SlashingProtectionClient != "" {
+		fmt.Printf("\tSlashing protection exported from: %s\n", manifest.SlashingProtectionClient)
+	} else {
+		fmt.Println("\tSlashing protection: not included")
+	}
+	fmt.Printf("\t%d file(s)\n\n", len(manifest.Files))
+
+	// Don't mix networks
+	if !isNew && string(cfg.Smartnode.Network.Value.(cfgtypes.Network)) != manifest.Network {
+		return fmt.Errorf("This backup is for %s, but this node is configured for %s. Please change networks with `rocketpool service config` first.", manifest.Network, cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	}
+
+	// Load the slashing protection
+	var interchangePubkeys map[types.ValidatorPubkey]bool
+	slashingProtectionFile := filepath.Join(tempFolder, backup.SlashingProtectionPath)
+	if manifest.Has(backup.SlashingProtectionPath) {
+		interchange, err := rocketpool.LoadSlashingProtectionInterchange(slashingProtectionFile)
+		if err != nil {
+			return err
+		}
+		interchangePubkeys = interchange.Pubkeys()
+	}
+
+	// Make sure the wallet is the backup's node
+	var password string
+	if manifest.Has(backup.WalletPath) {
+		password, err = checkBackupWallet(tempFolder, manifest)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check the backup against the chain
+	if c.Bool("skip-chain-check") {
+		fmt.Printf("%sSkipping the check against the chain.%s\n\n", colorYellow, colorReset)
+	} else {
+		err = checkBackupAgainstChain(rp, manifest, interchangePubkeys)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Make sure the old node is gone
+	fmt.Printf("%s=== WARNING ===\n", colorRed)
+	fmt.Println("If the node this backup came from is still running, or could be started again, your validators WILL BE SLASHED once this node starts attesting with the same keys.")
+	fmt.Println("The slashing protection in the backup only covers what the old node signed up until the backup was made.")
+	fmt.Printf("Shut the old node down, make sure it can't restart (e.g. remove its validator keys or wipe it), and ideally wait at least 15 minutes before starting this one.%s\n\n", colorReset)
+	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree("Is the old node permanently offline?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the destinations
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	restoredCfg := cfg
+	if manifest.Has(backup.SettingsPath) {
+		restoredCfg, err = rputils.LoadConfigFromFile(filepath.Join(tempFolder, backup.SettingsPath))
+		if err != nil {
+			return fmt.Errorf("error loading the backup's settings: %w", err)
+		}
+	} else if isNew {
+		return fmt.Errorf("The backup doesn't have any settings, and neither does this node. Please run `rocketpool service config` first.")
+	}
+	dataPath, err := homedir.Expand(os.ExpandEnv(restoredCfg.Smartnode.DataPath.Value.(string)))
+	if err != nil {
+		return fmt.Errorf("error expanding data path: %w", err)
+	}
+
+	// Don't silently replace an existing wallet
+	walletPath := filepath.Join(dataPath, backup.WalletPath)
+	if _, err := os.Stat(walletPath); err == nil {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sThis node already has a wallet, which will be replaced by the one in the backup. Make sure you have its recovery mnemonic if you still need it. Continue?%s", colorYellow, colorReset))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Copy the files into place
+	savePassword := restoredCfg.Smartnode.SaveWalletPassword.Value.(bool)
+	restoredCount := 0
+	for _, file := range manifest.Files {
+		var targetPath string
+		switch {
+		case file.Path == backup.SlashingProtectionPath:
+			continue
+		case file.Path == backup.PasswordPath && !savePassword:
+			// The restored settings keep the password off of the disk
+			continue
+		case file.Path == backup.SettingsPath:
+			targetPath = filepath.Join(configPath, rocketpool.SettingsFile)
+		case strings.HasPrefix(file.Path, backup.OverrideFolder+"/"):
+			targetPath = filepath.Join(configPath, filepath.FromSlash(file.Path))
+		case strings.HasPrefix(file.Path, backup.RewardsTreesFolder+"/"):
+			targetPath = filepath.Join(dataPath, config.RewardsTreesFolder, filepath.FromSlash(strings.TrimPrefix(file.Path, backup.RewardsTreesFolder+"/")))
+		case strings.HasPrefix(file.Path, backup.WatchtowerFolder+"/"):
+			targetPath = filepath.Join(dataPath, config.WatchtowerFolder, filepath.FromSlash(strings.TrimPrefix(file.Path, backup.WatchtowerFolder+"/")))
+		default:
+			targetPath = filepath.Join(dataPath, filepath.FromSlash(file.Path))
+		}
+		err = copyRestoredFile(filepath.Join(tempFolder, filepath.FromSlash(path.Clean(file.Path))), targetPath, file.Mode)
+		if err != nil {
+			return err
+		}
+		restoredCount++
+	}
+	fmt.Printf("Restored %d file(s).\n", restoredCount)
+
+	// Save the password that was entered for the wallet check if the backup didn't include it
+	if savePassword && password != "" && !manifest.Has(backup.PasswordPath) {
+		passwordPath := filepath.Join(dataPath, backup.PasswordPath)
+		err = os.WriteFile(passwordPath, []byte(password), passwords.FileMode)
+		if err != nil {
+			return fmt.Errorf("error saving the wallet password to %s: %w", passwordPath, err)
+		}
+		fmt.Printf("Saved the wallet password to %s.\n", passwordPath)
+	}
+
+	// Import the slashing protection into the VC that the restored settings use
+	if manifest.Has(backup.SlashingProtectionPath) {
+		err = importSlashingProtection(rp, restoredCfg, slashingProtectionFile)
+		if err != nil {
+			fmt.Printf("%sWARNING: Couldn't import the slashing protection database: %s%s\n", colorRed, err.Error(), colorReset)
+			fmt.Printf("%sDo NOT start the Smart Node until you have imported %s into your validator client manually.%s\n", colorRed, backup.SlashingProtectionPath, colorReset)
+			keptPath := filepath.Join(dataPath, backup.SlashingProtectionPath)
+			if err := copyRestoredFile(slashingProtectionFile, keptPath, 0600); err == nil {
+				fmt.Printf("It has been saved to %s.\n", keptPath)
+			}
+			return nil
+		}
+		fmt.Println("Imported the slashing protection database.")
+	}
+
+	fmt.Println()
+	fmt.Printf("%sThe backup was restored.%s\n", colorGreen, colorReset)
+	fmt.Println("Next, start the Smart Node with `rocketpool service start`.")
+	if manifest.Has(backup.WalletPath) && !savePassword {
+		fmt.Println("Your settings keep the wallet password off of the disk, so unlock the wallet with `rocketpool wallet unlock` once the Smart Node has started. You will have to unlock it again every time the Smart Node restarts.")
+	}
+	fmt.Println("Then regenerate your validator keys with `rocketpool wallet rebuild`.")
+	return nil
+
+}
+
+// Check that the backup's node is registered and that it covers every minipool the node has on chain
+func checkBackupAgainstChain(rp *rocketpool.Client, manifest *backup.Manifest, interchangePubkeys map[types.ValidatorPubkey]bool) error {
+
+	response, err := rp.NodeMinipoolPubkeys(manifest.NodeAddress)
+	if err != nil {
+		return fmt.Errorf("%w\nThe Smart Node must be running to check the backup against the chain. Start it with `rocketpool service start`, or skip the check with --skip-chain-check.", err)
+	}
+	if !response.Exists {
+		return fmt.Errorf("Node %s is not registered with Rocket Pool on this network.", manifest.NodeAddress.Hex())
+	}
+
+	// Find minipools that were made after the backup, or aren't covered by its slashing protection
+	manifestPubkeys := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range manifest.MinipoolPubkeys {
+		manifestPubkeys[pubkey] = true
+	}
+	missingFromManifest := []types.ValidatorPubkey{}
+	missingFromInterchange := []types.ValidatorPubkey{}
+	for _, pubkey := range response.Pubkeys {
+		if !manifestPubkeys[pubkey] {
+			missingFromManifest = append(missingFromManifest, pubkey)
+		}
+		if interchangePubkeys != nil && !interchangePubkeys[pubkey] {
+			missingFromInterchange = append(missingFromInterchange, pubkey)
+		}
+	}
+
+	fmt.Printf("The node is registered and has %d validating minipool(s) on chain.\n", len(response.Pubkeys))
+	if len(missingFromManifest) > 0 {
+		fmt.Printf("%sNOTE: %d minipool(s) were created after the backup was made. Their keys will be regenerated by `rocketpool wallet rebuild`.%s\n", colorYellow, len(missingFromManifest), colorReset)
+	}
+	if interchangePubkeys == nil && len(response.Pubkeys) > 0 {
+		fmt.Printf("%sWARNING: The backup doesn't include any slashing protection data.%s\n", colorYellow, colorReset)
+	} else if len(missingFromInterchange) > 0 {
+		fmt.Printf("%sWARNING: The backup has no slashing protection data for %d of your minipool(s):%s\n", colorYellow, len(missingFromInterchange), colorReset)
+		for _, pubkey := range missingFromInterchange {
+			fmt.Printf("\t%s\n", pubkey.Hex())
+		}
+	}
+	fmt.Println()
+	return nil
+
+}
+
+// Check that the backup's wallet belongs to the node its manifest describes, so the chain check and slashing protection apply to it.
+// Returns the wallet's password.
+func checkBackupWallet(tempFolder string, manifest *backup.Manifest) (string, error) {
+
+	// Get the wallet password from the backup, or ask for it if it isn't included
+	var password string
+	if manifest.Has(backup.PasswordPath) {
+		passwordBytes, err := os.ReadFile(filepath.Join(tempFolder, backup.PasswordPath))
+		if err != nil {
+			return "", fmt.Errorf("error reading the backup's wallet password: %w", err)
+		}
+		password = string(passwordBytes)
+	} else {
+		password = cliutils.PromptPassword("The backup doesn't include the wallet password. Please enter it to check the wallet:", "^.+$", "")
+	}
+
+	// Decrypt the wallet and get its node address
+	pm := passwords.NewPasswordManager("", false)
+	w, err := wallet.NewWallet(filepath.Join(tempFolder, backup.WalletPath), 0, nil, nil, 0, pm)
+	if err != nil {
+		return "", fmt.Errorf("error loading the backup's wallet: %w", err)
+	}
+	err = pm.Unlock(password, w.VerifyPassword)
+	if err != nil {
+		return "", fmt.Errorf("error unlocking the backup's wallet: %w", err)
+	}
+	err = w.Reload()
+	if err != nil {
+		return "", fmt.Errorf("error decrypting the backup's wallet: %w", err)
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return "", fmt.Errorf("error getting the node address of the backup's wallet: %w", err)
+	}
+	if nodeAccount.Address != manifest.NodeAddress {
+		return "", fmt.Errorf("The backup's wallet is for node %s, but its manifest says it's for node %s. The backup may have been tampered with, so it can't be restored.", nodeAccount.Address.Hex(), manifest.NodeAddress.Hex())
+	}
+	fmt.Printf("The backup's wallet matches node %s.\n", manifest.NodeAddress.Hex())
+	return password, nil
+
+}
+
+// Import slashing protection into the VC selected in the settings, stopping it first if it's deployed
+func importSlashingProtection(rp *rocketpool.Client, cfg *config.RocketPoolConfig, sourceFile string) error {
+
+	if cfg.IsNativeMode {
+		return fmt.Errorf("it can't be imported automatically in Native mode")
+	}
+	if cfg.EnableRemoteSigner.Value == true {
+		return fmt.Errorf("your remote signer manages slashing protection")
+	}
+	client, _ := cfg.GetSelectedConsensusClient()
+	clientConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return fmt.Errorf("error getting selected consensus client config: %w", err)
+	}
+
+	// Stop the VC if it's running
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return fmt.Errorf("Error getting validator container prefix: %w", err)
+	}
+	validatorContainerName := prefix + ValidatorContainerSuffix
+	status, err := rp.GetDockerStatus(validatorContainerName)
+	if err == nil && status == "running" {
+		fmt.Printf("Stopping %s...\n", validatorContainerName)
+		if _, err := rp.StopContainer(validatorContainerName); err != nil {
+			return fmt.Errorf("Error stopping container [%s]: %w", validatorContainerName, err)
+		}
+	}
+
+	image := clientConfig.GetValidatorImage()
+	return rp.ImportSlashingProtection(cfg, client, rocketpool.GetSlashingProtectionImage(cfg, client, image), sourceFile)
+
+}
+
+// Copy a file from the extracted backup to its destination
+func copyRestoredFile(sourcePath string, targetPath string, mode os.FileMode) error {
+
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return fmt.Errorf("error creating folder for %s: %w", targetPath, err)
+	}
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("error reading %s from the backup: %w", sourcePath, err)
+	}
+	defer source.Close()
+	target, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", targetPath, err)
+	}
+	_, err = io.Copy(target, source)
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error restoring %s: %w", targetPath, err)
+	}
+	return nil
+
+}
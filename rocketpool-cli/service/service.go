@@ -16,13 +16,16 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/dustin/go-humanize"
+	"github.com/rocket-pool/rocketpool-go/types"
 	cliconfig "github.com/rocket-pool/smartnode/rocketpool-cli/service/config"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	sharedConfig "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/shirou/gopsutil/v3/disk"
 )
 
@@ -300,6 +303,17 @@ func configureService(c *cli.Context) error {
 			return startService(c, true)
 		}
 
+		// Set a new graffiti live instead of restarting the VC if it's the only thing the VC needs to pick up
+		if onlyGraffitiChanged(md.PreviousConfig, md.Config, md.ContainersToRestart) {
+			response, err := rp.SetGraffiti()
+			if err != nil {
+				fmt.Printf("Couldn't set the new graffiti through the validator client's keymanager API, so it will need to be restarted:\n%s\n\n", err.Error())
+			} else {
+				fmt.Printf("Set the graffiti for %d validator(s) to \"%s\" without restarting your validator client.\n", response.ValidatorCount, response.Graffiti)
+				return nil
+			}
+		}
+
 		// Query for service start if this is old and there are containers to change
 		if len(md.ContainersToRestart) > 0 {
 			fmt.Println("The following containers must be restarted for the changes to take effect:")
@@ -331,6 +345,36 @@ func configureService(c *cli.Context) error {
 	return err
 }
 
+// Check if the graffiti is the only setting that changed which needs a container to be restarted, and that container is the VC
+func onlyGraffitiChanged(oldCfg *config.RocketPoolConfig, newCfg *config.RocketPoolConfig, containersToRestart []cfgtypes.ContainerID) bool {
+
+	if len(containersToRestart) != 1 || containersToRestart[0] != cfgtypes.ContainerID_Validator {
+		return false
+	}
+
+	// Collect the parameters of both configs in the same order
+	oldParams := oldCfg.GetParameters()
+	newParams := newCfg.GetParameters()
+	oldSubconfigs := oldCfg.GetSubconfigs()
+	for name, subconfig := range newCfg.GetSubconfigs() {
+		oldParams = append(oldParams, oldSubconfigs[name].GetParameters()...)
+		newParams = append(newParams, subconfig.GetParameters()...)
+	}
+
+	graffitiChanged := false
+	for i, param := range newParams {
+		if fmt.Sprint(oldParams[i].Value) == fmt.Sprint(param.Value) || len(param.AffectsContainers) == 0 {
+			continue
+		}
+		if param.ID != config.GraffitiID {
+			return false
+		}
+		graffitiChanged = true
+	}
+	return graffitiChanged
+
+}
+
 // Updates a configuration from the provided CLI arguments headlessly
 func configureHeadless(c *cli.Context, cfg *config.RocketPoolConfig) error {
 
@@ -541,9 +585,9 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return nil
 	}
 
+	// Do the client swap check; slashing protection is always moved to the new client, the flag only skips the fallback delay
+	err = checkForValidatorChange(rp, cfg, c.Bool("ignore-slash-timer"))
 	if !c.Bool("ignore-slash-timer") {
-		// Do the client swap check
-		err := checkForValidatorChange(rp, cfg)
 		if err != nil {
 			fmt.Printf("%sWARNING: couldn't verify that the validator container can be safely restarted:\n\t%s\n", colorYellow, err.Error())
 			fmt.Println("If you are changing to a different ETH2 client, it may resubmit an attestation you have already submitted.")
@@ -723,6 +767,7 @@ func handleNimbusSplitConversion(rp *rocketpool.Client, cfg *config.RocketPoolCo
 }
 
 // Versions prior to v1.3.1 didn't preserve Teku's slashing DB, so force a delay when upgrading to ensure the user doesn't get slashed by accident
+// The database that was lost lived inside the old container rather than the validator folder, so there is nothing to export; waiting is the only protection
 func handleTekuSlashProtectionMigrationDelay(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
 
 	fmt.Printf("%s=== NOTICE ===\n", colorYellow)
@@ -795,7 +840,9 @@ func handleTekuSlashProtectionMigrationDelay(rp *rocketpool.Client, cfg *config.
 	return nil
 }
 
-func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+// Check if the validator client is changing; if it is, move its slashing protection database to the new client.
+// If that can't be done completely, wait until it has been offline long enough that the new client can't double-sign.
+func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig, ignoreSlashTimer bool) error {
 
 	// Get the container prefix
 	prefix, err := rp.GetContainerPrefix()
@@ -868,6 +915,18 @@ func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig
 			}
 		}
 
+		// Move the slashing protection database to the new client
+		fmt.Printf("Moving the slashing protection database from %s to %s...\n", currentValidatorName, pendingValidatorName)
+		err = migrateSlashingProtection(rp, cfg, currentValidatorImageString, selectedConsensusClientConfig.GetValidatorImage())
+		if err == nil {
+			fmt.Printf("%sThe slashing protection database was moved to %s and covers all of your validator keys - no slashing prevention delay necessary.%s\n", colorGreen, pendingValidatorName, colorReset)
+			return nil
+		}
+		fmt.Printf("%sCouldn't move the slashing protection database to the new client: %s%s\n\n", colorYellow, err.Error(), colorReset)
+		if ignoreSlashTimer {
+			return nil
+		}
+
 		// Print the warning and start the time lockout
 		safeStartTime := validatorFinishTime.Add(15 * time.Minute)
 		remainingTime := time.Until(safeStartTime)
@@ -898,6 +957,72 @@ func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig
 	return nil
 }
 
+// Export the slashing protection database from the old VC and import it into the new one, checking that it covers every validator key the node has
+func migrateSlashingProtection(rp *rocketpool.Client, cfg *config.RocketPoolConfig, currentImage string, pendingImage string) error {
+
+	currentClient, err := rocketpool.GetConsensusClientFromImage(currentImage)
+	if err != nil {
+		return err
+	}
+	pendingClient, err := rocketpool.GetConsensusClientFromImage(pendingImage)
+	if err != nil {
+		return err
+	}
+
+	// Export it from the old client
+	tempFolder, err := os.MkdirTemp("", "rocketpool-slashing-protection-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary folder: %w", err)
+	}
+	defer os.RemoveAll(tempFolder)
+	interchangeFile := filepath.Join(tempFolder, rocketpool.SlashingProtectionFilename)
+	err = rp.ExportSlashingProtection(cfg, currentClient, rocketpool.GetSlashingProtectionImage(cfg, currentClient, currentImage), interchangeFile)
+	if err != nil {
+		return fmt.Errorf("error exporting from %s: %w", currentClient, err)
+	}
+	interchange, err := rocketpool.LoadSlashingProtectionInterchange(interchangeFile)
+	if err != nil {
+		return err
+	}
+
+	// Make sure it covers every validator key on this machine. The node daemon is stopped during a client change,
+	// so the keys come from the Lighthouse keystore, which the Smartnode saves every key to regardless of the client.
+	pubkeys, err := getLocalValidatorPubkeys(cfg)
+	if err != nil {
+		return fmt.Errorf("error getting validator keys to check the export: %w", err)
+	}
+	exported := interchange.Pubkeys()
+	missing := 0
+	for _, pubkey := range pubkeys {
+		if !exported[pubkey] {
+			missing++
+		}
+	}
+	if missing > 0 {
+		return fmt.Errorf("%s had no slashing protection data for %d of your %d validator key(s)", currentClient, missing, len(pubkeys))
+	}
+
+	// Import it into the new client
+	err = rp.ImportSlashingProtection(cfg, pendingClient, rocketpool.GetSlashingProtectionImage(cfg, pendingClient, pendingImage), interchangeFile)
+	if err != nil {
+		return fmt.Errorf("error importing into %s: %w", pendingClient, err)
+	}
+	return nil
+
+}
+
+// Get the pubkeys of the validator keys on this machine, or the ones in the remote signer if it's enabled
+func getLocalValidatorPubkeys(cfg *config.RocketPoolConfig) ([]types.ValidatorPubkey, error) {
+	validatorsPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPathInCLI()))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding validator folder path: %w", err)
+	}
+	if cfg.EnableRemoteSigner.Value.(bool) {
+		return lhkeystore.NewRemoteKeystore(validatorsPath, cfg.RemoteSigner.Url.Value.(string)).GetValidatorPubkeys()
+	}
+	return lhkeystore.NewKeystore(validatorsPath, nil).GetValidatorPubkeys()
+}
+
 // Get the name of the container responsible for validator duties based on the client name
 func getContainerNameForValidatorDuties(CurrentValidatorClientName string, rp *rocketpool.Client) (string, error) {
 
@@ -1314,6 +1439,12 @@ func serviceVersion(c *cli.Context) error {
 		return err
 	}
 
+	// Values computed for machine-readable output
+	output.Set("network", cfg.GetNetwork())
+	output.Set("clientVersion", c.App.Version)
+	output.Set("serviceVersion", serviceVersion)
+	output.Set("nativeMode", cfg.IsNativeMode)
+
 	// Handle native mode
 	if cfg.IsNativeMode {
 		fmt.Printf("Rocket Pool client version: %s\n", c.App.Version)
@@ -1404,6 +1535,10 @@ func serviceVersion(c *cli.Context) error {
 		mevBoostString = "Disabled"
 	}
 
+	output.Set("executionClient", strings.ReplaceAll(eth1ClientString, "\n\t", "; "))
+	output.Set("consensusClient", strings.ReplaceAll(eth2ClientString, "\n\t", "; "))
+	output.Set("mevBoost", strings.ReplaceAll(mevBoostString, "\n\t", "; "))
+
 	// Print version info
 	fmt.Printf("Rocket Pool client version: %s\n", c.App.Version)
 	fmt.Printf("Rocket Pool service version: %s\n", serviceVersion)
@@ -34,6 +34,30 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "unlock",
+				Aliases:   []string{"u"},
+				Usage:     "Unlock the node wallet for the running Smartnode processes, if its password isn't saved to disk",
+				UsageText: "rocketpool wallet unlock [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The node wallet password",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return unlockWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "init",
 				Aliases:   []string{"i"},
@@ -196,6 +220,55 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "sign-tx",
+				Aliases:   []string{"st"},
+				Usage:     "Sign a transaction that was exported with --export-unsigned. This doesn't need the Smartnode service or a network connection, so it can be run on an offline machine.",
+				UsageText: "rocketpool wallet sign-tx [options] unsigned-tx-file-or-payload",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mnemonic, m",
+						Usage: "The mnemonic phrase of the wallet to sign with",
+					},
+					cli.StringFlag{
+						Name:  "derivation-path, d",
+						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
+					},
+					cli.UintFlag{
+						Name:  "wallet-index, i",
+						Usage: "Specify the index to use with the derivation path",
+						Value: 0,
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The `file` to save the signed transaction to",
+						Value: "signed-tx.json",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm signing the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("mnemonic") != "" {
+						if _, err := cliutils.ValidateWalletMnemonic("mnemonic", c.String("mnemonic")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return signTransaction(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
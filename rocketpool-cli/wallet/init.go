@@ -25,6 +25,10 @@ func initWallet(c *cli.Context) error {
 		fmt.Println("The node wallet is already initialized.")
 		return nil
 	}
+	if status.WalletLocked {
+		fmt.Println("The node wallet already exists, but it's locked. Run `rocketpool wallet unlock` to unlock it.")
+		return nil
+	}
 
 	// Prompt for user confirmation before printing sensitive information
 	if !(c.GlobalBool("secure-session") ||
@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/urfave/cli"
 
@@ -20,9 +21,25 @@ func purge(c *cli.Context) error {
 		return nil
 	}
 
+	// Delete the keys from the VC first so it hands over their slashing protection data
+	deleteResponse, err := rp.DeleteValidatorKeys()
+	if err != nil {
+		fmt.Printf("%sNOTE: Couldn't delete the keys from your validator client through its keymanager API, so they'll be removed when it restarts instead:\n%s%s\n\n", colorYellow, err.Error(), colorReset)
+	} else {
+		fmt.Printf("Deleted %d validator key(s) from your validator client.\n", len(deleteResponse.ValidatorKeys))
+		if deleteResponse.SlashingProtectionFile != "" {
+			cfg, _, err := rp.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("error loading user settings: %w", err)
+			}
+			fmt.Printf("Their slashing protection data was saved to %s; import it into any validator client you use them with again.\n", filepath.Join(cfg.Smartnode.GetSlashingProtectionFolder(false), deleteResponse.SlashingProtectionFile))
+		}
+		fmt.Println()
+	}
+
 	// Purge
 	composeFiles := c.Parent().StringSlice("compose-file")
-	err := rp.PurgeAllKeys(composeFiles)
+	err = rp.PurgeAllKeys(composeFiles)
 	if err != nil {
 		return fmt.Errorf("%w\n%sTHERE WAS AN ERROR DELETING YOUR KEYS. They most likely have not been deleted. Proceed with caution.%s", err, colorRed, colorReset)
 	}
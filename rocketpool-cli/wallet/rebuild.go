@@ -70,6 +70,11 @@ func rebuildWallet(c *cli.Context) error {
 		for _, key := range response.ValidatorKeys {
 			fmt.Println(key.Hex())
 		}
+		if response.ValidatorsLoaded {
+			fmt.Println("\nThe keys have been loaded into your Validator Client without restarting it.")
+		} else {
+			fmt.Println("\nPlease restart your Validator Client so it loads these keys.")
+		}
 	} else {
 		fmt.Println("No validator keys were found.")
 	}
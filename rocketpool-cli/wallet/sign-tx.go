@@ -0,0 +1,115 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Sign a transaction exported with --export-unsigned.
+// This runs entirely on the local machine so it can be used on an air-gapped host without the Smartnode service.
+func signTransaction(c *cli.Context, source string) error {
+
+	// Load the unsigned transaction
+	tx, from, err := rocketpool.LoadUnsignedTransaction(source)
+	if err != nil {
+		return err
+	}
+
+	// Print the transaction
+	fmt.Println("Unsigned transaction:")
+	fmt.Printf("\tChain ID: %s\n", tx.ChainId().String())
+	if from != nil {
+		fmt.Printf("\tFrom:     %s\n", from.Hex())
+	}
+	if tx.To() != nil {
+		fmt.Printf("\tTo:       %s\n", tx.To().Hex())
+	} else {
+		fmt.Println("\tTo:       (contract creation)")
+	}
+	fmt.Printf("\tValue:    %.6f ETH\n", eth.WeiToEth(tx.Value()))
+	fmt.Printf("\tNonce:    %d\n", tx.Nonce())
+	fmt.Printf("\tGas limit: %d\n", tx.Gas())
+	fmt.Printf("\tMax fee:  %.2f gwei (max priority fee %.2f gwei)\n", eth.WeiToGwei(tx.GasFeeCap()), eth.WeiToGwei(tx.GasTipCap()))
+	fmt.Printf("\tData:     %s\n", hexutil.Encode(tx.Data()))
+	fmt.Println()
+
+	// Prompt for mnemonic
+	var mnemonic string
+	if c.String("mnemonic") != "" {
+		mnemonic = c.String("mnemonic")
+	} else {
+		mnemonic = PromptMnemonic()
+	}
+	mnemonic = strings.TrimSpace(mnemonic)
+
+	// Get the derivation path
+	path := c.String("derivation-path")
+	switch path {
+	case "":
+		path = wallet.DefaultNodeKeyPath
+	case "ledgerLive":
+		path = wallet.LedgerLiveNodeKeyPath
+	case "mew":
+		path = wallet.MyEtherWalletNodeKeyPath
+	}
+
+	// Derive the key in memory without saving a wallet
+	w, err := wallet.NewWallet("", uint(tx.ChainId().Uint64()), nil, nil, 0, nil)
+	if err != nil {
+		return err
+	}
+	if err := w.TestRecovery(path, c.Uint("wallet-index"), mnemonic); err != nil {
+		return err
+	}
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	if from != nil && account.Address != *from {
+		return fmt.Errorf("the transaction must be sent from %s, but the mnemonic, derivation path and index you provided belong to %s", from.Hex(), account.Address.Hex())
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to sign this transaction with %s?", account.Address.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign the transaction
+	payload, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error serializing unsigned transaction: %w", err)
+	}
+	signedPayload, err := w.Sign(payload)
+	if err != nil {
+		return err
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(signedPayload); err != nil {
+		return fmt.Errorf("error decoding signed transaction: %w", err)
+	}
+
+	// Save it
+	outputPath := c.String("output")
+	if err := rocketpool.SaveSignedTransaction(outputPath, signedTx, account.Address); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Saved the signed transaction %s to %s.\n", signedTx.Hash().Hex(), outputPath)
+	fmt.Println("Move it to your node and submit it with `rocketpool node broadcast-tx`.")
+	fmt.Println()
+	fmt.Println("To move the transaction with a QR code instead, encode this payload (e.g. with `qrencode -t ansiutf8`):")
+	fmt.Println(hexutil.Encode(signedPayload))
+	return nil
+
+}
@@ -37,6 +37,8 @@ func getStatus(c *cli.Context) error {
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+	} else if status.WalletLocked {
+		fmt.Println("The node wallet is locked. Run `rocketpool wallet unlock` to unlock it.")
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func unlockWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return errors.New("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.Smartnode.SaveWalletPassword.Value.(bool) {
+		fmt.Println("Your node wallet password is saved to disk, so the wallet doesn't need to be unlocked.")
+		fmt.Println("If you want to keep it off of the disk, disable the `Save Wallet Password` option in `rocketpool service config`.")
+		return nil
+	}
+
+	// Make sure the API server is running so its wallet gets unlocked too
+	err = rp.StartAPIServer()
+	if err != nil {
+		fmt.Printf("%sWARNING: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	}
+
+	// Warn about a password that's still on disk from before
+	passwordPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetPasswordPathInCLI()))
+	if err == nil {
+		if _, err := os.Stat(passwordPath); err == nil {
+			fmt.Printf("%sNOTE: your old node wallet password file is still saved at %s. The Smartnode no longer uses it; delete it if you don't want your password on the disk.%s\n\n", colorYellow, passwordPath, colorReset)
+		}
+	}
+
+	// Get the password
+	password := c.String("password")
+	if password == "" {
+		password = cliutils.PromptPassword("Please enter your node wallet password:", "^.*$", "")
+	}
+
+	// Unlock each process that's waiting for it
+	unlocked := 0
+	var unlockErr error
+	for _, process := range config.WalletUnlockProcesses {
+		served, err := rp.UnlockWallet(process, password)
+		if err != nil {
+			fmt.Printf("%s%s%s\n", colorRed, err.Error(), colorReset)
+			unlockErr = err
+			continue
+		}
+		if served {
+			fmt.Printf("Unlocked the node wallet for the %s process.\n", process)
+			unlocked++
+		}
+	}
+	if unlockErr != nil {
+		return errors.New("The node wallet could not be unlocked for every process.")
+	}
+	if unlocked == 0 {
+		fmt.Println("There aren't any Smartnode processes running that need to be unlocked. Start the Smartnode with `rocketpool service start` and try again.")
+	}
+	return nil
+
+}
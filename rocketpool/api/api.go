@@ -64,6 +64,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		return err
 	}
 
+	// Refresh the per-call settings of the cached services, since the API server runs many calls in one process
+	command.Before = func(c *cli.Context) error {
+		return services.ApplyCallFlags(c)
+	}
+
 	// Register subcommands
 	auction.RegisterSubcommands(&command, "auction", []string{"a"})
 	minipool.RegisterSubcommands(&command, "minipool", []string{"m"})
@@ -1,6 +1,8 @@
 package minipool
 
 import (
+	"strconv"
+
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -253,6 +255,57 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-signed-exits",
+				Usage:     "Get signed voluntary exits for the given minipools without broadcasting them",
+				UsageText: "rocketpool api minipool get-signed-exits minipool-addresses",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddresses, err := cliutils.ValidateAddresses("minipool addresses", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSignedExits(c, minipoolAddresses))
+					return nil
+
+				},
+			},
+			{
+				Name:      "broadcast-exit",
+				Usage:     "Broadcast a signed voluntary exit to the Beacon Chain",
+				UsageText: "rocketpool api minipool broadcast-exit validator-index epoch signature",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					validatorIndex, err := cliutils.ValidateUint("validator index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					signature, err := cliutils.ValidateValidatorSignature("signature", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastExit(c, strconv.FormatUint(validatorIndex, 10), epoch, signature))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "get-minipool-close-details-for-node",
 				Usage:     "Check all of the node's minipools for closure eligibility, and return the details of the closeable ones",
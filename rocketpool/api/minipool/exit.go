@@ -1,7 +1,11 @@
 package minipool
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
@@ -67,10 +71,6 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	if err := services.RequireBeaconClientSynced(c); err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return nil, err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
@@ -89,8 +89,8 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 		return nil, err
 	}
 
-	// Get validator private key
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	// Get the signer for the validator's key
+	signer, err := services.GetValidatorSigner(c, validatorPubkey)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +114,7 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	}
 
 	// Get signed voluntary exit message
-	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	signature, err := validator.SignExitMessage(signer, validatorIndex, head.Epoch, signatureDomain)
 	if err != nil {
 		return nil, err
 	}
@@ -128,3 +128,120 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	return &response, nil
 
 }
+
+func getSignedExits(c *cli.Context, minipoolAddresses []common.Address) (*api.GetSignedExitsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetSignedExitsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get beacon head
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get voluntary exit signature domain; since EIP-7044 this is pinned to the Capella fork so the exits never expire
+	signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], head.Epoch, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign an exit for each minipool
+	response.Exits = make([]api.SignedMinipoolExit, len(minipoolAddresses))
+	for i, minipoolAddress := range minipoolAddresses {
+
+		// Create minipool and validate its owner
+		mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+			return nil, err
+		}
+
+		// Get minipool validator pubkey and index
+		validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+		if err != nil {
+			return nil, err
+		}
+		validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("error getting validator index for minipool %s: %w", minipoolAddress.Hex(), err)
+		}
+
+		// Sign the exit
+		signer, err := services.GetValidatorSigner(c, validatorPubkey)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := validator.SignExitMessage(signer, validatorIndex, head.Epoch, signatureDomain)
+		if err != nil {
+			return nil, fmt.Errorf("error signing exit for minipool %s: %w", minipoolAddress.Hex(), err)
+		}
+
+		signedExit := api.SignedMinipoolExit{
+			Minipool: minipoolAddress,
+			Pubkey:   validatorPubkey,
+			Domain:   hexutil.Encode(signatureDomain),
+		}
+		signedExit.Exit.Message.Epoch = strconv.FormatUint(head.Epoch, 10)
+		signedExit.Exit.Message.ValidatorIndex = validatorIndex
+		signedExit.Exit.Signature = hexutil.Encode(signature.Bytes())
+		response.Exits[i] = signedExit
+
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func broadcastExit(c *cli.Context, validatorIndex string, epoch uint64, signature types.ValidatorSignature) (*api.BroadcastExitResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastExitResponse{}
+
+	// Broadcast voluntary exit message
+	if err := bc.ExitValidator(validatorIndex, epoch, signature); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
@@ -32,6 +33,18 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 	if err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.ImportKeyResponse{}
@@ -88,6 +101,15 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 		return nil, fmt.Errorf("error saving keystore: %w", err)
 	}
 
+	// Load it into the VC if it has a keymanager API; otherwise the CLI will restart it
+	if km != nil {
+		feeRecipientInfo, err := rputils.GetFeeRecipientInfoWithoutState(rp, bc, nodeAccount.Address, nil)
+		if err == nil {
+			err = validator.ImportValidatorKeys(cfg, km, []types.ValidatorPubkey{pubkey}, w.LoadValidatorKey, feeRecipientInfo.GetCorrectFeeRecipient())
+			response.ValidatorLoaded = (err == nil)
+		}
+	}
+
 	// Return response
 	return &response, nil
 }
@@ -131,7 +131,99 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(generateRewardsTree(c, index))
+					api.PrintResponse(generateRewardsTree(c, index, false))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "record-rewards-fixture",
+				Usage:     "Set a request marker for the watchtower to generate the rewards tree for the given interval, recording every client response it uses into a fixture",
+				UsageText: "rocketpool api network record-rewards-fixture index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(generateRewardsTree(c, index, true))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-verify-rewards-tree",
+				Usage:     "Check if the rewards tree for the provided interval can be verified",
+				UsageText: "rocketpool api network can-verify-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canVerifyRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify-rewards-tree",
+				Usage:     "Set a request marker for the watchtower to regenerate and verify the rewards tree for the given interval",
+				UsageText: "rocketpool api network verify-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rewards-tree-verification",
+				Usage:     "Get the verification report for the rewards tree of the given interval, if it exists",
+				UsageText: "rocketpool api network rewards-tree-verification index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsTreeVerification(c, index))
 					return nil
 
 				},
@@ -51,7 +51,7 @@ func canGenerateRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkGenera
 
 }
 
-func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+func generateRewardsTree(c *cli.Context, index uint64, recordFixture bool) (*api.NetworkGenerateRewardsTreeResponse, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -64,6 +64,9 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 
 	// Create the generation request
 	requestPath := cfg.Smartnode.GetRegenerateRewardsTreeRequestPath(index, true)
+	if recordFixture {
+		requestPath = cfg.Smartnode.GetRecordRewardsFixtureRequestPath(index, true)
+	}
 	requestFile, err := os.Create(requestPath)
 	if requestFile != nil {
 		requestFile.Close()
@@ -0,0 +1,106 @@
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func canVerifyRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkVerifyRewardsTreeResponse, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNetworkVerifyRewardsTreeResponse{}
+
+	// Get the current interval
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentIndex = currentIndexBig.Uint64()
+
+	// Check for the canonical tree and an existing report
+	_, err = os.Stat(cfg.Smartnode.GetRewardsTreePath(index, true))
+	response.TreeFileExists = !os.IsNotExist(err)
+	_, err = os.Stat(cfg.Smartnode.GetRewardsTreeVerificationPath(index, true))
+	response.ReportExists = !os.IsNotExist(err)
+
+	return &response, nil
+
+}
+
+func verifyRewardsTree(c *cli.Context, index uint64) (*api.NetworkVerifyRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkVerifyRewardsTreeResponse{}
+
+	// Remove the previous report so it isn't mistaken for the result of this request
+	reportPath := cfg.Smartnode.GetRewardsTreeVerificationPath(index, true)
+	err = os.Remove(reportPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Error removing previous verification report: %w", err)
+	}
+
+	// Create the verification request
+	requestPath := cfg.Smartnode.GetVerifyRewardsTreeRequestPath(index, true)
+	requestFile, err := os.Create(requestPath)
+	if requestFile != nil {
+		requestFile.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error creating request marker: %w", err)
+	}
+
+	return &response, nil
+
+}
+
+func getRewardsTreeVerification(c *cli.Context, index uint64) (*api.NetworkRewardsTreeVerificationResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkRewardsTreeVerificationResponse{}
+
+	// Load the report if it exists
+	reportPath := cfg.Smartnode.GetRewardsTreeVerificationPath(index, true)
+	_, err = os.Stat(reportPath)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	report, err := rprewards.ReadRewardsTreeVerificationReport(reportPath)
+	if err != nil {
+		return nil, err
+	}
+	response.ReportExists = true
+	response.ReportPath = cfg.Smartnode.GetRewardsTreeVerificationPath(index, false)
+	response.Report = report
+
+	return &response, nil
+
+}
@@ -0,0 +1,46 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func broadcastTransaction(c *cli.Context, payload []byte) (*api.BroadcastTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastTransactionResponse{}
+
+	// Decode the signed transaction
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(payload); err != nil {
+		return nil, fmt.Errorf("error decoding signed transaction: %w", err)
+	}
+	if _, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err != nil {
+		return nil, fmt.Errorf("the transaction does not have a valid signature: %w", err)
+	}
+
+	// Broadcast it
+	if err := ec.SendTransaction(context.Background(), tx); err != nil {
+		return nil, err
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
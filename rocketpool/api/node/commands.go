@@ -1,6 +1,8 @@
 package node
 
 import (
+	"time"
+
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -1519,6 +1521,190 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "pending-txs",
+				Usage:     "Get the node's transactions that haven't been included in a block yet",
+				UsageText: "rocketpool api node pending-txs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPendingTransactions(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel-tx",
+				Usage:     "Cancel a pending transaction by replacing it with an empty transfer",
+				UsageText: "rocketpool api node cancel-tx nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(cancelTransaction(c, nonce))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "broadcast-tx",
+				Usage:     "Broadcast a transaction that was signed offline",
+				UsageText: "rocketpool api node broadcast-tx payload",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					payload, err := cliutils.ValidateByteArray("payload", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastTransaction(c, payload))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "export-income",
+				Usage:     "Get every ETH and RPL inflow to the node between two times, given as Unix timestamps (0 for no limit)",
+				UsageText: "rocketpool api node export-income from to",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					fromUnix, err := cliutils.ValidateUint("from", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					toUnix, err := cliutils.ValidateUint("to", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					var from, to time.Time
+					if fromUnix > 0 {
+						from = time.Unix(int64(fromUnix), 0)
+					}
+					if toUnix > 0 {
+						to = time.Unix(int64(toUnix), 0)
+					}
+
+					// Run
+					api.PrintResponse(exportIncome(c, from, to))
+					return nil
+
+				},
+			},
+			{
+				Name:      "history",
+				Usage:     "Get the node's recorded history between two times, given as Unix timestamps (0 for no limit), with one sample per step in seconds (0 for every sample)",
+				UsageText: "rocketpool api node history from to step",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					fromUnix, err := cliutils.ValidateUint("from", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					toUnix, err := cliutils.ValidateUint("to", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					stepSeconds, err := cliutils.ValidateUint("step", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+					var from, to time.Time
+					if fromUnix > 0 {
+						from = time.Unix(int64(fromUnix), 0)
+					}
+					if toUnix > 0 {
+						to = time.Unix(int64(toUnix), 0)
+					}
+
+					// Run
+					api.PrintResponse(getHistory(c, from, to, time.Duration(stepSeconds)*time.Second))
+					return nil
+
+				},
+			},
+			{
+				Name:      "dashboard",
+				Usage:     "Get the port and token of the node daemon's web dashboard",
+				UsageText: "rocketpool api node dashboard",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDashboard(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "reset-dashboard-token",
+				Usage:     "Replace the node daemon's web dashboard token with a new one",
+				UsageText: "rocketpool api node reset-dashboard-token",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(resetDashboardToken(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "minipool-pubkeys",
+				Usage:     "Get the validator pubkeys of a node's minipools",
+				UsageText: "rocketpool api node minipool-pubkeys address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nodeAddress, err := cliutils.ValidateAddress("node address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolPubkeys(c, nodeAddress))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/dashboard"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func getDashboard(c *cli.Context) (*api.NodeDashboardResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeDashboardResponse{}
+	response.Enabled = (cfg.Smartnode.EnableDashboard.Value == true)
+	response.Port = cfg.Smartnode.DashboardPort.Value.(uint16)
+	response.PortMode = cfg.Smartnode.OpenDashboardPort.Value.(cfgtypes.RPCMode)
+	if !response.Enabled {
+		return &response, nil
+	}
+
+	// Get the token, creating it if the daemon hasn't yet
+	tokenPath := cfg.Smartnode.GetDashboardTokenPath()
+	err = dashboard.CreateTokenFile(tokenPath)
+	if err != nil {
+		return nil, err
+	}
+	response.Token, err = dashboard.LoadToken(tokenPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func resetDashboardToken(c *cli.Context) (*api.ResetDashboardTokenResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ResetDashboardTokenResponse{}
+
+	// Replace the token; the daemon checks the file on every request, so old links stop working right away
+	response.Token, err = dashboard.ResetTokenFile(cfg.Smartnode.GetDashboardTokenPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
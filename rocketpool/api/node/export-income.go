@@ -0,0 +1,515 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// How far before the start of an export to look for the RPL price that was in effect at the time, in blocks (about two days)
+const incomePriceLookbackBlocks uint64 = 14400
+
+// The signature of the PricesUpdated event before Houston, which didn't include the slot timestamp
+const legacyPricesUpdatedSignature string = "PricesUpdated(uint256,uint256,uint256)"
+
+// An RPL price that took effect at a block
+type rplPriceUpdate struct {
+	block uint64
+	price *big.Int
+}
+
+func exportIncome(c *cli.Context, from time.Time, to time.Time) (*api.ExportIncomeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportIncomeResponse{
+		Events:   []api.NodeIncomeEvent{},
+		Warnings: []string{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// Get the block range
+	response.FromBlock, response.ToBlock, err = getIncomeBlockRange(rp, from, to)
+	if err != nil {
+		return nil, err
+	}
+	fromBlock := new(big.Int).SetUint64(response.FromBlock)
+	toBlock := new(big.Int).SetUint64(response.ToBlock)
+
+	// Get the rewards claims
+	events, warnings, err := getRewardsClaimIncome(rp, cfg, nodeAccount.Address, intervalSize, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	response.Events = append(response.Events, events...)
+	response.Warnings = append(response.Warnings, warnings...)
+
+	// Get the minipool distributions
+	events, warnings, err = getMinipoolDistributionIncome(rp, nodeAccount.Address, intervalSize, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	response.Events = append(response.Events, events...)
+	response.Warnings = append(response.Warnings, warnings...)
+
+	// Get the fee distributor payouts
+	events, warnings, err = getFeeDistributorIncome(rp, nodeAccount.Address, intervalSize, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	response.Events = append(response.Events, events...)
+	response.Warnings = append(response.Warnings, warnings...)
+
+	// Add the timestamps and the RPL price at the time of each event
+	warnings, err = addIncomeTimestampsAndPrices(rp, intervalSize, response.FromBlock, response.ToBlock, response.Events)
+	if err != nil {
+		return nil, err
+	}
+	response.Warnings = append(response.Warnings, warnings...)
+
+	// Sort chronologically
+	sort.SliceStable(response.Events, func(i, j int) bool {
+		return response.Events[i].Block < response.Events[j].Block
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the blocks that cover the time range of an export; zero times mean the protocol deployment and now
+func getIncomeBlockRange(rp *rocketpool.RocketPool, from time.Time, to time.Time) (uint64, uint64, error) {
+
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting latest block: %w", err)
+	}
+
+	var fromBlock uint64
+	if from.IsZero() {
+		deployBlock, err := storage.GetDeployBlock(rp)
+		if err != nil {
+			return 0, 0, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+		}
+		fromBlock = deployBlock.Uint64()
+	} else {
+		header, err := rprewards.GetELBlockHeaderForTime(from, rp)
+		if err != nil {
+			return 0, 0, err
+		}
+		// The header is the last block before the start time
+		fromBlock = header.Number.Uint64() + 1
+	}
+
+	toBlock := latestBlock
+	if !to.IsZero() && to.Before(time.Now()) {
+		header, err := rprewards.GetELBlockHeaderForTime(to, rp)
+		if err != nil {
+			return 0, 0, err
+		}
+		toBlock = header.Number.Uint64()
+	}
+
+	if fromBlock > toBlock {
+		return 0, 0, fmt.Errorf("the start of the export (block %d) is after its end (block %d)", fromBlock, toBlock)
+	}
+	return fromBlock, toBlock, nil
+
+}
+
+// Get the RPL and smoothing pool ETH the node claimed from the rewards intervals
+func getRewardsClaimIncome(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, intervalSize *big.Int, fromBlock *big.Int, toBlock *big.Int) ([]api.NodeIncomeEvent, []string, error) {
+
+	events := []api.NodeIncomeEvent{}
+	warnings := []string{}
+
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	claimEvent, exists := distributor.ABI.Events["RewardsClaimed"]
+	if !exists {
+		warnings = append(warnings, "The rewards distributor doesn't have a RewardsClaimed event, so rewards claims couldn't be exported.")
+		return events, warnings, nil
+	}
+
+	// Get the node's claims across every version of the distributor
+	logs, err := eth.FilterContractLogs(rp, "rocketMerkleDistributorMainnet", eth.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Topics:    [][]common.Hash{{claimEvent.ID}, {common.BytesToHash(nodeAddress.Bytes())}},
+	}, intervalSize, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting rewards claims: %w", err)
+	}
+
+	for _, log := range logs {
+		values := map[string]interface{}{}
+		if err := claimEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Couldn't decode the rewards claim in transaction %s: %s", log.TxHash.Hex(), err.Error()))
+			continue
+		}
+		intervals, _ := getIncomeEventArray(values, "rewardIndex")
+		claimedRpl, _ := getIncomeEventArray(values, "amountRPL")
+		claimedEth, _ := getIncomeEventArray(values, "amountETH")
+
+		for i, intervalBig := range intervals {
+			interval := intervalBig.Uint64()
+
+			// The rewards file is the canonical breakdown of each interval; fall back to the claim itself if it isn't available
+			var rplAmount, ethAmount *big.Int
+			info, err := rprewards.GetIntervalInfo(rp, cfg, nodeAddress, interval, nil)
+			if err == nil && info.TreeFileExists && info.MerkleRootValid && info.NodeExists {
+				rplAmount = new(big.Int).Add(&info.CollateralRplAmount.Int, &info.ODaoRplAmount.Int)
+				ethAmount = new(big.Int).Set(&info.SmoothingPoolEthAmount.Int)
+			} else {
+				if i >= len(claimedRpl) || i >= len(claimedEth) {
+					warnings = append(warnings, fmt.Sprintf("The rewards file for interval %d is unavailable and the claim in transaction %s doesn't list its amounts, so it was skipped.", interval, log.TxHash.Hex()))
+					continue
+				}
+				warnings = append(warnings, fmt.Sprintf("The rewards file for interval %d is unavailable, so its amounts come from the claim transaction instead.", interval))
+				rplAmount = claimedRpl[i]
+				ethAmount = claimedEth[i]
+			}
+
+			intervalCopy := interval
+			if rplAmount.Sign() > 0 {
+				events = append(events, api.NodeIncomeEvent{
+					Type:     api.IncomeType_RewardsRpl,
+					Source:   log.Address,
+					Interval: &intervalCopy,
+					Block:    log.BlockNumber,
+					TxHash:   log.TxHash,
+					Token:    "RPL",
+					Amount:   rplAmount,
+				})
+			}
+			if ethAmount.Sign() > 0 {
+				events = append(events, api.NodeIncomeEvent{
+					Type:     api.IncomeType_SmoothingPoolEth,
+					Source:   log.Address,
+					Interval: &intervalCopy,
+					Block:    log.BlockNumber,
+					TxHash:   log.TxHash,
+					Token:    "ETH",
+					Amount:   ethAmount,
+				})
+			}
+		}
+	}
+
+	return events, warnings, nil
+
+}
+
+// Get the ETH the node received when its minipools' balances were distributed
+func getMinipoolDistributionIncome(rp *rocketpool.RocketPool, nodeAddress common.Address, intervalSize *big.Int, fromBlock *big.Int, toBlock *big.Int) ([]api.NodeIncomeEvent, []string, error) {
+
+	events := []api.NodeIncomeEvent{}
+	warnings := []string{}
+
+	delegate, err := rp.GetContract("rocketMinipoolDelegate", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	distributionEvent, exists := delegate.ABI.Events["EtherWithdrawalProcessed"]
+	if !exists {
+		warnings = append(warnings, "The minipool delegate doesn't have an EtherWithdrawalProcessed event, so minipool distributions couldn't be exported.")
+		return events, warnings, nil
+	}
+
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting node minipool addresses: %w", err)
+	}
+	if len(addresses) == 0 {
+		return events, warnings, nil
+	}
+
+	logs, err := eth.GetLogs(rp, addresses, [][]common.Hash{{distributionEvent.ID}}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting minipool distributions: %w", err)
+	}
+
+	fullWithdrawalThreshold := eth.EthToWei(8)
+	for _, log := range logs {
+		values := map[string]interface{}{}
+		if err := distributionEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Couldn't decode the distribution of minipool %s in transaction %s: %s", log.Address.Hex(), log.TxHash.Hex(), err.Error()))
+			continue
+		}
+		nodeAmount, exists := getIncomeEventValue(values, "nodeAmount")
+		if !exists || nodeAmount.Sign() == 0 {
+			continue
+		}
+		event := api.NodeIncomeEvent{
+			Type:   api.IncomeType_MinipoolDistribution,
+			Source: log.Address,
+			Block:  log.BlockNumber,
+			TxHash: log.TxHash,
+			Token:  "ETH",
+			Amount: nodeAmount,
+		}
+		if totalBalance, exists := getIncomeEventValue(values, "totalBalance"); exists && totalBalance.Cmp(fullWithdrawalThreshold) >= 0 {
+			event.Note = "full withdrawal; the amount includes the node's returned bond, which is not income"
+		}
+		events = append(events, event)
+	}
+
+	return events, warnings, nil
+
+}
+
+// Get the ETH the node received from its fee distributor
+func getFeeDistributorIncome(rp *rocketpool.RocketPool, nodeAddress common.Address, intervalSize *big.Int, fromBlock *big.Int, toBlock *big.Int) ([]api.NodeIncomeEvent, []string, error) {
+
+	events := []api.NodeIncomeEvent{}
+	warnings := []string{}
+
+	delegate, err := rp.GetContract("rocketNodeDistributorDelegate", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	distributionEvent, exists := delegate.ABI.Events["FeesDistributed"]
+	if !exists {
+		warnings = append(warnings, "The fee distributor doesn't have a FeesDistributed event, so fee distributor payouts couldn't be exported.")
+		return events, warnings, nil
+	}
+
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting fee distributor address: %w", err)
+	}
+
+	logs, err := eth.GetLogs(rp, []common.Address{distributorAddress}, [][]common.Hash{{distributionEvent.ID}}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting fee distributor payouts: %w", err)
+	}
+
+	for _, log := range logs {
+		values := map[string]interface{}{}
+		if err := distributionEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Couldn't decode the fee distributor payout in transaction %s: %s", log.TxHash.Hex(), err.Error()))
+			continue
+		}
+		nodeAmount, exists := getIncomeEventValue(values, "nodeAmount")
+		if !exists || nodeAmount.Sign() == 0 {
+			continue
+		}
+		events = append(events, api.NodeIncomeEvent{
+			Type:   api.IncomeType_FeeDistributor,
+			Source: log.Address,
+			Block:  log.BlockNumber,
+			TxHash: log.TxHash,
+			Token:  "ETH",
+			Amount: nodeAmount,
+		})
+	}
+
+	return events, warnings, nil
+
+}
+
+// Set the timestamp, RPL price and ETH value of each income event
+func addIncomeTimestampsAndPrices(rp *rocketpool.RocketPool, intervalSize *big.Int, fromBlock uint64, toBlock uint64, events []api.NodeIncomeEvent) ([]string, error) {
+
+	warnings := []string{}
+	if len(events) == 0 {
+		return warnings, nil
+	}
+
+	// Get the price updates, starting a little before the export so the price in effect at its start is included
+	priceFromBlock := uint64(0)
+	if fromBlock > incomePriceLookbackBlocks {
+		priceFromBlock = fromBlock - incomePriceLookbackBlocks
+	}
+	prices, err := getRplPriceHistory(rp, intervalSize, priceFromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[uint64]*types.Header{}
+	for i := range events {
+		event := &events[i]
+
+		// Get the timestamp
+		header, exists := headers[event.Block]
+		if !exists {
+			header, err = rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(event.Block))
+			if err != nil {
+				return nil, fmt.Errorf("error getting header for block %d: %w", event.Block, err)
+			}
+			headers[event.Block] = header
+		}
+		event.Timestamp = time.Unix(int64(header.Time), 0).UTC()
+
+		// Get the latest price update at or before the event
+		index := sort.Search(len(prices), func(j int) bool {
+			return prices[j].block > event.Block
+		})
+		if index > 0 {
+			event.RplPrice = prices[index-1].price
+		} else {
+			// Fall back to reading the price from the contract's state at that block
+			price, err := network.GetRPLPrice(rp, &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(event.Block)})
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("Couldn't find the RPL price at block %d.", event.Block))
+			} else {
+				event.RplPrice = price
+			}
+		}
+
+		// Get the value in ETH
+		switch event.Token {
+		case "ETH":
+			event.EthValue = event.Amount
+		case "RPL":
+			if event.RplPrice != nil {
+				event.EthValue = new(big.Int).Mul(event.Amount, event.RplPrice)
+				event.EthValue.Div(event.EthValue, eth.EthToWei(1))
+			}
+		}
+	}
+
+	return warnings, nil
+
+}
+
+// Get the RPL price updates from the Oracle DAO's on-chain submissions, ordered by block
+func getRplPriceHistory(rp *rocketpool.RocketPool, intervalSize *big.Int, fromBlock uint64, toBlock uint64) ([]rplPriceUpdate, error) {
+
+	prices, err := rp.GetContract("rocketNetworkPrices", nil)
+	if err != nil {
+		return nil, err
+	}
+	pricesUpdatedEvent, exists := prices.ABI.Events["PricesUpdated"]
+	if !exists {
+		return nil, fmt.Errorf("the network prices contract doesn't have a PricesUpdated event")
+	}
+	legacyEventID := crypto.Keccak256Hash([]byte(legacyPricesUpdatedSignature))
+
+	logs, err := eth.FilterContractLogs(rp, "rocketNetworkPrices", eth.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Topics:    [][]common.Hash{{pricesUpdatedEvent.ID, legacyEventID}},
+	}, intervalSize, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting RPL price updates: %w", err)
+	}
+
+	updates := []rplPriceUpdate{}
+	for _, log := range logs {
+		price := getRplPriceFromUpdate(pricesUpdatedEvent, log.Data)
+		if price == nil {
+			continue
+		}
+		updates = append(updates, rplPriceUpdate{
+			block: log.BlockNumber,
+			price: price,
+		})
+	}
+	sort.SliceStable(updates, func(i, j int) bool {
+		return updates[i].block < updates[j].block
+	})
+	return updates, nil
+
+}
+
+// Get the RPL price from the data of a PricesUpdated event.
+// The event's layout changed across protocol upgrades, so this handles each of them:
+// (rplPrice, time) before Houston, (rplPrice, effectiveRplStake, time) in the original release, and (slotTimestamp, rplPrice, time) since Houston.
+func getRplPriceFromUpdate(event abi.Event, data []byte) *big.Int {
+	switch len(data) {
+	case 64:
+		return new(big.Int).SetBytes(data[:32])
+	case 96:
+		// Slot timestamps are far smaller than any RPL price in wei, which tells the two layouts apart
+		first := new(big.Int).SetBytes(data[:32])
+		if first.BitLen() > 40 {
+			return first
+		}
+		values := map[string]interface{}{}
+		if err := event.Inputs.UnpackIntoMap(values, data); err == nil {
+			if price, exists := getIncomeEventValue(values, "rplPrice"); exists {
+				return price
+			}
+		}
+		return new(big.Int).SetBytes(data[32:64])
+	default:
+		return nil
+	}
+}
+
+// Get a uint256 value from a decoded event, allowing for the underscore prefix some contracts use for their parameter names
+func getIncomeEventValue(values map[string]interface{}, name string) (*big.Int, bool) {
+	for key, value := range values {
+		if strings.TrimPrefix(key, "_") == name {
+			bigValue, ok := value.(*big.Int)
+			return bigValue, ok
+		}
+	}
+	return nil, false
+}
+
+// Get a uint256 array from a decoded event, allowing for the underscore prefix some contracts use for their parameter names
+func getIncomeEventArray(values map[string]interface{}, name string) ([]*big.Int, bool) {
+	for key, value := range values {
+		if strings.TrimPrefix(key, "_") == name {
+			array, ok := value.([]*big.Int)
+			return array, ok
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getHistory(c *cli.Context, from time.Time, to time.Time, step time.Duration) (*api.NodeHistoryResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeHistoryResponse{}
+	response.RetentionDays = cfg.Smartnode.HistoryRetentionDays.Value.(uint64)
+
+	// Read the recorded samples
+	store := history.NewStore(cfg.Smartnode.GetHistoryPath())
+	samples, err := store.Query(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error reading node history: %w", err)
+	}
+	response.Samples = history.Downsample(samples, step)
+
+	// Return response
+	return &response, nil
+
+}
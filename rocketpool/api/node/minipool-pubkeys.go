@@ -0,0 +1,46 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the validator pubkeys of a node's minipools; this works without a wallet so a backup can be checked before it's restored
+func getMinipoolPubkeys(c *cli.Context, nodeAddress common.Address) (*api.NodeMinipoolPubkeysResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeMinipoolPubkeysResponse{}
+
+	// Check the node
+	response.Exists, err = node.GetNodeExists(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Exists {
+		return &response, nil
+	}
+
+	// Get the pubkeys
+	response.Pubkeys, err = minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
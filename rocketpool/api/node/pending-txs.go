@@ -0,0 +1,74 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getPendingTransactions(c *cli.Context) (*api.PendingTransactionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PendingTransactionsResponse{}
+
+	// Get the pending transactions
+	response.Transactions, response.LatestNonce, response.PendingNonce, err = txm.GetPendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func cancelTransaction(c *cli.Context, nonce uint64) (*api.CancelTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CancelTransactionResponse{}
+
+	// Get transactor, which holds any fees set with the global flags
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancel the transaction
+	response.TxHash, err = txm.CancelTransaction(nonce, opts.GasFeeCap, opts.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -201,9 +201,10 @@ func confirmPrimaryWithdrawalAddress(c *cli.Context) (*api.ConfirmNodePrimaryWit
 	if err != nil {
 		return nil, err
 	}
-	if pendingAddress != nodeAccount.Address {
+	// The sender is the node address unless the transaction is being exported for another address to sign
+	if pendingAddress != opts.From {
 		return nil, fmt.Errorf("This wallet's pending withdrawal address is %s, "+
-			"which is not the node address.", pendingAddress.String())
+			"which is not the sender address %s.", pendingAddress.String(), opts.From.Hex())
 	}
 
 	// Set withdrawal address
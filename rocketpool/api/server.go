@@ -0,0 +1,189 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const (
+	ServerColor = color.FgHiCyan
+)
+
+// API server
+type apiServer struct {
+	app          *cli.App
+	settingsPath string
+	log          log.ColorLogger
+	lock         sync.Mutex
+}
+
+// Register the API server command
+func RegisterServerCommand(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Run the Rocket Pool API server, which serves API commands over a local socket from a single long-lived process",
+		Action: func(c *cli.Context) error {
+			return runServer(c)
+		},
+	})
+}
+
+// Run the API server
+func runServer(c *cli.Context) error {
+
+	// Get services; the settings can change while the server runs
+	services.EnableConfigReload()
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Remove the socket left behind by a previous run
+	socketPath := os.ExpandEnv(cfg.Smartnode.GetApiSocketPath())
+	err = os.Remove(socketPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing old API socket [%s]: %w", socketPath, err)
+	}
+
+	// Create the socket
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating API socket [%s]: %w", socketPath, err)
+	}
+	defer listener.Close()
+
+	// Give the socket to the owner of the data folder so the CLI can connect to it, and lock everyone else out
+	err = matchOwner(socketPath, filepath.Dir(socketPath))
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(socketPath, 0600)
+	if err != nil {
+		return fmt.Errorf("error setting permissions of API socket [%s]: %w", socketPath, err)
+	}
+
+	server := &apiServer{
+		app:          c.App,
+		settingsPath: c.GlobalString("settings"),
+		log:          log.NewColorLogger(ServerColor),
+	}
+
+	// Listen for the wallet password if it isn't saved to disk
+	err = services.ServeWalletUnlock(c, "api", server.log)
+	if err != nil {
+		return err
+	}
+
+	// Start the HTTP server
+	mux := http.NewServeMux()
+	mux.HandleFunc(apitypes.APIServerCallPath, server.handleCall)
+	server.log.Printlnf("Starting API server on %s.", socketPath)
+	err = http.Serve(listener, mux)
+	if err != nil {
+		return fmt.Errorf("Error running API server: %w", err)
+	}
+
+	return nil
+
+}
+
+// Run an API command and return its response
+func (s *apiServer) handleCall(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Decode the request
+	var request apitypes.APIServerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding request: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	// Build the command line for the call
+	args := []string{
+		s.app.Name,
+		"--settings", s.settingsPath,
+		"--maxFee", strconv.FormatFloat(request.MaxFee, 'f', -1, 64),
+		"--maxPrioFee", strconv.FormatFloat(request.MaxPrioFee, 'f', -1, 64),
+		"--gasLimit", strconv.FormatUint(request.GasLimit, 10),
+	}
+	if request.Nonce != "" {
+		args = append(args, "--nonce", request.Nonce)
+	}
+	if request.IgnoreSyncCheck {
+		args = append(args, "--ignore-sync-check")
+	}
+	if request.ForceFallbacks {
+		args = append(args, "--force-fallbacks")
+	}
+	if request.DryRun {
+		args = append(args, "--dry-run")
+	}
+	if request.Unsigned {
+		args = append(args, "--unsigned")
+	}
+	if request.UnsignedNode != "" {
+		args = append(args, "--unsigned-node", request.UnsignedNode)
+	}
+	if request.UnsignedFrom != "" {
+		args = append(args, "--unsigned-from", request.UnsignedFrom)
+	}
+	args = append(args, "api")
+	args = append(args, request.Args...)
+
+	// The services are shared by every call, so only run one at a time
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Run the command and capture its response
+	buffer := new(bytes.Buffer)
+	api.SetResponseOutput(buffer)
+	defer api.SetResponseOutput(os.Stdout)
+	if err := s.app.Run(args); err != nil {
+		api.PrintErrorResponse(err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err := w.Write(buffer.Bytes())
+	if err != nil {
+		s.log.Printlnf("Error writing API response: %s", err.Error())
+	}
+
+}
+
+// Set the owner of a file to the owner of a reference path
+func matchOwner(path string, referencePath string) error {
+	info, err := os.Stat(referencePath)
+	if err != nil {
+		return fmt.Errorf("error getting info for [%s]: %w", referencePath, err)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	err = os.Chown(path, int(stat.Uid), int(stat.Gid))
+	if err != nil {
+		return fmt.Errorf("error setting owner of [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -52,6 +52,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "doctor",
+				Usage:     "Gathers the node's details for a health and misconfiguration diagnosis",
+				UsageText: "rocketpool api service doctor",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDoctorStatus(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "restart-vc",
 				Usage:     "Restarts the validator client",
@@ -69,6 +87,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "set-graffiti",
+				Usage:     "Sets the graffiti in the settings on every validator through the validator client's keymanager API",
+				UsageText: "rocketpool api service set-graffiti",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setGraffiti(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// How long to wait for the time server
+const doctorNtpTimeout = 5 * time.Second
+
+// Gathers the information the node daemon has for `rocketpool service doctor`.
+// Nothing here requires synced clients, since the doctor is most useful when something is wrong with them.
+func getDoctorStatus(c *cli.Context) (*api.ServiceDoctorResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ServiceDoctorResponse{}
+
+	// Get the client statuses
+	response.EcManagerStatus = *ec.CheckStatus(cfg)
+	response.BcManagerStatus = *bc.CheckStatus()
+	ecReady := (response.EcManagerStatus.PrimaryClientStatus.IsSynced || response.EcManagerStatus.FallbackClientStatus.IsSynced)
+	bcReady := (response.BcManagerStatus.PrimaryClientStatus.IsWorking || response.BcManagerStatus.FallbackClientStatus.IsWorking)
+
+	// Compare the clock with a time server
+	response.Clock.SystemTime = time.Now().UTC()
+	response.Clock.TimeServer = netutils.DefaultNtpServer
+	offset, err := netutils.GetNtpOffset(netutils.DefaultNtpServer, doctorNtpTimeout)
+	if err != nil {
+		response.Clock.TimeServerError = err.Error()
+	} else {
+		response.Clock.TimeServerOffset = offset.Seconds()
+	}
+
+	// Get the slot clock
+	if bcReady {
+		eth2Config, err := bc.GetEth2Config()
+		if err != nil {
+			response.Clock.Error = fmt.Sprintf("Error getting Beacon config: %s", err.Error())
+		} else {
+			response.Clock.GenesisTime = eth2Config.GenesisTime
+			response.Clock.SecondsPerSlot = eth2Config.SecondsPerSlot
+			syncStatus, err := bc.GetSyncStatus()
+			if err != nil {
+				response.Clock.Error = fmt.Sprintf("Error getting Beacon head: %s", err.Error())
+			} else {
+				response.Clock.HeadSlot = syncStatus.HeadSlot
+			}
+		}
+	} else {
+		response.Clock.Error = "The Beacon node isn't available"
+	}
+
+	// Get the node account
+	response.WalletInitialized, err = w.GetInitialized()
+	if err != nil {
+		return nil, err
+	}
+	response.WalletLocked, err = services.IsNodeWalletLocked(c)
+	if err != nil {
+		return nil, err
+	}
+	if !response.WalletInitialized || response.WalletLocked {
+		return &response, nil
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// The rest of the checks need the chain
+	if !ecReady {
+		response.ChainError = "None of your Execution clients are synced"
+		return &response, nil
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the balance and gas price
+	response.Gas.NodeBalance, err = ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		response.Gas.Error = fmt.Sprintf("Error getting node balance: %s", err.Error())
+	} else {
+		header, err := ec.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			response.Gas.Error = fmt.Sprintf("Error getting latest block: %s", err.Error())
+		} else {
+			response.Gas.BaseFee = header.BaseFee
+		}
+	}
+
+	// Everything else only applies to registered nodes
+	response.Registered, err = node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		response.ChainError = fmt.Sprintf("Error checking node registration: %s", err.Error())
+		return &response, nil
+	}
+	if !response.Registered {
+		return &response, nil
+	}
+
+	// Check the fee recipient file
+	if !bcReady {
+		response.FeeRecipient.Error = "The Beacon node isn't available"
+	} else {
+		feeRecipientInfo, err := rputils.GetFeeRecipientInfoWithoutState(rp, bc, nodeAccount.Address, nil)
+		if err != nil {
+			response.FeeRecipient.Error = fmt.Sprintf("Error getting fee recipient info: %s", err.Error())
+		} else {
+			response.FeeRecipient.IsInSmoothingPool = feeRecipientInfo.IsInSmoothingPool
+			response.FeeRecipient.IsInOptOutCooldown = feeRecipientInfo.IsInOptOutCooldown
+			if feeRecipientInfo.IsInSmoothingPool || feeRecipientInfo.IsInOptOutCooldown {
+				response.FeeRecipient.ExpectedRecipient = feeRecipientInfo.SmoothingPoolAddress
+			} else {
+				response.FeeRecipient.ExpectedRecipient = feeRecipientInfo.FeeDistributorAddress
+			}
+			response.FeeRecipient.FileExists, response.FeeRecipient.FileCorrect, err = rpsvc.CheckFeeRecipientFile(response.FeeRecipient.ExpectedRecipient, cfg)
+			if err != nil {
+				response.FeeRecipient.Error = fmt.Sprintf("Error checking fee recipient file: %s", err.Error())
+			}
+		}
+	}
+
+	// Check that there's a key for each validating minipool
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		response.ValidatorKeys.Error = fmt.Sprintf("Error getting minipools: %s", err.Error())
+	} else {
+		response.ValidatorKeys.MinipoolCount = len(pubkeys)
+		response.ValidatorKeys.MissingKeys, response.ValidatorKeys.Source, err = getMissingValidatorKeys(c, cfg, w, pubkeys)
+		if err != nil {
+			response.ValidatorKeys.Error = fmt.Sprintf("Error checking validator keys: %s", err.Error())
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the pubkeys that the validator client doesn't have a key for, along with a description of where it was checked.
+// The remote signer or the VC's keymanager API are asked when they're enabled, since they know what the VC can actually sign with;
+// otherwise, only the selected client's keystore counts, since that's the only one the VC loads.
+func getMissingValidatorKeys(c *cli.Context, cfg *config.RocketPoolConfig, w *wallet.Wallet, pubkeys []rptypes.ValidatorPubkey) ([]rptypes.ValidatorPubkey, string, error) {
+
+	// Get the selected client
+	client, _ := cfg.GetSelectedConsensusClient()
+	if cfg.IsNativeMode {
+		client = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+	}
+
+	// Get the keys the VC can sign with, if they can be listed
+	var source string
+	var available []rptypes.ValidatorPubkey
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, "", err
+	}
+	switch {
+	case cfg.EnableRemoteSigner.Value.(bool) && client == cfgtypes.ConsensusClient_Lighthouse:
+		// Lighthouse only loads the remote keys it has definitions for
+		ks, ok := w.GetKeystore(string(client)).(*lhkeystore.RemoteKeystore)
+		if !ok {
+			return nil, "", fmt.Errorf("the Lighthouse remote signer definitions aren't available")
+		}
+		source = "Lighthouse's remote signer definitions"
+		available, err = ks.GetValidatorPubkeys()
+
+	case cfg.EnableRemoteSigner.Value.(bool):
+		// The other clients load every key in the remote signer
+		ks, ok := w.GetKeystore("web3signer").(*web3signer.Keystore)
+		if !ok {
+			return nil, "", fmt.Errorf("the remote signer isn't available")
+		}
+		source = "the remote signer"
+		available, err = ks.GetValidatorPubkeys()
+
+	case km != nil:
+		source = "the validator client's keymanager API"
+		available, err = km.ListKeys()
+
+	default:
+		// Check the selected client's keystore for each key
+		ks := w.GetKeystore(string(client))
+		if ks == nil {
+			return nil, "", fmt.Errorf("there isn't a keystore for %s", client)
+		}
+		missing := []rptypes.ValidatorPubkey{}
+		for _, pubkey := range pubkeys {
+			key, err := ks.LoadValidatorKey(pubkey)
+			if err != nil {
+				return nil, "", err
+			}
+			if key == nil {
+				missing = append(missing, pubkey)
+			}
+		}
+		return missing, fmt.Sprintf("the %s keystore", client), nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Compare them with the minipools
+	availableKeys := map[rptypes.ValidatorPubkey]bool{}
+	for _, pubkey := range available {
+		availableKeys[pubkey] = true
+	}
+	missing := []rptypes.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if !availableKeys[pubkey] {
+			missing = append(missing, pubkey)
+		}
+	}
+	return missing, source, nil
+
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Sets the graffiti in the settings on every validator in the running VC, so it doesn't have to be restarted to use it
+func setGraffiti(c *cli.Context) (*api.SetGraffitiResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		return nil, errors.New("The validator client's keymanager API isn't enabled.")
+	}
+	if cfg.GraffitiWallWriter.GetEnabledParameter().Value == true {
+		return nil, errors.New("The graffiti wall writer manages the validator client's graffiti.")
+	}
+
+	// Response
+	response := api.SetGraffitiResponse{}
+
+	// Set the graffiti
+	response.Graffiti, err = cfg.Graffiti()
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorCount, err = validator.SetGraffiti(cfg, km, response.Graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
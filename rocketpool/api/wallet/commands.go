@@ -172,6 +172,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "delete-validator-keys",
+				Usage:     "Delete every validator key from the validator client through its keymanager API, saving their slashing protection data",
+				UsageText: "rocketpool api wallet delete-validator-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(deleteValidatorKeys(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"r"},
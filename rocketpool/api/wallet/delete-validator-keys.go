@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+const slashingProtectionFileMode os.FileMode = 0600
+
+func deleteValidatorKeys(c *cli.Context) (*api.DeleteValidatorKeysResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
+	if km == nil {
+		return nil, errors.New("The validator client's keymanager API isn't enabled, so its keys can't be deleted while it's running.")
+	}
+
+	// Response
+	response := api.DeleteValidatorKeysResponse{}
+
+	// Delete the keys
+	pubkeys, slashingProtection, err := validator.DeleteValidatorKeys(cfg, km)
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorKeys = pubkeys
+
+	// Save the slashing protection data, since the keys can't be used safely anywhere else without it
+	if slashingProtection != "" {
+		folder := cfg.Smartnode.GetSlashingProtectionFolder(true)
+		err = os.MkdirAll(folder, 0700)
+		if err != nil {
+			return nil, fmt.Errorf("error creating slashing protection folder [%s]: %w", folder, err)
+		}
+		response.SlashingProtectionFile = fmt.Sprintf(config.DeletedKeysSlashingProtectionFormat, time.Now().Unix())
+		path := filepath.Join(folder, response.SlashingProtectionFile)
+		err = os.WriteFile(path, []byte(slashingProtection), slashingProtectionFileMode)
+		if err != nil {
+			return nil, fmt.Errorf("the keys were deleted but their slashing protection data couldn't be saved to [%s]: %w", path, err)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
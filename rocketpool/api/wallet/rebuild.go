@@ -5,6 +5,8 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
@@ -25,6 +27,18 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.RebuildWalletResponse{}
@@ -46,6 +60,15 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 		return nil, err
 	}
 
+	// Load the keys into the VC if it has a keymanager API
+	if km != nil && len(response.ValidatorKeys) > 0 {
+		feeRecipientInfo, err := rputils.GetFeeRecipientInfoWithoutState(rp, bc, nodeAccount.Address, nil)
+		if err == nil {
+			err = validator.ImportValidatorKeys(cfg, km, response.ValidatorKeys, w.LoadValidatorKey, feeRecipientInfo.GetCorrectFeeRecipient())
+			response.ValidatorsLoaded = (err == nil)
+		}
+	}
+
 	// Return response
 	return &response, nil
 
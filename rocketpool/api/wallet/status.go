@@ -24,7 +24,14 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 
 	// Get wallet status
 	response.PasswordSet = pm.IsPasswordSet()
-	response.WalletInitialized = w.IsInitialized()
+	response.WalletLocked, err = services.IsNodeWalletLocked(c)
+	if err != nil {
+		return nil, err
+	}
+	response.WalletInitialized, err = w.GetInitialized()
+	if err != nil {
+		return nil, err
+	}
 
 	// Get accounts if initialized
 	if response.WalletInitialized {
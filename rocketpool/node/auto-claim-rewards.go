@@ -0,0 +1,321 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Auto claim rewards task
+type autoClaimRewards struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	txm            *txmanager.Manager
+	gasThreshold   float64
+	rplThreshold   *big.Int
+	ethThreshold   *big.Int
+	restakePercent float64
+	disabled       bool
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// The rewards from the unclaimed intervals that can be claimed
+type claimableRewards struct {
+	intervals    []uint64
+	indices      []*big.Int
+	amountRPL    []*big.Int
+	amountETH    []*big.Int
+	merkleProofs [][]common.Hash
+	totalRPL     *big.Int
+	totalETH     *big.Int
+}
+
+// Create auto claim rewards task
+func newAutoClaimRewards(c *cli.Context, logger log.ColorLogger) (*autoClaimRewards, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-claiming is disabled
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	rplThreshold := cfg.Smartnode.AutoClaimRplThreshold.Value.(float64)
+	ethThreshold := cfg.Smartnode.AutoClaimEthThreshold.Value.(float64)
+	restakePercent := cfg.Smartnode.AutoClaimRestakePercent.Value.(float64)
+	disabled := false
+	if rplThreshold <= 0 && ethThreshold <= 0 {
+		disabled = true
+	} else if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling auto-claim.")
+		disabled = true
+	}
+
+	// Safety clamp
+	if restakePercent < 0 {
+		logger.Printlnf("WARNING: Auto-claim restake percent is negative (%.2f%%), restaking nothing.", restakePercent)
+		restakePercent = 0
+	} else if restakePercent > 100 {
+		logger.Printlnf("WARNING: Auto-claim restake percent is more than 100%% (%.2f%%), restaking all claimed RPL.", restakePercent)
+		restakePercent = 100
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &autoClaimRewards{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		txm:            txm,
+		gasThreshold:   gasThreshold,
+		rplThreshold:   getAutoClaimThreshold(rplThreshold),
+		ethThreshold:   getAutoClaimThreshold(ethThreshold),
+		restakePercent: restakePercent,
+		disabled:       disabled,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Auto claim rewards
+func (t *autoClaimRewards) run(state *state.NetworkState) error {
+
+	// Check if auto-claim is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for rewards to claim...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the rewards from the unclaimed intervals
+	claimable, err := t.getClaimableRewards(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if len(claimable.intervals) == 0 {
+		return nil
+	}
+
+	// Check the thresholds
+	rplReady := t.rplThreshold != nil && claimable.totalRPL.Cmp(t.rplThreshold) >= 0
+	ethReady := t.ethThreshold != nil && claimable.totalETH.Cmp(t.ethThreshold) >= 0
+	if !rplReady && !ethReady {
+		return nil
+	}
+
+	// Get the amount of RPL to restake
+	stakeAmount := big.NewInt(0)
+	if t.restakePercent > 0 {
+		stakeAmount = eth.EthToWei(eth.WeiToEth(claimable.totalRPL) * t.restakePercent / 100)
+		if stakeAmount.Cmp(claimable.totalRPL) > 0 {
+			stakeAmount.Set(claimable.totalRPL)
+		}
+	}
+
+	// Claim
+	success, err := t.claimRewards(nodeAccount.Address, claimable, stakeAmount)
+	if !success && err == nil {
+		// Waiting for lower gas
+		return nil
+	}
+	alerting.AlertRewardsClaimed(t.cfg, claimable.intervals, eth.WeiToEth(claimable.totalRPL), eth.WeiToEth(claimable.totalETH), eth.WeiToEth(stakeAmount), err == nil)
+	if err != nil {
+		return fmt.Errorf("Could not claim rewards for intervals %v: %w", claimable.intervals, err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the rewards for every unclaimed interval with a valid tree file
+func (t *autoClaimRewards) getClaimableRewards(nodeAddress common.Address) (*claimableRewards, error) {
+
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, nodeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	claimable := &claimableRewards{
+		totalRPL: big.NewInt(0),
+		totalETH: big.NewInt(0),
+	}
+	for _, interval := range unclaimed {
+		intervalInfo, err := rprewards.GetIntervalInfo(t.rp, t.cfg, nodeAddress, interval, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting info for interval %d: %w", interval, err)
+		}
+		if !intervalInfo.TreeFileExists {
+			t.log.Printlnf("Rewards tree file for interval %d doesn't exist yet, skipping it.", interval)
+			continue
+		}
+		if !intervalInfo.MerkleRootValid {
+			t.log.Printlnf("WARNING: Merkle root for the rewards tree file of interval %d doesn't match the canonical one, skipping it.", interval)
+			continue
+		}
+		if !intervalInfo.NodeExists {
+			continue
+		}
+
+		rplForInterval := big.NewInt(0)
+		rplForInterval.Add(rplForInterval, &intervalInfo.CollateralRplAmount.Int)
+		rplForInterval.Add(rplForInterval, &intervalInfo.ODaoRplAmount.Int)
+		ethForInterval := big.NewInt(0)
+		ethForInterval.Add(ethForInterval, &intervalInfo.SmoothingPoolEthAmount.Int)
+
+		claimable.intervals = append(claimable.intervals, interval)
+		claimable.indices = append(claimable.indices, big.NewInt(0).SetUint64(interval))
+		claimable.amountRPL = append(claimable.amountRPL, rplForInterval)
+		claimable.amountETH = append(claimable.amountETH, ethForInterval)
+		claimable.merkleProofs = append(claimable.merkleProofs, intervalInfo.MerkleProof)
+		claimable.totalRPL.Add(claimable.totalRPL, rplForInterval)
+		claimable.totalETH.Add(claimable.totalETH, ethForInterval)
+	}
+
+	return claimable, nil
+
+}
+
+// Claim the rewards, restaking some of the RPL if requested
+func (t *autoClaimRewards) claimRewards(nodeAddress common.Address, claimable *claimableRewards, stakeAmount *big.Int) (bool, error) {
+
+	// Log
+	t.log.Printlnf("Claiming %.6f RPL and %.6f ETH from intervals %v (restaking %.6f RPL)...", eth.WeiToEth(claimable.totalRPL), eth.WeiToEth(claimable.totalETH), claimable.intervals, eth.WeiToEth(stakeAmount))
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	restake := stakeAmount.Sign() > 0
+	var gasInfo rocketpool.GasInfo
+	if restake {
+		gasInfo, err = rewards.EstimateClaimAndStakeGas(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, stakeAmount, opts)
+	} else {
+		gasInfo, err = rewards.EstimateClaimGas(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, opts)
+	}
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to claim rewards: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = GetPriorityFee(t.maxPriorityFee, maxFee)
+	opts.GasLimit = gas.Uint64()
+
+	// Claim rewards
+	var hash common.Hash
+	if restake {
+		hash, err = rewards.ClaimAndStake(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, stakeAmount, opts)
+	} else {
+		hash, err = rewards.Claim(t.rp, nodeAddress, claimable.indices, claimable.amountRPL, claimable.amountETH, claimable.merkleProofs, opts)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully claimed rewards for intervals %v.", claimable.intervals)
+
+	// Return
+	return true, nil
+
+}
+
+// Convert an auto-claim threshold to wei, or nil if it's disabled
+func getAutoClaimThreshold(threshold float64) *big.Int {
+	if threshold <= 0 {
+		return nil
+	}
+	return eth.EthToWei(threshold)
+}
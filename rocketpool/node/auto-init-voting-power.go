@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -25,6 +26,7 @@ type autoInitVotingPower struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *txmanager.Manager
 	bc             beacon.Client
 	gasThreshold   float64
 	maxFee         *big.Int
@@ -47,6 +49,10 @@ func newAutoInitVotingPower(c *cli.Context, logger log.ColorLogger, gasThreshold
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -84,6 +90,7 @@ func newAutoInitVotingPower(c *cli.Context, logger log.ColorLogger, gasThreshold
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		bc:             bc,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
@@ -154,7 +161,7 @@ func (t *autoInitVotingPower) submitInitializeVotingPower() error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, t.log)
 	if err != nil {
 		return err
 	}
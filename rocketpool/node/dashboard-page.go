@@ -0,0 +1,98 @@
+package node
+
+import (
+	"fmt"
+	"html/template"
+	"time"
+)
+
+// Helpers for the dashboard page
+var dashboardTemplateFuncs = template.FuncMap{
+	"amount": func(value float64) string {
+		return fmt.Sprintf("%.4f", value)
+	},
+	"percent": func(value float64) string {
+		return fmt.Sprintf("%.2f%%", value*100)
+	},
+	"date": func(value time.Time) string {
+		if value.IsZero() {
+			return "-"
+		}
+		return value.UTC().Format("2006-01-02 15:04 UTC")
+	},
+}
+
+// The dashboard page. It has no scripts so it works with a strict Content Security Policy; it refreshes itself instead.
+const dashboardPageTemplate string = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="60">
+<title>Rocket Pool Node Dashboard</title>
+<style>
+body { font-family: sans-serif; margin: 2em; background: #fafafa; color: #222; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 2em; border-bottom: 1px solid #ccc; }
+table { border-collapse: collapse; margin-top: 0.5em; }
+th, td { text-align: left; padding: 0.3em 1em 0.3em 0; }
+th { border-bottom: 1px solid #ccc; }
+.mono { font-family: monospace; }
+.good { color: #1a7f37; }
+.bad { color: #cf222e; }
+.warn { color: #9a6700; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>Rocket Pool Node Dashboard</h1>
+<p class="muted">Node <span class="mono">{{.NodeAddress.Hex}}</span> on {{.Network}} &middot; block {{.Block}}, slot {{.Slot}} &middot; updated {{date .UpdatedAt}}</p>
+{{range .Warnings}}<p class="warn">{{.}}</p>{{end}}
+
+<h2>Clients</h2>
+<table>
+<tr><th>Client</th><th>Status</th></tr>
+{{range .Clients}}<tr><td>{{.Name}}</td><td>{{if not .IsWorking}}<span class="bad">Not working: {{.Error}}</span>{{else if .IsSynced}}<span class="good">Synced and ready</span>{{else}}<span class="warn">Syncing ({{percent .SyncProgress}})</span>{{end}}</td></tr>
+{{end}}</table>
+
+<h2>Alerts</h2>
+{{if .Alerts}}<table>
+<tr><th>Severity</th><th>Alert</th><th>Description</th></tr>
+{{range .Alerts}}<tr><td class="{{if eq (index .Labels "severity") "critical"}}bad{{else}}warn{{end}}">{{index .Labels "severity"}}</td><td>{{.Summary}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>{{else}}<p class="good">No active alerts.</p>{{end}}
+
+<h2>Node</h2>
+{{if .Node.Registered}}<table>
+<tr><td>ETH balance</td><td>{{amount .Node.EthBalance}} ETH</td></tr>
+<tr><td>RPL balance</td><td>{{amount .Node.RplBalance}} RPL</td></tr>
+<tr><td>RPL stake</td><td>{{amount .Node.RplStake}} RPL (effective: {{amount .Node.EffectiveRplStake}} RPL, minimum: {{amount .Node.MinimumRplStake}} RPL)</td></tr>
+<tr><td>RPL price</td><td>{{amount .Node.RplPrice}} ETH</td></tr>
+<tr><td>Collateral</td><td{{if lt .Node.RplStake .Node.MinimumRplStake}} class="bad"{{end}}>{{percent .Node.BorrowedCollateralRatio}} of borrowed ETH, {{percent .Node.BondedCollateralRatio}} of bonded ETH</td></tr>
+<tr><td>Withdrawal address</td><td class="mono">{{.Node.WithdrawalAddress}}</td></tr>
+<tr><td>Fee distributor balance</td><td>{{amount .Node.FeeDistributorBalance}} ETH</td></tr>
+</table>{{else}}<p class="warn">This node is not registered with Rocket Pool.</p>{{end}}
+
+<h2>Smoothing Pool</h2>
+{{if .Node.Registered}}<p>{{if .Node.SmoothingPoolOptedIn}}<span class="good">Opted in</span>{{else}}Not opted in{{end}}{{if not .Node.SmoothingPoolChanged.IsZero}} since {{date .Node.SmoothingPoolChanged}}{{end}}. The Smoothing Pool currently holds {{amount .Node.SmoothingPoolBalance}} ETH.</p>{{end}}
+
+<h2>Minipools</h2>
+{{if .Minipools}}<table>
+<tr><th>Address</th><th>Status</th><th>Bond</th><th>Validator</th><th>Validator State</th><th>Beacon Balance</th></tr>
+{{range .Minipools}}<tr{{if .Finalised}} class="muted"{{end}}><td class="mono">{{.Address.Hex}}</td><td>{{.Status}}{{if .Finalised}} (finalised){{end}}</td><td>{{amount .NodeDeposit}} ETH</td><td>{{if .ValidatorIndex}}{{.ValidatorIndex}}{{else}}-{{end}}</td><td>{{if .ValidatorState}}{{.ValidatorState}}{{else}}not seen on the Beacon Chain{{end}}</td><td>{{amount .BeaconBalance}} ETH</td></tr>
+{{end}}</table>{{else}}<p>This node doesn't have any minipools.</p>{{end}}
+
+<h2>Rewards</h2>
+{{if .Rewards}}<table>
+<tr><th>Interval</th><th>Ended</th><th>RPL</th><th>Smoothing Pool ETH</th><th>Claimed</th></tr>
+{{range .Rewards}}<tr><td>{{.Index}}</td><td>{{date .EndTime}}</td>{{if .Available}}<td>{{amount .Rpl}}</td><td>{{amount .Eth}}</td>{{else}}<td colspan="2" class="warn">Rewards file not downloaded yet</td>{{end}}<td>{{if .Claimed}}Yes{{else}}No{{end}}</td></tr>
+{{end}}</table>{{else}}<p>This node hasn't earned any rewards yet.</p>{{end}}
+
+<h2>Pending Protocol DAO Proposals</h2>
+{{if .Proposals}}<table>
+<tr><th>ID</th><th>Proposal</th><th>State</th><th>Until</th></tr>
+{{range .Proposals}}<tr><td>{{.ID}}</td><td>{{.Message}}</td><td>{{.State}}</td><td>{{date .EndTime}}</td></tr>
+{{end}}</table>{{else}}<p>There are no pending proposals.</p>{{end}}
+
+<p class="muted">This dashboard is read-only. The same data is available as JSON at <span class="mono">/api/status</span>.</p>
+</body>
+</html>
+`
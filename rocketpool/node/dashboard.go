@@ -0,0 +1,487 @@
+package node
+
+import (
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dashboard"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long a dashboard snapshot is reused before it's rebuilt
+var dashboardRefreshInterval, _ = time.ParseDuration("1m")
+
+// The cookie that holds the dashboard token once a browser has logged in
+const dashboardCookieName string = "rp-dashboard-token"
+
+// A snapshot of everything the dashboard shows
+type dashboardData struct {
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Network     string         `json:"network"`
+	NodeAddress common.Address `json:"nodeAddress"`
+	Block       uint64         `json:"block"`
+	Slot        uint64         `json:"slot"`
+
+	Node      dashboardNode       `json:"node"`
+	Minipools []dashboardMinipool `json:"minipools"`
+	Rewards   []dashboardInterval `json:"rewards"`
+	Proposals []dashboardProposal `json:"proposals"`
+	Alerts    []api.NodeAlert     `json:"alerts"`
+	Clients   []dashboardClient   `json:"clients"`
+	Warnings  []string            `json:"warnings"`
+}
+type dashboardNode struct {
+	Registered              bool      `json:"registered"`
+	EthBalance              float64   `json:"ethBalance"`
+	RplBalance              float64   `json:"rplBalance"`
+	RplStake                float64   `json:"rplStake"`
+	EffectiveRplStake       float64   `json:"effectiveRplStake"`
+	MinimumRplStake         float64   `json:"minimumRplStake"`
+	RplPrice                float64   `json:"rplPrice"`
+	BorrowedCollateralRatio float64   `json:"borrowedCollateralRatio"`
+	BondedCollateralRatio   float64   `json:"bondedCollateralRatio"`
+	WithdrawalAddress       string    `json:"withdrawalAddress"`
+	FeeDistributorBalance   float64   `json:"feeDistributorBalance"`
+	SmoothingPoolOptedIn    bool      `json:"smoothingPoolOptedIn"`
+	SmoothingPoolChanged    time.Time `json:"smoothingPoolChanged"`
+	SmoothingPoolBalance    float64   `json:"smoothingPoolBalance"`
+}
+type dashboardMinipool struct {
+	Address        common.Address `json:"address"`
+	Status         string         `json:"status"`
+	Finalised      bool           `json:"finalised"`
+	NodeDeposit    float64        `json:"nodeDeposit"`
+	ValidatorIndex string         `json:"validatorIndex"`
+	ValidatorState string         `json:"validatorState"`
+	BeaconBalance  float64        `json:"beaconBalance"`
+}
+type dashboardInterval struct {
+	Index     uint64    `json:"index"`
+	EndTime   time.Time `json:"endTime"`
+	Rpl       float64   `json:"rpl"`
+	Eth       float64   `json:"eth"`
+	Claimed   bool      `json:"claimed"`
+	Available bool      `json:"available"`
+}
+type dashboardProposal struct {
+	ID      uint64    `json:"id"`
+	Message string    `json:"message"`
+	State   string    `json:"state"`
+	EndTime time.Time `json:"endTime"`
+}
+type dashboardClient struct {
+	Name         string  `json:"name"`
+	IsWorking    bool    `json:"isWorking"`
+	IsSynced     bool    `json:"isSynced"`
+	SyncProgress float64 `json:"syncProgress"`
+	Error        string  `json:"error"`
+}
+
+// The web dashboard server
+type dashboardServer struct {
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	ec          *services.ExecutionClientManager
+	bc          *services.BeaconClientManager
+	nodeAddress common.Address
+	stateLocker *collectors.StateLocker
+	tokenPath   string
+	page        *template.Template
+
+	// The latest snapshot and the rewards intervals that don't need to be looked up again
+	lock      *sync.Mutex
+	data      *dashboardData
+	intervals map[uint64]*rprewards.IntervalInfo
+}
+
+// Run the web dashboard server
+func runDashboardServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, nodeAddress common.Address) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Return if the dashboard is disabled
+	if cfg.Smartnode.EnableDashboard.Value != true {
+		return nil
+	}
+
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+
+	// Make sure there's a token to log in with
+	tokenPath := cfg.Smartnode.GetDashboardTokenPath()
+	err = dashboard.CreateTokenFile(tokenPath)
+	if err != nil {
+		return err
+	}
+
+	page, err := template.New("dashboard").Funcs(dashboardTemplateFuncs).Parse(dashboardPageTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing dashboard template: %w", err)
+	}
+
+	server := &dashboardServer{
+		log:         logger,
+		cfg:         cfg,
+		rp:          rp,
+		ec:          ec,
+		bc:          bc,
+		nodeAddress: nodeAddress,
+		stateLocker: stateLocker,
+		tokenPath:   tokenPath,
+		page:        page,
+		lock:        &sync.Mutex{},
+		intervals:   map[uint64]*rprewards.IntervalInfo{},
+	}
+
+	// The metrics exporter uses the default mux, so the dashboard gets its own
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.handlePage)
+	mux.HandleFunc("/api/status", server.handleStatus)
+
+	// Only listen on localhost unless the port is meant to be reachable; in Docker, the port mapping decides that instead
+	listenAddress := "0.0.0.0"
+	if cfg.IsNativeMode && cfg.Smartnode.OpenDashboardPort.Value.(cfgtypes.RPCMode) != cfgtypes.RPC_OpenExternal {
+		listenAddress = "127.0.0.1"
+	}
+	address := fmt.Sprintf("%s:%d", listenAddress, cfg.Smartnode.DashboardPort.Value.(uint16))
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      2 * time.Minute,
+	}
+	logger.Printlnf("Starting web dashboard on %s.", address)
+	err = httpServer.ListenAndServe()
+	if err != nil {
+		return fmt.Errorf("Error running web dashboard: %w", err)
+	}
+
+	return nil
+
+}
+
+// Check a request's token, which can come from the Authorization header, the login cookie, or the link printed by `rocketpool node dashboard`
+func (s *dashboardServer) authorize(w http.ResponseWriter, r *http.Request) bool {
+
+	// The dashboard is read-only
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "The dashboard is read-only.", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found && dashboard.CheckToken(s.tokenPath, token) {
+		return true
+	}
+	if cookie, err := r.Cookie(dashboardCookieName); err == nil && dashboard.CheckToken(s.tokenPath, cookie.Value) {
+		return true
+	}
+
+	// Move a token from the link into a cookie so it doesn't stay in the address bar or the browser history
+	if token := r.URL.Query().Get("token"); token != "" && dashboard.CheckToken(s.tokenPath, token) {
+		http.SetCookie(w, &http.Cookie{
+			Name:     dashboardCookieName,
+			Value:    token,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+		http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+		return false
+	}
+
+	http.Error(w, "Unauthorized. Run `rocketpool node dashboard` on your node to get a link to the dashboard.", http.StatusUnauthorized)
+	return false
+
+}
+
+// Serve the dashboard page
+func (s *dashboardServer) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if !s.authorize(w, r) {
+		return
+	}
+
+	data, err := s.getData()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.page.Execute(w, data); err != nil {
+		s.log.Printlnf("Error rendering dashboard: %s", err.Error())
+	}
+}
+
+// Serve the dashboard's data as JSON
+func (s *dashboardServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r) {
+		return
+	}
+
+	data, err := s.getData()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(bytes)
+}
+
+// Get the latest snapshot, rebuilding it if it's stale
+func (s *dashboardServer) getData() (*dashboardData, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.data != nil && time.Since(s.data.UpdatedAt) < dashboardRefreshInterval {
+		return s.data, nil
+	}
+	networkState := s.stateLocker.GetState()
+	if networkState == nil {
+		return nil, fmt.Errorf("The node daemon hasn't loaded the network state yet; please try again in a few minutes.")
+	}
+	s.data = s.buildData(networkState)
+	return s.data, nil
+}
+
+// Build a snapshot from the latest network state. Sections that fail to load are reported as warnings instead of failing the whole page.
+func (s *dashboardServer) buildData(networkState *state.NetworkState) *dashboardData {
+
+	data := &dashboardData{
+		UpdatedAt:   time.Now(),
+		Network:     string(s.cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		NodeAddress: s.nodeAddress,
+		Block:       networkState.ElBlockNumber,
+		Slot:        networkState.BeaconSlotNumber,
+		Minipools:   []dashboardMinipool{},
+		Rewards:     []dashboardInterval{},
+		Proposals:   []dashboardProposal{},
+		Alerts:      []api.NodeAlert{},
+		Warnings:    []string{},
+	}
+
+	// Node status
+	rplPrice := eth.WeiToEth(networkState.NetworkDetails.RplPrice)
+	nd, exists := networkState.NodeDetailsByAddress[s.nodeAddress]
+	if exists && nd.Exists {
+		data.Node = dashboardNode{
+			Registered:            true,
+			EthBalance:            eth.WeiToEth(nd.BalanceETH),
+			RplBalance:            eth.WeiToEth(nd.BalanceRPL),
+			RplStake:              eth.WeiToEth(nd.RplStake),
+			EffectiveRplStake:     eth.WeiToEth(nd.EffectiveRPLStake),
+			MinimumRplStake:       eth.WeiToEth(nd.MinimumRPLStake),
+			RplPrice:              rplPrice,
+			WithdrawalAddress:     nd.WithdrawalAddress.Hex(),
+			FeeDistributorBalance: eth.WeiToEth(nd.DistributorBalance),
+			SmoothingPoolOptedIn:  nd.SmoothingPoolRegistrationState,
+			SmoothingPoolBalance:  eth.WeiToEth(networkState.NetworkDetails.SmoothingPoolBalance),
+		}
+		if nd.SmoothingPoolRegistrationChanged != nil && nd.SmoothingPoolRegistrationChanged.Sign() > 0 {
+			data.Node.SmoothingPoolChanged = time.Unix(nd.SmoothingPoolRegistrationChanged.Int64(), 0)
+		}
+	}
+
+	// Minipools and their validators
+	bondedEth := big.NewInt(0)
+	borrowedEth := big.NewInt(0)
+	for _, mpd := range networkState.MinipoolDetailsByNode[s.nodeAddress] {
+		minipool := dashboardMinipool{
+			Address:     mpd.MinipoolAddress,
+			Status:      rptypes.MinipoolStatus(mpd.StatusRaw).String(),
+			Finalised:   mpd.Finalised,
+			NodeDeposit: eth.WeiToEth(mpd.NodeDepositBalance),
+		}
+		validator, exists := networkState.ValidatorDetails[mpd.Pubkey]
+		if exists && validator.Exists {
+			minipool.ValidatorIndex = validator.Index
+			minipool.ValidatorState = string(validator.Status)
+			minipool.BeaconBalance = eth.WeiToEth(eth.GweiToWei(float64(validator.Balance)))
+		}
+		data.Minipools = append(data.Minipools, minipool)
+
+		if !mpd.Finalised {
+			bondedEth.Add(bondedEth, mpd.NodeDepositBalance)
+			borrowedEth.Add(borrowedEth, big.NewInt(0).Sub(eth.EthToWei(32), mpd.NodeDepositBalance))
+		}
+	}
+	if bondedEth.Sign() > 0 {
+		data.Node.BondedCollateralRatio = rplPrice * data.Node.RplStake / eth.WeiToEth(bondedEth)
+	}
+	if borrowedEth.Sign() > 0 {
+		data.Node.BorrowedCollateralRatio = rplPrice * data.Node.RplStake / eth.WeiToEth(borrowedEth)
+	}
+
+	// Rewards
+	if data.Node.Registered {
+		rewards, err := s.getRewards()
+		if err != nil {
+			data.Warnings = append(data.Warnings, fmt.Sprintf("Error getting rewards: %s", err.Error()))
+		} else {
+			data.Rewards = rewards
+		}
+	}
+
+	// Pending Protocol DAO proposals
+	for _, prop := range networkState.ProtocolDaoProposalDetails {
+		var endTime time.Time
+		switch prop.State {
+		case rptypes.ProtocolDaoProposalState_Pending:
+			endTime = prop.VotingStartTime
+		case rptypes.ProtocolDaoProposalState_ActivePhase1:
+			endTime = prop.Phase1EndTime
+		case rptypes.ProtocolDaoProposalState_ActivePhase2:
+			endTime = prop.Phase2EndTime
+		case rptypes.ProtocolDaoProposalState_Succeeded:
+			endTime = prop.ExpiryTime
+		default:
+			continue
+		}
+		data.Proposals = append(data.Proposals, dashboardProposal{
+			ID:      prop.ID,
+			Message: prop.Message,
+			State:   rptypes.ProtocolDaoProposalStates[prop.State],
+			EndTime: endTime,
+		})
+	}
+
+	// Active alerts
+	alerts, err := alerting.FetchAlerts(s.cfg)
+	if err != nil {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("Error fetching alerts from Alertmanager: %s", err.Error()))
+	}
+	for _, alert := range alerts {
+		nodeAlert := api.NodeAlert{
+			State:       *alert.Status.State,
+			Labels:      alert.Labels,
+			Annotations: alert.Annotations,
+		}
+		if nodeAlert.IsActive() {
+			data.Alerts = append(data.Alerts, nodeAlert)
+		}
+	}
+
+	// Client health
+	ecStatus := s.ec.CheckStatus(s.cfg)
+	bcStatus := s.bc.CheckStatus()
+	data.Clients = append(data.Clients, newDashboardClient("Primary Execution Client", ecStatus.PrimaryClientStatus))
+	if ecStatus.FallbackEnabled {
+		data.Clients = append(data.Clients, newDashboardClient("Fallback Execution Client", ecStatus.FallbackClientStatus))
+	}
+	data.Clients = append(data.Clients, newDashboardClient("Primary Consensus Client", bcStatus.PrimaryClientStatus))
+	if bcStatus.FallbackEnabled {
+		data.Clients = append(data.Clients, newDashboardClient("Fallback Consensus Client", bcStatus.FallbackClientStatus))
+	}
+
+	return data
+
+}
+
+// Get the node's rewards for each interval, newest first.
+// Interval info is cached once its rewards file has been downloaded, since it never changes after that.
+func (s *dashboardServer) getRewards() ([]dashboardInterval, error) {
+
+	unclaimed, claimed, err := rprewards.GetClaimStatus(s.rp, s.nodeAddress)
+	if err != nil {
+		return nil, err
+	}
+	claimedMap := map[uint64]bool{}
+	for _, interval := range claimed {
+		claimedMap[interval] = true
+	}
+
+	rewards := []dashboardInterval{}
+	for _, interval := range append(claimed, unclaimed...) {
+		info, exists := s.intervals[interval]
+		if !exists {
+			intervalInfo, err := rprewards.GetIntervalInfo(s.rp, s.cfg, s.nodeAddress, interval, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting info for rewards interval %d: %w", interval, err)
+			}
+			info = &intervalInfo
+			if intervalInfo.TreeFileExists {
+				s.intervals[interval] = info
+			}
+		}
+		if info.TreeFileExists && !info.NodeExists {
+			continue
+		}
+
+		reward := dashboardInterval{
+			Index:     interval,
+			EndTime:   info.EndTime,
+			Claimed:   claimedMap[interval],
+			Available: info.TreeFileExists,
+		}
+		if info.TreeFileExists {
+			reward.Rpl = eth.WeiToEth(&info.CollateralRplAmount.Int)
+			reward.Eth = eth.WeiToEth(&info.SmoothingPoolEthAmount.Int)
+		}
+		rewards = append(rewards, reward)
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Index > rewards[j].Index
+	})
+	return rewards, nil
+
+}
+
+// Summarize a client's status for the dashboard
+func newDashboardClient(name string, status api.ClientStatus) dashboardClient {
+	return dashboardClient{
+		Name:         name,
+		IsWorking:    status.IsWorking,
+		IsSynced:     status.IsSynced,
+		SyncProgress: status.SyncProgress,
+		Error:        status.Error,
+	}
+}
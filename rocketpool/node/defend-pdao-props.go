@@ -17,6 +17,7 @@ import (
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/proposals"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -34,6 +35,7 @@ type defendPdaoProps struct {
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
 	rp               *rocketpool.RocketPool
+	txm              *txmanager.Manager
 	bc               beacon.Client
 	gasThreshold     float64
 	maxFee           *big.Int
@@ -61,6 +63,10 @@ func newDefendPdaoProps(c *cli.Context, logger log.ColorLogger) (*defendPdaoProp
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -106,6 +112,7 @@ func newDefendPdaoProps(c *cli.Context, logger log.ColorLogger) (*defendPdaoProp
 		cfg:              cfg,
 		w:                w,
 		rp:               rp,
+		txm:              txm,
 		bc:               bc,
 		gasThreshold:     gasThreshold,
 		maxFee:           maxFee,
@@ -278,7 +285,7 @@ func (t *defendPdaoProps) defendProposal(prop defendableProposal) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, t.log)
 	if err != nil {
 		return err
 	}
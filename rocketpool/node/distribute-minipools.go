@@ -20,6 +20,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -32,6 +33,7 @@ type distributeMinipools struct {
 	cfg                 *config.RocketPoolConfig
 	w                   *wallet.Wallet
 	rp                  *rocketpool.RocketPool
+	txm                 *txmanager.Manager
 	bc                  beacon.Client
 	d                   *client.Client
 	gasThreshold        float64
@@ -59,6 +61,10 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -112,6 +118,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		cfg:                 cfg,
 		w:                   w,
 		rp:                  rp,
+		txm:                 txm,
 		bc:                  bc,
 		d:                   d,
 		gasThreshold:        gasThreshold,
@@ -264,7 +271,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return false, err
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -29,6 +30,7 @@ type manageFeeRecipient struct {
 	rp  *rocketpool.RocketPool
 	d   *client.Client
 	bc  beacon.Client
+	km  *keymanager.Client
 }
 
 // Create manage fee recipient task
@@ -55,6 +57,10 @@ func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRe
 	if err != nil {
 		return nil, err
 	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	return &manageFeeRecipient{
@@ -65,6 +71,7 @@ func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRe
 		rp:  rp,
 		d:   d,
 		bc:  bc,
+		km:  km,
 	}, nil
 
 }
@@ -130,15 +137,15 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return nil
 	}
 
-	// Restart the VC
-	m.log.Println("Fee recipient files updated successfully! Restarting validator client...")
-	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
+	// Update the VC
+	m.log.Println("Fee recipient files updated successfully! Updating validator client...")
+	err = validator.ApplyFeeRecipient(m.cfg, m.km, correctFeeRecipient, m.bc, &m.log, m.d)
 	if err != nil {
-		return fmt.Errorf("error restarting validator client: %w", err)
+		return fmt.Errorf("error updating validator client: %w", err)
 	}
 
 	// Log & return
-	m.log.Println("Successfully restarted, you are now validating safely.")
+	m.log.Println("Successfully updated, you are now validating safely.")
 	return nil
 
 }
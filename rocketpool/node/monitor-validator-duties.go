@@ -0,0 +1,435 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// A validator belonging to the node, along with its Beacon Chain status
+type monitoredValidator struct {
+	minipool *rpstate.NativeMinipoolDetails
+	status   beacon.ValidatorStatus
+}
+
+// Monitor validator duties task
+type monitorValidatorDuties struct {
+	c                        *cli.Context
+	log                      log.ColorLogger
+	cfg                      *config.RocketPoolConfig
+	w                        *wallet.Wallet
+	bc                       beacon.Client
+	missedAttestationsWindow uint64
+	lowBalanceThreshold      float64
+
+	// Attestation tracking
+	hasCheckedEpochs        bool
+	lastCheckedEpoch        uint64
+	consecutiveMissedEpochs map[string]uint64
+	missedAttestationAlerts map[string]bool
+
+	// Proposal tracking, since proposer duties can only be queried for the current and next epochs
+	scheduledProposals map[uint64]map[string]uint64
+
+	// Sync committee tracking
+	lastSyncPeriodChecked uint64
+
+	// Balance tracking
+	lowBalanceAlerted bool
+}
+
+// Create monitor validator duties task
+func newMonitorValidatorDuties(c *cli.Context, logger log.ColorLogger) (*monitorValidatorDuties, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the settings
+	window := cfg.Alertmanager.MissedAttestationsWindow.Value.(uint64)
+	if window == 0 {
+		logger.Println("Missed attestations window is 0, using 1 epoch instead.")
+		window = 1
+	}
+
+	// Return task
+	return &monitorValidatorDuties{
+		c:                        c,
+		log:                      logger,
+		cfg:                      cfg,
+		w:                        w,
+		bc:                       bc,
+		missedAttestationsWindow: window,
+		lowBalanceThreshold:      cfg.Alertmanager.LowNodeBalanceThreshold.Value.(float64),
+		consecutiveMissedEpochs:  map[string]uint64{},
+		missedAttestationAlerts:  map[string]bool{},
+		scheduledProposals:       map[uint64]map[string]uint64{},
+	}, nil
+
+}
+
+// Check the node's validators for missed duties and the node wallet for a low balance
+func (t *monitorValidatorDuties) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check the node wallet balance
+	t.checkNodeBalance(state, nodeAccount.Address)
+
+	// Get the node's validators that exist on the Beacon Chain
+	validators := map[string]*monitoredValidator{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		validators[status.Index] = &monitoredValidator{
+			minipool: mpd,
+			status:   status,
+		}
+	}
+	if len(validators) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking validator duties...")
+
+	// Check for upcoming sync committee duties
+	if t.cfg.Alertmanager.AlertEnabled_SyncCommitteeDuty.Value == true {
+		err = t.checkSyncCommittees(state, validators)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't check sync committee duties: %s", err.Error())
+		}
+	}
+
+	// Check for missed attestations and proposals
+	if t.cfg.Alertmanager.AlertEnabled_MissedAttestations.Value == true || t.cfg.Alertmanager.AlertEnabled_MissedProposal.Value == true {
+		err = t.recordProposalDuties(state, validators)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't get proposal duties: %s", err.Error())
+		}
+		err = t.checkRecentEpochs(state, validators)
+		if err != nil {
+			return fmt.Errorf("error checking validator duties: %w", err)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Alert if the node wallet balance dropped below the threshold
+func (t *monitorValidatorDuties) checkNodeBalance(state *state.NetworkState, nodeAddress common.Address) {
+
+	if t.lowBalanceThreshold == 0 {
+		return
+	}
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAddress]
+	if !exists || nodeDetails.BalanceETH == nil {
+		return
+	}
+
+	// Only alert once each time the balance drops below the threshold
+	balance := eth.WeiToEth(nodeDetails.BalanceETH)
+	if balance >= t.lowBalanceThreshold {
+		t.lowBalanceAlerted = false
+		return
+	}
+	if t.lowBalanceAlerted {
+		return
+	}
+
+	t.log.Printlnf("WARNING: node wallet balance is %.6f ETH, which is below the low balance threshold of %.6f ETH.", balance, t.lowBalanceThreshold)
+	alerting.AlertLowNodeBalance(t.cfg, nodeAddress, balance, t.lowBalanceThreshold)
+	t.lowBalanceAlerted = true
+
+}
+
+// Alert if any validators are in the next sync committee
+func (t *monitorValidatorDuties) checkSyncCommittees(state *state.NetworkState, validators map[string]*monitoredValidator) error {
+
+	// Only check each sync committee period once
+	epochsPerPeriod := state.BeaconConfig.EpochsPerSyncCommitteePeriod
+	currentEpoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	nextPeriod := currentEpoch/epochsPerPeriod + 1
+	if t.lastSyncPeriodChecked >= nextPeriod {
+		return nil
+	}
+
+	// Get the sync duties for the next period
+	startEpoch := nextPeriod * epochsPerPeriod
+	endEpoch := startEpoch + epochsPerPeriod - 1
+	duties, err := t.bc.GetValidatorSyncDuties(getValidatorIndices(validators), startEpoch)
+	if err != nil {
+		return err
+	}
+	for index, inCommittee := range duties {
+		if !inCommittee {
+			continue
+		}
+		validator := validators[index]
+		t.log.Printlnf("Validator %s (minipool %s) will be in the sync committee from epoch %d to epoch %d.", index, validator.minipool.MinipoolAddress.Hex(), startEpoch, endEpoch)
+		alerting.AlertSyncCommitteeDuty(t.cfg, validator.minipool.MinipoolAddress, index, startEpoch, endEpoch)
+	}
+
+	t.lastSyncPeriodChecked = nextPeriod
+	return nil
+
+}
+
+// Record the proposal duties of the validators for the current and next epochs so they can be checked once the epochs are complete
+func (t *monitorValidatorDuties) recordProposalDuties(state *state.NetworkState, validators map[string]*monitoredValidator) error {
+
+	currentEpoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	indices := getValidatorIndices(validators)
+	for epoch := currentEpoch; epoch <= currentEpoch+1; epoch++ {
+		if _, exists := t.scheduledProposals[epoch]; exists {
+			continue
+		}
+		duties, err := t.bc.GetValidatorProposerDuties(indices, epoch)
+		if err != nil {
+			return err
+		}
+		scheduled := map[string]uint64{}
+		for index, count := range duties {
+			if count > 0 {
+				scheduled[index] = count
+			}
+		}
+		t.scheduledProposals[epoch] = scheduled
+	}
+	return nil
+
+}
+
+// Check all of the completed epochs since the last run for missed attestations and proposals
+func (t *monitorValidatorDuties) checkRecentEpochs(state *state.NetworkState, validators map[string]*monitoredValidator) error {
+
+	// Attestations can be included up to the end of the following epoch, so only check epochs that are at least 2 behind the head
+	currentEpoch := state.BeaconSlotNumber / state.BeaconConfig.SlotsPerEpoch
+	if currentEpoch < 2 {
+		return nil
+	}
+	targetEpoch := currentEpoch - 2
+
+	// Start after the last checked epoch, or start over if the daemon fell too far behind
+	var startEpoch uint64
+	if t.hasCheckedEpochs && t.lastCheckedEpoch <= targetEpoch && targetEpoch-t.lastCheckedEpoch <= t.missedAttestationsWindow {
+		startEpoch = t.lastCheckedEpoch + 1
+	} else {
+		t.consecutiveMissedEpochs = map[string]uint64{}
+		startEpoch = 0
+		if targetEpoch+1 > t.missedAttestationsWindow {
+			startEpoch = targetEpoch + 1 - t.missedAttestationsWindow
+		}
+	}
+	if startEpoch > targetEpoch {
+		return nil
+	}
+
+	// Process each epoch
+	blocks := map[uint64]*beacon.BeaconBlock{}
+	for epoch := startEpoch; epoch <= targetEpoch; epoch++ {
+		err := t.checkEpoch(state, validators, epoch, blocks)
+		if err != nil {
+			return fmt.Errorf("error checking epoch %d: %w", epoch, err)
+		}
+		t.lastCheckedEpoch = epoch
+		t.hasCheckedEpochs = true
+	}
+
+	// Prune the proposal duties for epochs that have been checked
+	for epoch := range t.scheduledProposals {
+		if epoch <= t.lastCheckedEpoch {
+			delete(t.scheduledProposals, epoch)
+		}
+	}
+
+	// Alert for validators that have missed the whole window
+	missedValidators := []string{}
+	for index, missedEpochs := range t.consecutiveMissedEpochs {
+		if missedEpochs >= t.missedAttestationsWindow && !t.missedAttestationAlerts[index] {
+			missedValidators = append(missedValidators, index)
+			t.missedAttestationAlerts[index] = true
+		}
+	}
+	if len(missedValidators) > 0 {
+		t.log.Printlnf("WARNING: %d validator(s) missed all of their attestations for the last %d epochs: %v", len(missedValidators), t.missedAttestationsWindow, missedValidators)
+		if t.cfg.Alertmanager.AlertEnabled_MissedAttestations.Value == true {
+			alerting.AlertMissedAttestations(t.cfg, missedValidators, targetEpoch+1-t.missedAttestationsWindow, targetEpoch)
+		}
+	}
+
+	return nil
+
+}
+
+// Check a single epoch for missed attestations and proposals
+func (t *monitorValidatorDuties) checkEpoch(state *state.NetworkState, validators map[string]*monitoredValidator, epoch uint64, blocks map[uint64]*beacon.BeaconBlock) error {
+
+	slotsPerEpoch := state.BeaconConfig.SlotsPerEpoch
+
+	// Get the blocks that can include attestations for this epoch
+	err := t.getBlocks(epoch*slotsPerEpoch, (epoch+2)*slotsPerEpoch, blocks)
+	if err != nil {
+		return err
+	}
+
+	// Get the committee positions of the validators that were active during this epoch
+	committees, err := t.bc.GetCommitteesForEpoch(&epoch)
+	if err != nil {
+		return fmt.Errorf("error getting committees: %w", err)
+	}
+	defer committees.Release()
+
+	positions := map[uint64]map[uint64]map[int]string{}
+	for idx := 0; idx < committees.Count(); idx++ {
+		slot := committees.Slot(idx)
+		committeeIndex := committees.Index(idx)
+		for position, index := range committees.Validators(idx) {
+			validator, exists := validators[index]
+			if !exists || validator.status.ActivationEpoch > epoch || validator.status.ExitEpoch <= epoch {
+				continue
+			}
+			committeesForSlot, exists := positions[slot]
+			if !exists {
+				committeesForSlot = map[uint64]map[int]string{}
+				positions[slot] = committeesForSlot
+			}
+			positionsForCommittee, exists := committeesForSlot[committeeIndex]
+			if !exists {
+				positionsForCommittee = map[int]string{}
+				committeesForSlot[committeeIndex] = positionsForCommittee
+			}
+			positionsForCommittee[position] = index
+		}
+	}
+
+	// Check which validators had their attestations included
+	attested := map[string]bool{}
+	for slot := epoch * slotsPerEpoch; slot < (epoch+2)*slotsPerEpoch; slot++ {
+		block := blocks[slot]
+		if block == nil {
+			continue
+		}
+		for _, attestation := range block.Attestations {
+			positionsForCommittee, exists := positions[attestation.SlotIndex][attestation.CommitteeIndex]
+			if !exists {
+				continue
+			}
+			for position, index := range positionsForCommittee {
+				if attestation.AggregationBits.BitAt(uint64(position)) {
+					attested[index] = true
+				}
+			}
+		}
+	}
+
+	// Update the consecutive missed epoch counters
+	for _, committeesForSlot := range positions {
+		for _, positionsForCommittee := range committeesForSlot {
+			for _, index := range positionsForCommittee {
+				if attested[index] {
+					delete(t.consecutiveMissedEpochs, index)
+					delete(t.missedAttestationAlerts, index)
+				} else {
+					t.consecutiveMissedEpochs[index]++
+				}
+			}
+		}
+	}
+
+	// Check the scheduled proposals against the blocks in the canonical chain
+	scheduled, exists := t.scheduledProposals[epoch]
+	if !exists || len(scheduled) == 0 {
+		return nil
+	}
+	proposed := map[string]uint64{}
+	for slot := epoch * slotsPerEpoch; slot < (epoch+1)*slotsPerEpoch; slot++ {
+		block := blocks[slot]
+		if block != nil {
+			proposed[block.ProposerIndex]++
+		}
+	}
+	for index, count := range scheduled {
+		if proposed[index] >= count {
+			continue
+		}
+		missedCount := count - proposed[index]
+		validator := validators[index]
+		t.log.Printlnf("WARNING: validator %s (minipool %s) missed %d proposal(s) in epoch %d.", index, validator.minipool.MinipoolAddress.Hex(), missedCount, epoch)
+		if t.cfg.Alertmanager.AlertEnabled_MissedProposal.Value == true {
+			alerting.AlertMissedProposal(t.cfg, validator.minipool.MinipoolAddress, index, epoch, missedCount)
+		}
+	}
+
+	return nil
+
+}
+
+// Get the blocks for the provided slot range, skipping any that have already been retrieved
+func (t *monitorValidatorDuties) getBlocks(startSlot uint64, endSlot uint64, blocks map[uint64]*beacon.BeaconBlock) error {
+
+	var lock sync.Mutex
+	var wg errgroup.Group
+	for slot := startSlot; slot < endSlot; slot++ {
+		if _, exists := blocks[slot]; exists {
+			continue
+		}
+		slot := slot
+		wg.Go(func() error {
+			block, found, err := t.bc.GetBeaconBlock(fmt.Sprint(slot))
+			if err != nil {
+				return fmt.Errorf("error getting block for slot %d: %w", slot, err)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if found {
+				blocks[slot] = &block
+			} else {
+				blocks[slot] = nil
+			}
+			return nil
+		})
+	}
+	return wg.Wait()
+
+}
+
+// Get the indices of a collection of validators
+func getValidatorIndices(validators map[string]*monitoredValidator) []string {
+	indices := make([]string, 0, len(validators))
+	for index := range validators {
+		indices = append(indices, index)
+	}
+	return indices
+}
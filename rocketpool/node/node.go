@@ -16,6 +16,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
@@ -34,6 +35,7 @@ const (
 
 	StakePrelaunchMinipoolsColor = color.FgBlue
 	DownloadRewardsTreesColor    = color.FgGreen
+	AutoClaimRewardsColor        = color.FgHiGreen
 	MetricsColor                 = color.FgHiYellow
 	ManageFeeRecipientColor      = color.FgHiCyan
 	PromoteMinipoolsColor        = color.FgMagenta
@@ -42,6 +44,9 @@ const (
 	VerifyPdaoPropsColor         = color.FgYellow
 	AutoInitVotingPowerColor     = color.FgHiYellow
 	DistributeMinipoolsColor     = color.FgHiGreen
+	MonitorValidatorDutiesColor  = color.FgHiMagenta
+	RecordHistoryColor           = color.FgCyan
+	DashboardColor               = color.FgHiBlue
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -74,14 +79,37 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Create the token for the VC's keymanager API
+	err = deployKeymanagerTokenFile(c)
+	if err != nil {
+		return err
+	}
+
 	// Configure
 	configureHTTP()
 
+	// Listen for the wallet password if it isn't saved to disk
+	err = services.ServeWalletUnlock(c, "node", log.NewColorLogger(UpdateColor))
+	if err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
 	}
 
+	// Resume waiting on the transactions that were pending when the daemon stopped
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return err
+	}
+	txLog := log.NewColorLogger(UpdateColor)
+	err = txm.Start("node", &txLog)
+	if err != nil {
+		return err
+	}
+
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -144,10 +172,22 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	autoClaimRewards, err := newAutoClaimRewards(c, log.NewColorLogger(AutoClaimRewardsColor))
+	if err != nil {
+		return err
+	}
 	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
 	if err != nil {
 		return err
 	}
+	monitorValidatorDuties, err := newMonitorValidatorDuties(c, log.NewColorLogger(MonitorValidatorDutiesColor))
+	if err != nil {
+		return err
+	}
+	recordHistory, err := newRecordHistory(c, log.NewColorLogger(RecordHistoryColor), nodeAccount.Address)
+	if err != nil {
+		return err
+	}
 	defendPdaoProps, err := newDefendPdaoProps(c, log.NewColorLogger(DefendPdaoPropsColor))
 	if err != nil {
 		return err
@@ -173,7 +213,7 @@ func run(c *cli.Context) error {
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(3)
 
 	// Timestamp for caching total effective RPL stake
 	lastTotalEffectiveStakeTime := time.Unix(0, 0)
@@ -241,6 +281,12 @@ func run(c *cli.Context) error {
 			}
 			time.Sleep(taskCooldown)
 
+			// Run the rewards auto-claim check
+			if err := autoClaimRewards.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
 			// Run the pDAO proposal defender
 			if err := defendPdaoProps.run(state); err != nil {
 				errorLog.Println(err)
@@ -285,6 +331,18 @@ func run(c *cli.Context) error {
 			if err := promoteMinipools.run(state); err != nil {
 				errorLog.Println(err)
 			}
+			time.Sleep(taskCooldown)
+
+			// Run the validator duty monitor
+			if err := monitorValidatorDuties.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Record the node's history
+			if err := recordHistory.run(state); err != nil {
+				errorLog.Println(err)
+			}
 
 			time.Sleep(tasksInterval)
 		}
@@ -300,7 +358,16 @@ func run(c *cli.Context) error {
 		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Run the web dashboard
+	go func() {
+		err := runDashboardServer(c, log.NewColorLogger(DashboardColor), stateLocker, nodeAccount.Address)
+		if err != nil {
+			errorLog.Println(err)
+		}
+		wg.Done()
+	}()
+
+	// Wait for all threads to stop
 	wg.Wait()
 	return nil
 
@@ -355,6 +422,21 @@ func deployDefaultFeeRecipientFile(c *cli.Context) error {
 
 }
 
+// Create the bearer token for the VC's keymanager API if the VC hasn't already made one
+func deployKeymanagerTokenFile(c *cli.Context) error {
+
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if cfg.GetVcKeymanagerUrl() == "" {
+		return nil
+	}
+
+	return keymanager.CreateTokenFile(os.ExpandEnv(cfg.Smartnode.GetKeymanagerTokenPath()))
+
+}
+
 // Remove the old fee recipient files that were created in v1.5.0
 func removeLegacyFeeRecipientFiles(c *cli.Context) error {
 
@@ -0,0 +1,264 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often old history is pruned
+var historyPruneInterval, _ = time.ParseDuration("24h")
+
+// Record history task
+type recordHistory struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	bc          *services.BeaconClientManager
+	nodeAddress common.Address
+	store       *history.Store
+	lastPrune   time.Time
+
+	// The node's rewards for each interval with a rewards file, which don't change once it's been read
+	intervalRewards map[uint64]*intervalRewards
+
+	// The rewards of intervals that have already been claimed, which don't need to be looked up again
+	handledIntervals map[uint64]bool
+	claimedRpl       *big.Int
+	claimedEth       *big.Int
+}
+
+// The node's rewards for a single interval
+type intervalRewards struct {
+	rpl *big.Int
+	eth *big.Int
+}
+
+// Create record history task
+func newRecordHistory(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address) (*recordHistory, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &recordHistory{
+		c:                c,
+		log:              logger,
+		cfg:              cfg,
+		rp:               rp,
+		bc:               bc,
+		nodeAddress:      nodeAddress,
+		store:            history.NewStore(cfg.Smartnode.GetHistoryPath()),
+		intervalRewards:  map[uint64]*intervalRewards{},
+		handledIntervals: map[uint64]bool{},
+		claimedRpl:       big.NewInt(0),
+		claimedEth:       big.NewInt(0),
+	}, nil
+
+}
+
+// Record a sample of the node's metrics
+func (t *recordHistory) run(state *state.NetworkState) error {
+
+	// Check if history is enabled
+	retentionDays := t.cfg.Smartnode.HistoryRetentionDays.Value.(uint64)
+	if retentionDays == 0 {
+		return nil
+	}
+
+	// Get the sample
+	sample, err := t.getSample(state)
+	if err != nil {
+		return fmt.Errorf("error recording node history: %w", err)
+	}
+	if err := t.store.Append(sample); err != nil {
+		return fmt.Errorf("error recording node history: %w", err)
+	}
+
+	// Prune old samples once a day
+	if time.Since(t.lastPrune) > historyPruneInterval {
+		if err := t.store.Prune(time.Duration(retentionDays)*24*time.Hour, time.Now()); err != nil {
+			return fmt.Errorf("error pruning node history: %w", err)
+		}
+		t.lastPrune = time.Now()
+	}
+
+	return nil
+
+}
+
+// Build a sample from the network state
+func (t *recordHistory) getSample(state *state.NetworkState) (history.Sample, error) {
+
+	nd := state.NodeDetailsByAddress[t.nodeAddress]
+	minipools := state.MinipoolDetailsByNode[t.nodeAddress]
+	rplPrice := eth.WeiToEth(state.NetworkDetails.RplPrice)
+	stakedRpl := eth.WeiToEth(nd.RplStake)
+
+	// Get the time of the state's slot
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	secondsSinceGenesis := time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second
+	sample := history.Sample{
+		Time:              genesisTime.Add(secondsSinceGenesis).UTC(),
+		Block:             state.ElBlockNumber,
+		RplStake:          stakedRpl,
+		EffectiveRplStake: eth.WeiToEth(nd.EffectiveRPLStake),
+		RplPrice:          rplPrice,
+		Minipools:         []history.MinipoolSample{},
+	}
+
+	// Get the collateral ratios of the node's active minipools
+	bondedEth := big.NewInt(0)
+	borrowedEth := big.NewInt(0)
+	for _, mpd := range minipools {
+		if mpd.Finalised {
+			continue
+		}
+		bondedEth.Add(bondedEth, mpd.NodeDepositBalance)
+		borrowedEth.Add(borrowedEth, big.NewInt(0).Sub(eth.EthToWei(32), mpd.NodeDepositBalance))
+	}
+	if bondedEth.Sign() > 0 {
+		sample.BondedCollateralRatio = rplPrice * stakedRpl / eth.WeiToEth(bondedEth)
+	}
+	if borrowedEth.Sign() > 0 {
+		sample.BorrowedCollateralRatio = rplPrice * stakedRpl / eth.WeiToEth(borrowedEth)
+	}
+
+	// Get the Beacon Chain balances
+	beaconHead, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return history.Sample{}, fmt.Errorf("error getting beacon chain head: %w", err)
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	balances, err := eth2.GetBeaconBalancesFromState(t.rp, minipools, state, beaconHead, opts)
+	if err != nil {
+		return history.Sample{}, fmt.Errorf("error getting minipool beacon balances: %w", err)
+	}
+	for i, mpd := range minipools {
+		if mpd.Finalised {
+			continue
+		}
+		minipoolSample := history.MinipoolSample{
+			Address:       mpd.MinipoolAddress,
+			BeaconBalance: eth.WeiToEth(balances[i].TotalBalance),
+			NodeShare:     eth.WeiToEth(balances[i].NodeBalance),
+		}
+		sample.BeaconBalance += minipoolSample.BeaconBalance
+		sample.BeaconShare += minipoolSample.NodeShare
+		sample.Minipools = append(sample.Minipools, minipoolSample)
+	}
+
+	// Get the claimed and unclaimed rewards
+	unclaimedRpl, unclaimedEth, err := t.getRewards()
+	if err != nil {
+		return history.Sample{}, err
+	}
+	sample.ClaimedRplRewards = eth.WeiToEth(t.claimedRpl)
+	sample.ClaimedEthRewards = eth.WeiToEth(t.claimedEth)
+	sample.UnclaimedRplRewards = eth.WeiToEth(unclaimedRpl)
+	sample.UnclaimedEthRewards = eth.WeiToEth(unclaimedEth)
+
+	return sample, nil
+
+}
+
+// Update the node's claimed rewards and get its unclaimed ones.
+// Intervals without a rewards file are skipped until the file is downloaded.
+func (t *recordHistory) getRewards() (*big.Int, *big.Int, error) {
+
+	unclaimed, claimed, err := rprewards.GetClaimStatus(t.rp, t.nodeAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	for _, interval := range claimed {
+		if t.handledIntervals[interval] {
+			continue
+		}
+		rewards, err := t.getIntervalRewards(interval)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rewards == nil {
+			continue
+		}
+		t.claimedRpl.Add(t.claimedRpl, rewards.rpl)
+		t.claimedEth.Add(t.claimedEth, rewards.eth)
+		t.handledIntervals[interval] = true
+		delete(t.intervalRewards, interval)
+	}
+
+	unclaimedRpl := big.NewInt(0)
+	unclaimedEth := big.NewInt(0)
+	for _, interval := range unclaimed {
+		rewards, err := t.getIntervalRewards(interval)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rewards == nil {
+			continue
+		}
+		unclaimedRpl.Add(unclaimedRpl, rewards.rpl)
+		unclaimedEth.Add(unclaimedEth, rewards.eth)
+	}
+
+	return unclaimedRpl, unclaimedEth, nil
+
+}
+
+// Get the node's rewards for an interval, or nil if its rewards file hasn't been downloaded yet
+func (t *recordHistory) getIntervalRewards(interval uint64) (*intervalRewards, error) {
+
+	if rewards, exists := t.intervalRewards[interval]; exists {
+		return rewards, nil
+	}
+
+	intervalInfo, err := rprewards.GetIntervalInfo(t.rp, t.cfg, t.nodeAddress, interval, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting info for rewards interval %d: %w", interval, err)
+	}
+	if !intervalInfo.TreeFileExists {
+		return nil, nil
+	}
+
+	// The RPL rewards include both the collateral and Oracle DAO rewards
+	rewards := &intervalRewards{
+		rpl: big.NewInt(0),
+		eth: big.NewInt(0),
+	}
+	if intervalInfo.NodeExists {
+		rewards.rpl.Add(&intervalInfo.CollateralRplAmount.Int, &intervalInfo.ODaoRplAmount.Int)
+		rewards.eth.Set(&intervalInfo.SmoothingPoolEthAmount.Int)
+	}
+	t.intervalRewards[interval] = rewards
+	return rewards, nil
+
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -35,6 +36,7 @@ type reduceBonds struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *txmanager.Manager
 	d              *client.Client
 	gasThreshold   float64
 	disabled       bool
@@ -68,6 +70,10 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	d, err := services.GetDocker(c)
 	if err != nil {
 		return nil, err
@@ -107,6 +113,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		d:              d,
 		gasThreshold:   gasThreshold,
 		disabled:       disabled,
@@ -292,7 +299,7 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return false, err
 	}
@@ -410,7 +417,7 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return false, err
 	}
@@ -21,10 +21,13 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
@@ -35,8 +38,10 @@ type stakePrelaunchMinipools struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *txmanager.Manager
 	bc             beacon.Client
 	d              *client.Client
+	km             *keymanager.Client
 	gasThreshold   float64
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -59,6 +64,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -67,6 +76,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	if err != nil {
 		return nil, err
 	}
+	km, err := services.GetKeymanager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
 
@@ -96,8 +109,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		bc:             bc,
 		d:              d,
+		km:             km,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
@@ -141,7 +156,7 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 	t.log.Printlnf("%d minipool(s) are ready for staking...", len(minipools))
 
 	// Stake minipools
-	successCount := 0
+	stakedPubkeys := []rptypes.ValidatorPubkey{}
 	for _, mpd := range minipools {
 		success, err := t.stakeMinipool(mpd, state, opts)
 		alerting.AlertMinipoolStaked(t.cfg, mpd.MinipoolAddress, success && err == nil)
@@ -150,13 +165,23 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 			return err
 		}
 		if success {
-			successCount++
+			stakedPubkeys = append(stakedPubkeys, mpd.Pubkey)
 		}
 	}
 
-	// Restart validator process if any minipools were staked successfully
-	if successCount > 0 {
-		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+	// Load the keys into the validator process if any minipools were staked successfully
+	if len(stakedPubkeys) > 0 {
+		// If the fee recipient can't be determined, restart the VC so it loads the keys with the fee recipient file
+		km := t.km
+		var feeRecipient common.Address
+		feeRecipientInfo, err := rputils.GetFeeRecipientInfo(t.rp, t.bc, nodeAccount.Address, state)
+		if err != nil {
+			t.log.Printlnf("Couldn't get the fee recipient for the new validators, restarting the validator instead of loading them live: %s", err.Error())
+			km = nil
+		} else {
+			feeRecipient = feeRecipientInfo.GetCorrectFeeRecipient()
+		}
+		if err := validator.LoadValidatorKeys(t.cfg, km, stakedPubkeys, t.w.LoadValidatorKey, feeRecipient, t.bc, &t.log, t.d); err != nil {
 			return err
 		}
 	}
@@ -301,7 +326,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return false, err
 	}
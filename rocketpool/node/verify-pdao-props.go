@@ -17,6 +17,7 @@ import (
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/proposals"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -41,6 +42,7 @@ type verifyPdaoProps struct {
 	cfg                 *config.RocketPoolConfig
 	w                   *wallet.Wallet
 	rp                  *rocketpool.RocketPool
+	txm                 *txmanager.Manager
 	bc                  beacon.Client
 	gasThreshold        float64
 	maxFee              *big.Int
@@ -70,6 +72,10 @@ func newVerifyPdaoProps(c *cli.Context, logger log.ColorLogger) (*verifyPdaoProp
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -115,6 +121,7 @@ func newVerifyPdaoProps(c *cli.Context, logger log.ColorLogger) (*verifyPdaoProp
 		cfg:                 cfg,
 		w:                   w,
 		rp:                  rp,
+		txm:                 txm,
 		bc:                  bc,
 		gasThreshold:        gasThreshold,
 		maxFee:              maxFee,
@@ -393,7 +400,7 @@ func (t *verifyPdaoProps) submitChallenge(challenge challenge) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, t.log)
 	if err != nil {
 		return err
 	}
@@ -449,7 +456,7 @@ func (t *verifyPdaoProps) submitDefeat(defeat defeat) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, t.log)
 	if err != nil {
 		return err
 	}
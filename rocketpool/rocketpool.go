@@ -66,6 +66,22 @@ func main() {
 			Name:  "force-fallbacks",
 			Usage: "Set this to true if you know the primary EC or CC is offline and want to bypass its health checks, and just use the fallback EC and CC instead",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Set this to true to simulate any transactions this command would submit against the pending block instead of submitting them",
+		},
+		cli.BoolFlag{
+			Name:  "unsigned",
+			Usage: "Set this to true to return any transactions this command would submit unsigned instead of submitting them, so they can be signed on an offline machine",
+		},
+		cli.StringFlag{
+			Name:  "unsigned-node",
+			Usage: "In unsigned mode, the node `address` to use instead of the node wallet's, so the wallet doesn't need to be on this machine",
+		},
+		cli.StringFlag{
+			Name:  "unsigned-from",
+			Usage: "In unsigned mode, the `address` that will sign the transactions offline if it isn't the node address (e.g. the node's withdrawal address)",
+		},
 		cli.BoolFlag{
 			Name:  "use-protected-api",
 			Usage: "Set this to true to use the Flashbots Protect RPC instead of your local Execution Client. Useful to ensure your transactions aren't front-run.",
@@ -74,6 +90,7 @@ func main() {
 
 	// Register commands
 	api.RegisterCommands(app, "api", []string{"a"})
+	api.RegisterServerCommand(app, "api-server", []string{"s"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
 
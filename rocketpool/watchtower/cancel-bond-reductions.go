@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -35,6 +36,7 @@ type cancelBondReductions struct {
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
 	rp               *rocketpool.RocketPool
+	txm              *txmanager.Manager
 	ec               rocketpool.ExecutionClient
 	coll             *collectors.BondReductionCollector
 	lock             *sync.Mutex
@@ -62,6 +64,10 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
@@ -72,6 +78,7 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 		cfg:              cfg,
 		w:                w,
 		rp:               rp,
+		txm:              txm,
 		ec:               ec,
 		coll:             coll,
 		lock:             lock,
@@ -252,7 +259,7 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		t.printMessage(fmt.Sprintf("error waiting for cancel transaction: %s", err.Error()))
 		return
@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -38,6 +39,7 @@ type checkSoloMigrations struct {
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
 	rp               *rocketpool.RocketPool
+	txm              *txmanager.Manager
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
 	coll             *collectors.SoloMigrationCollector
@@ -66,6 +68,10 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -80,6 +86,7 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 		cfg:              cfg,
 		w:                w,
 		rp:               rp,
+		txm:              txm,
 		ec:               ec,
 		bc:               bc,
 		coll:             coll,
@@ -304,7 +311,7 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		t.printMessage(fmt.Sprintf("error waiting for scrub transaction: %s", err.Error()))
 		return
@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -26,6 +27,7 @@ type finalizePdaoProposals struct {
 	w   *wallet.Wallet
 	ec  rocketpool.ExecutionClient
 	rp  *rocketpool.RocketPool
+	txm *txmanager.Manager
 }
 
 // Create finalize PDAO proposals task task
@@ -48,6 +50,10 @@ func newFinalizePdaoProposals(c *cli.Context, logger log.ColorLogger) (*finalize
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	return &finalizePdaoProposals{
@@ -57,6 +63,7 @@ func newFinalizePdaoProposals(c *cli.Context, logger log.ColorLogger) (*finalize
 		w:   w,
 		ec:  ec,
 		rp:  rp,
+		txm: txm,
 	}, nil
 
 }
@@ -138,7 +145,7 @@ func (t *finalizePdaoProposals) finalizeProposal(propID uint64) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return err
 	}
@@ -20,8 +20,10 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/fixture"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
@@ -37,6 +39,10 @@ type generateRewardsTree struct {
 	bc        beacon.Client
 	lock      *sync.Mutex
 	isRunning bool
+
+	// The request currently being processed
+	requestIndex    uint64
+	requestIsVerify bool
 }
 
 // Create generate rewards Merkle Tree task
@@ -76,7 +82,7 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 	return generator, nil
 }
 
-// Check for generation requests
+// Check for generation and verification requests
 func (t *generateRewardsTree) run() error {
 	t.log.Println("Checking for manual rewards tree generation requests...")
 
@@ -104,9 +110,21 @@ func (t *generateRewardsTree) run() error {
 
 	for _, file := range files {
 		filename := file.Name()
+		var suffix string
+		verify := false
+		record := false
 		if strings.HasSuffix(filename, config.RegenerateRewardsTreeRequestSuffix) && !file.IsDir() {
+			suffix = config.RegenerateRewardsTreeRequestSuffix
+		} else if strings.HasSuffix(filename, config.VerifyRewardsTreeRequestSuffix) && !file.IsDir() {
+			suffix = config.VerifyRewardsTreeRequestSuffix
+			verify = true
+		} else if strings.HasSuffix(filename, config.RecordRewardsFixtureRequestSuffix) && !file.IsDir() {
+			suffix = config.RecordRewardsFixtureRequestSuffix
+			record = true
+		}
+		if suffix != "" {
 			// Get the index
-			indexString := strings.TrimSuffix(filename, config.RegenerateRewardsTreeRequestSuffix)
+			indexString := strings.TrimSuffix(filename, suffix)
 			index, err := strconv.ParseUint(indexString, 0, 64)
 			if err != nil {
 				return fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
@@ -122,8 +140,10 @@ func (t *generateRewardsTree) run() error {
 			// Generate the rewards tree
 			t.lock.Lock()
 			t.isRunning = true
+			t.requestIndex = index
+			t.requestIsVerify = verify
 			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+			go t.generateRewardsTree(index, verify, record)
 
 			// Return after the first request, do others at other intervals
 			return nil
@@ -133,14 +153,35 @@ func (t *generateRewardsTree) run() error {
 	return nil
 }
 
-func (t *generateRewardsTree) generateRewardsTree(index uint64) {
+func (t *generateRewardsTree) generateRewardsTree(index uint64, verify bool, record bool) {
 
 	// Begin generation of the tree
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
+	if verify {
+		generationPrefix = fmt.Sprintf("[Interval %d Verification]", index)
+	}
 	t.log.Printlnf("%s Starting generation of Merkle rewards tree for interval %d.", generationPrefix, index)
 
+	// Record every client response into a fixture if requested, so the generation can be replayed offline
+	rp := t.rp
+	ec := t.ec
+	bc := t.bc
+	var recording *fixture.Fixture
+	if record {
+		recording = fixture.New()
+		ec = fixture.NewExecutionClient(recording, t.ec)
+		bc = fixture.NewBeaconClient(recording, t.bc)
+		var err error
+		rp, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error creating Rocket Pool client for recording: %w", generationPrefix, err))
+			return
+		}
+		defer t.saveFixture(recording, index, generationPrefix)
+	}
+
 	// Find the event for this interval
-	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(t.rp, t.cfg, index, nil)
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, t.cfg, index, nil)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error getting event for interval %d: %w", generationPrefix, index, err))
 		return
@@ -148,7 +189,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	t.log.Printlnf("%s Found snapshot event: Beacon block %s, execution block %s", generationPrefix, rewardsEvent.ConsensusBlock.String(), rewardsEvent.ExecutionBlock.String())
 
 	// Get the EL block
-	elBlockHeader, err := t.ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	elBlockHeader, err := ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error getting execution block: %w", generationPrefix, err))
 		return
@@ -157,14 +198,14 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	var stateManager *state.NetworkStateManager
 
 	// Try getting the rETH address as a canary to see if the block is available
-	client := t.rp
+	client := rp
 	opts := &bind.CallOpts{
 		BlockNumber: elBlockHeader.Number,
 	}
 	address, err := client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
 	if err == nil {
 		// Create the state manager with using the primary or fallback (not necessarily archive) EC
-		stateManager, err = state.NewNetworkStateManager(client, t.cfg, t.rp.Client, t.bc, &t.log)
+		stateManager, err = state.NewNetworkStateManager(client, t.cfg, rp.Client, bc, &t.log)
 		if err != nil {
 			t.handleError(fmt.Errorf("error creating new NetworkStateManager with Archive EC: %w", err))
 			return
@@ -182,12 +223,16 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
 			if archiveEcUrl != "" {
 				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), archiveEcUrl)
-				ec, err := ethclient.Dial(archiveEcUrl)
+				var archiveEc rocketpool.ExecutionClient
+				archiveEc, err = ethclient.Dial(archiveEcUrl)
 				if err != nil {
 					t.handleError(fmt.Errorf("Error connecting to archive EC: %w", err))
 					return
 				}
-				client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+				if recording != nil {
+					archiveEc = fixture.NewExecutionClient(recording, archiveEc)
+				}
+				client, err = rocketpool.NewRocketPool(archiveEc, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
 				if err != nil {
 					t.handleError(fmt.Errorf("Error creating Rocket Pool client connected to archive EC: %w", err))
 					return
@@ -200,7 +245,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 					return
 				}
 				// Create the state manager with the archive EC
-				stateManager, err = state.NewNetworkStateManager(client, t.cfg, ec, t.bc, &t.log)
+				stateManager, err = state.NewNetworkStateManager(client, t.cfg, archiveEc, bc, &t.log)
 				if err != nil {
 					t.handleError(fmt.Errorf("Error creating new NetworkStateManager with ARchive EC: %w", err))
 					return
@@ -228,15 +273,26 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	}
 
 	// Generate the tree
-	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, state)
+	t.generateRewardsTreeImpl(client, bc, index, verify, generationPrefix, rewardsEvent, elBlockHeader, state)
+}
+
+// Save the client responses recorded during generation to the fixture file for the interval
+func (t *generateRewardsTree) saveFixture(recording *fixture.Fixture, index uint64, generationPrefix string) {
+	path := t.cfg.Smartnode.GetRewardsFixturePath(index, true)
+	err := recording.Save(path)
+	if err != nil {
+		t.errLog.Printlnf("%s Error saving fixture: %s", generationPrefix, err.Error())
+		return
+	}
+	t.log.Printlnf("%s Saved %d recorded client responses to %s.", generationPrefix, recording.Len(), path)
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
+func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, bc beacon.Client, index uint64, verify bool, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
 
 	// Generate the rewards file
 	start := time.Now()
-	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state, nil)
+	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rp, t.cfg, bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state, nil)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
 		return
@@ -260,6 +316,12 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		t.log.Printlnf("%s Your Merkle tree's root of %s matches the canonical root! You will be able to use this file for claiming rewards.", generationPrefix, header.MerkleRoot)
 	}
 
+	// Compare against the canonical tree instead of replacing it if this is a verification
+	if verify {
+		t.verifyRewardsTree(index, generationPrefix, rewardsFile, rewardsEvent.MerkleRoot)
+		return
+	}
+
 	// Create the JSON files
 	rewardsFile.SetMinipoolPerformanceFileCID("---")
 	t.log.Printlnf("%s Saving JSON files...", generationPrefix)
@@ -291,10 +353,63 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 
 }
 
+// Compare a regenerated rewards tree against the canonical one that was downloaded, and save the report
+func (t *generateRewardsTree) verifyRewardsTree(index uint64, generationPrefix string, rewardsFile rprewards.IRewardsFile, onChainRoot common.Hash) {
+
+	// Load the canonical files
+	canonicalFile, err := rprewards.ReadLocalRewardsFile(t.cfg.Smartnode.GetRewardsTreePath(index, true))
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error loading canonical rewards file: %w", generationPrefix, err))
+		return
+	}
+	var canonicalPerformance rprewards.IMinipoolPerformanceFile
+	canonicalPerformanceFile, err := rprewards.ReadLocalMinipoolPerformanceFile(t.cfg.Smartnode.GetMinipoolPerformancePath(index, true))
+	if err != nil {
+		t.log.Printlnf("%s WARNING: couldn't load the canonical minipool performance file, so minipools will not be compared: %s", generationPrefix, err.Error())
+	} else {
+		canonicalPerformance = canonicalPerformanceFile.Impl()
+	}
+
+	// Compare the trees and save the report
+	report := rprewards.VerifyRewardsFile(canonicalFile.Impl(), canonicalPerformance, rewardsFile, onChainRoot)
+	reportPath := t.cfg.Smartnode.GetRewardsTreeVerificationPath(index, true)
+	err = report.Write(reportPath)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error saving verification report: %w", generationPrefix, err))
+		return
+	}
+
+	if report.Matches {
+		t.log.Printlnf("%s The regenerated tree matches the canonical tree and the on-chain Merkle root.", generationPrefix)
+	} else {
+		t.log.Printlnf("%s WARNING: the regenerated tree does not match! Found %d node difference(s) and %d minipool difference(s).", generationPrefix, len(report.NodeDifferences), len(report.MinipoolDifferences))
+	}
+	t.log.Printlnf("%s Verification report saved to %s.", generationPrefix, reportPath)
+
+	t.lock.Lock()
+	t.isRunning = false
+	t.lock.Unlock()
+
+}
+
 func (t *generateRewardsTree) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Rewards tree generation failed. ***")
 	t.lock.Lock()
+	defer t.lock.Unlock()
 	t.isRunning = false
-	t.lock.Unlock()
+
+	// Record the failure so anything waiting on the verification knows it's done
+	if t.requestIsVerify {
+		report := &rprewards.RewardsTreeVerificationReport{
+			Index:           t.requestIndex,
+			Network:         string(t.cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+			VerifiedAt:      time.Now().UTC(),
+			GenerationError: err.Error(),
+		}
+		reportErr := report.Write(t.cfg.Smartnode.GetRewardsTreeVerificationPath(t.requestIndex, true))
+		if reportErr != nil {
+			t.errLog.Printlnf("Error saving verification failure report: %s", reportErr.Error())
+		}
+	}
 }
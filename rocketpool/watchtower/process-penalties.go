@@ -23,6 +23,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
@@ -44,6 +45,7 @@ type processPenalties struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *txmanager.Manager
 	ec             rocketpool.ExecutionClient
 	bc             beacon.Client
 	lock           *sync.Mutex
@@ -79,6 +81,10 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -120,6 +126,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		ec:             ec,
 		bc:             bc,
 		rp:             rp,
+		txm:            txm,
 		lock:           lock,
 		isRunning:      false,
 		maxFee:         maxFee,
@@ -526,7 +533,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return err
 	}
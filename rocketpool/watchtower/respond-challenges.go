@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -24,6 +25,7 @@ type respondChallenges struct {
 	cfg *config.RocketPoolConfig
 	w   *wallet.Wallet
 	rp  *rocketpool.RocketPool
+	txm *txmanager.Manager
 	m   *state.NetworkStateManager
 }
 
@@ -43,6 +45,10 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	return &respondChallenges{
@@ -51,6 +57,7 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 		cfg: cfg,
 		w:   w,
 		rp:  rp,
+		txm: txm,
 		m:   m,
 	}, nil
 
@@ -115,7 +122,7 @@ func (t *respondChallenges) run() error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return err
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -43,6 +44,7 @@ type submitNetworkBalances struct {
 	w         *wallet.Wallet
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
+	txm       *txmanager.Manager
 	bc        beacon.Client
 	lock      *sync.Mutex
 	isRunning bool
@@ -86,6 +88,10 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -101,6 +107,7 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 		w:         w,
 		ec:        ec,
 		rp:        rp,
+		txm:       txm,
 		bc:        bc,
 		lock:      lock,
 		isRunning: false,
@@ -535,7 +542,7 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, t.log)
 	if err != nil {
 		return fmt.Errorf("error waiting for transaction: %w", err)
 	}
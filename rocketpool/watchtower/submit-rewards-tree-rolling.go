@@ -24,6 +24,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -41,6 +42,7 @@ type submitRewardsTree_Rolling struct {
 	w           *wallet.Wallet
 	ec          rocketpool.ExecutionClient
 	rp          *rocketpool.RocketPool
+	txm         *txmanager.Manager
 	bc          beacon.Client
 	genesisTime time.Time
 	recordMgr   *rprewards.RollingRecordManager
@@ -71,6 +73,10 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -124,6 +130,7 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 		ec:          ec,
 		w:           w,
 		rp:          rp,
+		txm:         txm,
 		bc:          bc,
 		stateMgr:    stateMgr,
 		genesisTime: genesisTime,
@@ -684,7 +691,7 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return err
 	}
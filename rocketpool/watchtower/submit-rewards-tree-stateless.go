@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -42,6 +43,7 @@ type submitRewardsTree_Stateless struct {
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
 	rp               *rocketpool.RocketPool
+	txm              *txmanager.Manager
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
 	lock             *sync.Mutex
@@ -74,6 +76,10 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 
 	lock := &sync.Mutex{}
 	generator := &submitRewardsTree_Stateless{
@@ -85,6 +91,7 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 		bc:               bc,
 		w:                w,
 		rp:               rp,
+		txm:              txm,
 		lock:             lock,
 		isRunning:        false,
 		generationPrefix: "[Merkle Tree]",
@@ -483,7 +490,7 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, t.log)
 	if err != nil {
 		return err
 	}
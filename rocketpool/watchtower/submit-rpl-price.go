@@ -26,6 +26,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -247,6 +248,7 @@ type submitRplPrice struct {
 	w         *wallet.Wallet
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
+	txm       *txmanager.Manager
 	bc        beacon.Client
 	lock      *sync.Mutex
 	isRunning bool
@@ -272,6 +274,10 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -287,6 +293,7 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 		ec:     ec,
 		w:      w,
 		rp:     rp,
+		txm:    txm,
 		bc:     bc,
 		lock:   lock,
 	}, nil
@@ -596,7 +603,7 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, slotTimestamp uint64
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, t.log)
 	if err != nil {
 		return err
 	}
@@ -736,7 +743,7 @@ func (t *submitRplPrice) submitOptimismPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		err = api.PrintAndWaitForManagedTransaction(t.cfg, tx.Hash(), t.txm, t.log)
 		if err != nil {
 			return err
 		}
@@ -876,7 +883,7 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		err = api.PrintAndWaitForManagedTransaction(t.cfg, tx.Hash(), t.txm, t.log)
 		if err != nil {
 			return err
 		}
@@ -1039,7 +1046,7 @@ func (t *submitRplPrice) submitArbitrumPrice(priceMessengerAddress string) error
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		err = api.PrintAndWaitForManagedTransaction(t.cfg, tx.Hash(), t.txm, t.log)
 		if err != nil {
 			return err
 		}
@@ -1197,7 +1204,7 @@ func (t *submitRplPrice) submitZkSyncEraPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		err = api.PrintAndWaitForManagedTransaction(t.cfg, tx.Hash(), t.txm, t.log)
 		if err != nil {
 			return err
 		}
@@ -1337,7 +1344,7 @@ func (t *submitRplPrice) submitBasePrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		err = api.PrintAndWaitForManagedTransaction(t.cfg, tx.Hash(), t.txm, t.log)
 		if err != nil {
 			return err
 		}
@@ -1502,7 +1509,7 @@ func (t *submitRplPrice) submitScrollPrice() error {
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		err = api.PrintAndWaitForManagedTransaction(t.cfg, tx.Hash(), t.txm, t.log)
 		if err != nil {
 			return err
 		}
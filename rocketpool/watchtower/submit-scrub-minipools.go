@@ -26,6 +26,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -46,6 +47,7 @@ type submitScrubMinipools struct {
 	cfg       *config.RocketPoolConfig
 	w         *wallet.Wallet
 	rp        *rocketpool.RocketPool
+	txm       *txmanager.Manager
 	ec        rocketpool.ExecutionClient
 	bc        beacon.Client
 	it        *iterationData
@@ -102,6 +104,10 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -116,6 +122,7 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 		cfg:       cfg,
 		w:         w,
 		rp:        rp,
+		txm:       txm,
 		ec:        ec,
 		bc:        bc,
 		coll:      coll,
@@ -594,7 +601,7 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	err = api.PrintAndWaitForManagedTransaction(t.cfg, hash, t.txm, &t.log)
 	if err != nil {
 		return err
 	}
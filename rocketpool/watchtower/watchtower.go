@@ -65,11 +65,28 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Listen for the wallet password if it isn't saved to disk
+	err := services.ServeWalletUnlock(c, "watchtower", log.NewColorLogger(UpdateColor))
+	if err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
 	}
 
+	// Resume waiting on the transactions that were pending when the daemon stopped
+	txm, err := services.GetTxManager(c)
+	if err != nil {
+		return err
+	}
+	txLog := log.NewColorLogger(UpdateColor)
+	err = txm.Start("watchtower", &txLog)
+	if err != nil {
+		return err
+	}
+
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -1,20 +1,22 @@
 package alerting
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-openapi/strfmt"
 	apiclient "github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/client"
-	apialert "github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/client/alert"
 	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 )
 
 const (
 	DefaultEndsAtDurationForSeverityInfo     = time.Minute * 5
+	DefaultEndsAtDurationForSeverityWarning  = time.Minute * 30
 	DefaultEndsAtDurationForSeverityCritical = time.Minute * 60
 )
 
@@ -38,10 +40,10 @@ func FetchAlerts(cfg *config.RocketPoolConfig) ([]*models.GettableAlert, error)
 }
 
 // Sends an alert when the node automatically changed a node's fee recipient or attempted to (success or failure).
-// If alerting/metrics are disabled, this function does nothing.
+// If no notification backends are enabled, this function does nothing.
 func AlertFeeRecipientChanged(cfg *config.RocketPoolConfig, newFeeRecipient common.Address, succeeded bool) error {
-	if !isAlertingEnabled(cfg) {
-		logMessage("alerting is disabled, not sending AlertFeeRecipientChanged.")
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertFeeRecipientChanged.")
 		return nil
 	}
 
@@ -64,10 +66,10 @@ func AlertFeeRecipientChanged(cfg *config.RocketPoolConfig, newFeeRecipient comm
 }
 
 // Sends an alert when the node automatically reduced a minipool's bond or attempted to (success or failure).
-// If alerting/metrics are disabled, this function does nothing.
+// If no notification backends are enabled, this function does nothing.
 func AlertMinipoolBondReduced(cfg *config.RocketPoolConfig, minipoolAddress common.Address, succeeded bool) error {
-	if !isAlertingEnabled(cfg) {
-		logMessage("alerting is disabled, not sending AlertMinipoolBondReduced.")
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertMinipoolBondReduced.")
 		return nil
 	}
 
@@ -93,10 +95,10 @@ func AlertMinipoolBondReduced(cfg *config.RocketPoolConfig, minipoolAddress comm
 }
 
 // Sends an alert when the node automatically distributes a minipool's balance (success or failure).
-// If alerting/metrics are disabled, this function does nothing.
+// If no notification backends are enabled, this function does nothing.
 func AlertMinipoolBalanceDistributed(cfg *config.RocketPoolConfig, minipoolAddress common.Address, succeeded bool) error {
-	if !isAlertingEnabled(cfg) {
-		logMessage("alerting is disabled, not sending AlertMinipoolBalanceDistributed.")
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertMinipoolBalanceDistributed.")
 		return nil
 	}
 
@@ -121,10 +123,10 @@ func AlertMinipoolBalanceDistributed(cfg *config.RocketPoolConfig, minipoolAddre
 }
 
 // Sends an alert when the node automatically prompted a minipool or attempted to (success or failure).
-// If alerting/metrics are disabled, this function does nothing.
+// If no notification backends are enabled, this function does nothing.
 func AlertMinipoolPromoted(cfg *config.RocketPoolConfig, minipoolAddress common.Address, succeeded bool) error {
-	if !isAlertingEnabled(cfg) {
-		logMessage("alerting is disabled, not sending AlertMinipoolPromoted.")
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertMinipoolPromoted.")
 		return nil
 	}
 
@@ -149,10 +151,10 @@ func AlertMinipoolPromoted(cfg *config.RocketPoolConfig, minipoolAddress common.
 }
 
 // Sends an alert when the node automatically staked a minipool or attempted to (success or failure).
-// If alerting/metrics are disabled, this function does nothing.
+// If no notification backends are enabled, this function does nothing.
 func AlertMinipoolStaked(cfg *config.RocketPoolConfig, minipoolAddress common.Address, succeeded bool) error {
-	if !isAlertingEnabled(cfg) {
-		logMessage("alerting is disabled, not sending AlertMinipoolStaked.")
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertMinipoolStaked.")
 		return nil
 	}
 
@@ -177,6 +179,40 @@ func AlertMinipoolStaked(cfg *config.RocketPoolConfig, minipoolAddress common.Ad
 	return sendAlert(alert, cfg)
 }
 
+// Sends an alert when the node automatically claimed its rewards or attempted to (success or failure).
+// If no notification backends are enabled, this function does nothing.
+func AlertRewardsClaimed(cfg *config.RocketPoolConfig, intervals []uint64, rplAmount float64, ethAmount float64, restakeAmount float64, succeeded bool) error {
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertRewardsClaimed.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RewardsClaimed.Value != true {
+		logMessage("alert for RewardsClaimed is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	endsAt, severity, succeededOrFailedText := getAlertSettingsForEvent(succeeded)
+	intervalStrings := make([]string, len(intervals))
+	for i, interval := range intervals {
+		intervalStrings[i] = fmt.Sprint(interval)
+	}
+	intervalList := strings.Join(intervalStrings, ",")
+
+	alert := createAlert(
+		fmt.Sprintf("RewardsClaimed-%s-%s", succeededOrFailedText, intervalList),
+		fmt.Sprintf("Rewards claim %s", succeededOrFailedText),
+		fmt.Sprintf("Claiming %.6f RPL and %.6f ETH from intervals %s (restaking %.6f RPL) finished with status %s.", rplAmount, ethAmount, intervalList, restakeAmount, succeededOrFailedText),
+		severity,
+		endsAt,
+		map[string]string{
+			"intervals": intervalList,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
 // Gets various settings for an alert based on whether a process succeeded or failed.
 func getAlertSettingsForEvent(succeeded bool) (strfmt.DateTime, Severity, string) {
 	endsAt := strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo))
@@ -217,8 +253,8 @@ const (
 
 func alertClientSyncComplete(cfg *config.RocketPoolConfig, client ClientKind) error {
 	alertName := fmt.Sprintf("%sClientSyncComplete", client)
-	if !isAlertingEnabled(cfg) {
-		logMessage(fmt.Sprintf("alerting is disabled, not sending %s.", alertName))
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending %s.", alertName)
 		return nil
 	}
 
@@ -233,16 +269,128 @@ func alertClientSyncComplete(cfg *config.RocketPoolConfig, client ClientKind) er
 	return sendAlert(alert, cfg)
 }
 
+// Sends an alert when one or more of the node's validators have missed all of their attestations for the configured window of epochs.
+// If no notification backends are enabled, this function does nothing.
+func AlertMissedAttestations(cfg *config.RocketPoolConfig, validatorIndices []string, startEpoch uint64, endEpoch uint64) error {
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertMissedAttestations.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_MissedAttestations.Value != true {
+		logMessage("alert for MissedAttestations is disabled, not sending.")
+		return nil
+	}
+
+	validators := strings.Join(validatorIndices, ", ")
+	alert := createAlert(
+		fmt.Sprintf("MissedAttestations-%d", endEpoch),
+		fmt.Sprintf("%d validator(s) missing attestations", len(validatorIndices)),
+		fmt.Sprintf("The following validators missed all of their attestations from epoch %d to epoch %d: %s.", startEpoch, endEpoch, validators),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityWarning)),
+		map[string]string{
+			"validators": validators,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when one of the node's validators missed a block proposal, or its block was orphaned.
+// If no notification backends are enabled, this function does nothing.
+func AlertMissedProposal(cfg *config.RocketPoolConfig, minipoolAddress common.Address, validatorIndex string, epoch uint64, missedCount uint64) error {
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertMissedProposal.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_MissedProposal.Value != true {
+		logMessage("alert for MissedProposal is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("MissedProposal-%s-%d", validatorIndex, epoch),
+		fmt.Sprintf("Validator %s missed a proposal", validatorIndex),
+		fmt.Sprintf("Validator %s (minipool %s) was scheduled to propose %d block(s) in epoch %d, but they are missing from the canonical chain. The proposal(s) were either missed or orphaned.", validatorIndex, minipoolAddress.Hex(), missedCount, epoch),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"minipool":  minipoolAddress.Hex(),
+			"validator": validatorIndex,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when one of the node's validators has been scheduled for an upcoming sync committee.
+// If no notification backends are enabled, this function does nothing.
+func AlertSyncCommitteeDuty(cfg *config.RocketPoolConfig, minipoolAddress common.Address, validatorIndex string, startEpoch uint64, endEpoch uint64) error {
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertSyncCommitteeDuty.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_SyncCommitteeDuty.Value != true {
+		logMessage("alert for SyncCommitteeDuty is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("SyncCommitteeDuty-%s-%d", validatorIndex, startEpoch),
+		fmt.Sprintf("Validator %s is in an upcoming sync committee", validatorIndex),
+		fmt.Sprintf("Validator %s (minipool %s) will be part of the sync committee from epoch %d to epoch %d. Make sure your node stays online during this time.", validatorIndex, minipoolAddress.Hex(), startEpoch, endEpoch),
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{
+			"minipool":  minipoolAddress.Hex(),
+			"validator": validatorIndex,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node wallet's ETH balance is too low to pay for the gas of the node's automatic transactions.
+// If no notification backends are enabled, this function does nothing.
+func AlertLowNodeBalance(cfg *config.RocketPoolConfig, nodeAddress common.Address, balance float64, threshold float64) error {
+	if !isNotifyingEnabled(cfg) {
+		logMessage("no notification backends are enabled, not sending AlertLowNodeBalance.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_LowNodeBalance.Value != true {
+		logMessage("alert for LowNodeBalance is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("LowNodeBalance-%s", nodeAddress.Hex()),
+		"Node wallet balance is low",
+		fmt.Sprintf("The node wallet %s only has %.6f ETH, which is below the threshold of %.6f ETH. The node may not be able to pay for the gas of its automatic transactions.", nodeAddress.Hex(), balance, threshold),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityWarning)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert to every configured notification backend whose minimum severity it meets.
+// Delivery is attempted on all backends even if one of them fails.
 func sendAlert(alert *models.PostableAlert, cfg *config.RocketPoolConfig) error {
 	logMessage("sending alert for %s: %s", alert.Labels["alertname"], alert.Annotations["summary"])
 
-	params := apialert.NewPostAlertsParams().WithDefaults().WithAlerts(models.PostableAlerts{alert})
-	client := createClient(cfg)
-	_, err := client.Alert.PostAlerts(params)
-	if err != nil {
-		return fmt.Errorf("error posting alert: %s", err.Error())
+	severity := getAlertSeverity(alert)
+	errs := []error{}
+	for _, n := range getNotifiers(cfg) {
+		if severity.rank() < n.getMinSeverity().rank() {
+			continue
+		}
+		err := n.notify(alert)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error sending alert to %s: %w", n.getName(), err))
+		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 type Severity string
@@ -257,6 +405,11 @@ func isAlertingEnabled(cfg *config.RocketPoolConfig) bool {
 	return cfg.Alertmanager.EnableAlerting.Value == true
 }
 
+// Checks if at least one notification backend (including Alertmanager) is enabled
+func isNotifyingEnabled(cfg *config.RocketPoolConfig) bool {
+	return len(getNotifiers(cfg)) > 0
+}
+
 // Creates a uniform alert with the basic labels and annotations we expect.
 func createAlert(uniqueName string, summary string, description string, severity Severity, endsAt strfmt.DateTime, extraLabels map[string]string) *models.PostableAlert {
 	alert := &models.PostableAlert{
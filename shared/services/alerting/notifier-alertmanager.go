@@ -0,0 +1,40 @@
+package alerting
+
+import (
+	"fmt"
+
+	apialert "github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/client/alert"
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Sends alerts to the Alertmanager container / service
+type alertmanagerNotifier struct {
+	cfg         *config.RocketPoolConfig
+	minSeverity Severity
+}
+
+func newAlertmanagerNotifier(cfg *config.RocketPoolConfig) *alertmanagerNotifier {
+	return &alertmanagerNotifier{
+		cfg:         cfg,
+		minSeverity: getMinSeverity(cfg.Notifications.AlertmanagerMinSeverity),
+	}
+}
+
+func (n *alertmanagerNotifier) getName() string {
+	return "alertmanager"
+}
+
+func (n *alertmanagerNotifier) getMinSeverity() Severity {
+	return n.minSeverity
+}
+
+func (n *alertmanagerNotifier) notify(alert *models.PostableAlert) error {
+	params := apialert.NewPostAlertsParams().WithDefaults().WithAlerts(models.PostableAlerts{alert})
+	client := createClient(n.cfg)
+	_, err := client.Alert.PostAlerts(params)
+	if err != nil {
+		return fmt.Errorf("error posting alert: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package alerting
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Sends alerts as chat messages to a Discord or Slack webhook
+type chatNotifier struct {
+	url         string
+	format      cfgtypes.ChatWebhookFormat
+	minSeverity Severity
+}
+
+func newChatNotifier(cfg *config.RocketPoolConfig) *chatNotifier {
+	return &chatNotifier{
+		url:         cfg.Notifications.ChatWebhookURL.Value.(string),
+		format:      cfg.Notifications.ChatWebhookFormat.Value.(cfgtypes.ChatWebhookFormat),
+		minSeverity: getMinSeverity(cfg.Notifications.ChatMinSeverity),
+	}
+}
+
+func (n *chatNotifier) getName() string {
+	return string(n.format)
+}
+
+func (n *chatNotifier) getMinSeverity() Severity {
+	return n.minSeverity
+}
+
+func (n *chatNotifier) notify(alert *models.PostableAlert) error {
+	switch n.format {
+	case cfgtypes.ChatWebhookFormat_Discord:
+		message := fmt.Sprintf("**%s**\n%s", getAlertTitle(alert), alert.Annotations["description"])
+		return postJson(n.url, map[string]string{"content": message}, nil)
+	case cfgtypes.ChatWebhookFormat_Slack:
+		message := fmt.Sprintf("*%s*\n%s", getAlertTitle(alert), alert.Annotations["description"])
+		return postJson(n.url, map[string]string{"text": message}, nil)
+	default:
+		return fmt.Errorf("unknown chat webhook format [%s]", n.format)
+	}
+}
@@ -0,0 +1,77 @@
+package alerting
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Sends alerts as emails via an SMTP server
+type emailNotifier struct {
+	host        string
+	port        uint16
+	username    string
+	password    string
+	from        string
+	to          []string
+	minSeverity Severity
+}
+
+func newEmailNotifier(cfg *config.RocketPoolConfig) *emailNotifier {
+	to := []string{}
+	for _, address := range strings.Split(cfg.Notifications.EmailTo.Value.(string), ",") {
+		address = strings.TrimSpace(address)
+		if address != "" {
+			to = append(to, address)
+		}
+	}
+	return &emailNotifier{
+		host:        cfg.Notifications.SmtpHost.Value.(string),
+		port:        cfg.Notifications.SmtpPort.Value.(uint16),
+		username:    cfg.Notifications.SmtpUsername.Value.(string),
+		password:    cfg.Notifications.SmtpPassword.Value.(string),
+		from:        cfg.Notifications.EmailFrom.Value.(string),
+		to:          to,
+		minSeverity: getMinSeverity(cfg.Notifications.EmailMinSeverity),
+	}
+}
+
+func (n *emailNotifier) getName() string {
+	return "email"
+}
+
+func (n *emailNotifier) getMinSeverity() Severity {
+	return n.minSeverity
+}
+
+func (n *emailNotifier) notify(alert *models.PostableAlert) error {
+	if n.from == "" || len(n.to) == 0 {
+		return fmt.Errorf("email sender and recipients must both be set")
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("From: %s\r\n", n.from))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(n.to, ", ")))
+	message.WriteString(fmt.Sprintf("Subject: [Rocket Pool] %s\r\n", getAlertTitle(alert)))
+	message.WriteString(fmt.Sprintf("Date: %s\r\n", time.Now().Format(time.RFC1123Z)))
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(alert.Annotations["description"])
+	message.WriteString("\r\n")
+
+	address := fmt.Sprintf("%s:%d", n.host, n.port)
+	err := smtp.SendMail(address, auth, n.from, n.to, []byte(message.String()))
+	if err != nil {
+		return fmt.Errorf("error sending email via %s: %w", address, err)
+	}
+	return nil
+}
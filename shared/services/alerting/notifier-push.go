@@ -0,0 +1,89 @@
+package alerting
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Sends alerts as push notifications via ntfy or Gotify
+type pushNotifier struct {
+	service     cfgtypes.PushService
+	url         string
+	token       string
+	minSeverity Severity
+}
+
+func newPushNotifier(cfg *config.RocketPoolConfig) *pushNotifier {
+	return &pushNotifier{
+		service:     cfg.Notifications.PushService.Value.(cfgtypes.PushService),
+		url:         strings.TrimSuffix(cfg.Notifications.PushURL.Value.(string), "/"),
+		token:       cfg.Notifications.PushToken.Value.(string),
+		minSeverity: getMinSeverity(cfg.Notifications.PushMinSeverity),
+	}
+}
+
+func (n *pushNotifier) getName() string {
+	return string(n.service)
+}
+
+func (n *pushNotifier) getMinSeverity() Severity {
+	return n.minSeverity
+}
+
+func (n *pushNotifier) notify(alert *models.PostableAlert) error {
+	switch n.service {
+	case cfgtypes.PushService_Ntfy:
+		return n.notifyNtfy(alert)
+	case cfgtypes.PushService_Gotify:
+		return n.notifyGotify(alert)
+	default:
+		return fmt.Errorf("unknown push service [%s]", n.service)
+	}
+}
+
+// Publish an alert to an ntfy topic
+func (n *pushNotifier) notifyNtfy(alert *models.PostableAlert) error {
+	request, err := http.NewRequest(http.MethodPost, n.url, strings.NewReader(alert.Annotations["description"]))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Title", getAlertTitle(alert))
+	request.Header.Set("Tags", string(getAlertSeverity(alert)))
+	switch getAlertSeverity(alert) {
+	case SeverityCritical:
+		request.Header.Set("Priority", "urgent")
+	case SeverityWarning:
+		request.Header.Set("Priority", "high")
+	default:
+		request.Header.Set("Priority", "default")
+	}
+	if n.token != "" {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", n.token))
+	}
+	return doRequest(request)
+}
+
+// Publish an alert to a Gotify server
+func (n *pushNotifier) notifyGotify(alert *models.PostableAlert) error {
+	if n.token == "" {
+		return fmt.Errorf("Gotify requires an application token")
+	}
+	priority := 2
+	switch getAlertSeverity(alert) {
+	case SeverityCritical:
+		priority = 8
+	case SeverityWarning:
+		priority = 5
+	}
+	body := map[string]interface{}{
+		"title":    getAlertTitle(alert),
+		"message":  alert.Annotations["description"],
+		"priority": priority,
+	}
+	return postJson(n.url+"/message", body, map[string]string{"X-Gotify-Key": n.token})
+}
@@ -0,0 +1,51 @@
+package alerting
+
+import (
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The JSON body sent to generic webhooks
+type webhookPayload struct {
+	Name        string            `json:"name"`
+	Summary     string            `json:"summary"`
+	Description string            `json:"description"`
+	Severity    Severity          `json:"severity"`
+	EndsAt      time.Time         `json:"endsAt"`
+	Labels      map[string]string `json:"labels"`
+}
+
+// Sends alerts as JSON to a generic webhook
+type webhookNotifier struct {
+	url         string
+	minSeverity Severity
+}
+
+func newWebhookNotifier(cfg *config.RocketPoolConfig) *webhookNotifier {
+	return &webhookNotifier{
+		url:         cfg.Notifications.WebhookURL.Value.(string),
+		minSeverity: getMinSeverity(cfg.Notifications.WebhookMinSeverity),
+	}
+}
+
+func (n *webhookNotifier) getName() string {
+	return "webhook"
+}
+
+func (n *webhookNotifier) getMinSeverity() Severity {
+	return n.minSeverity
+}
+
+func (n *webhookNotifier) notify(alert *models.PostableAlert) error {
+	payload := webhookPayload{
+		Name:        alert.Labels["alertname"],
+		Summary:     alert.Annotations["summary"],
+		Description: alert.Annotations["description"],
+		Severity:    getAlertSeverity(alert),
+		EndsAt:      time.Time(alert.EndsAt),
+		Labels:      alert.Labels,
+	}
+	return postJson(n.url, payload, nil)
+}
@@ -0,0 +1,115 @@
+package alerting
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const notifierHttpTimeout = 10 * time.Second
+
+// A backend that alerts can be delivered to
+type notifier interface {
+	// The name of the backend, used for logging
+	getName() string
+
+	// The minimum severity an alert must have to be sent to this backend
+	getMinSeverity() Severity
+
+	// Deliver an alert to the backend
+	notify(alert *models.PostableAlert) error
+}
+
+// Get all of the notification backends that are currently configured
+func getNotifiers(cfg *config.RocketPoolConfig) []notifier {
+	notifiers := []notifier{}
+	if isAlertingEnabled(cfg) {
+		notifiers = append(notifiers, newAlertmanagerNotifier(cfg))
+	}
+	if cfg.Notifications.WebhookURL.Value.(string) != "" {
+		notifiers = append(notifiers, newWebhookNotifier(cfg))
+	}
+	if cfg.Notifications.ChatWebhookURL.Value.(string) != "" {
+		notifiers = append(notifiers, newChatNotifier(cfg))
+	}
+	if cfg.Notifications.SmtpHost.Value.(string) != "" {
+		notifiers = append(notifiers, newEmailNotifier(cfg))
+	}
+	if cfg.Notifications.PushURL.Value.(string) != "" {
+		notifiers = append(notifiers, newPushNotifier(cfg))
+	}
+	return notifiers
+}
+
+// Get the severity level of a minimum severity parameter
+func getMinSeverity(param cfgtypes.Parameter) Severity {
+	severity, ok := param.Value.(cfgtypes.AlertSeverity)
+	if !ok {
+		return SeverityInfo
+	}
+	return Severity(severity)
+}
+
+// Get the numeric rank of a severity, so severities can be compared
+func (s Severity) rank() int {
+	switch s {
+	case SeverityWarning:
+		return 1
+	case SeverityCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Get the severity of an alert from its labels
+func getAlertSeverity(alert *models.PostableAlert) Severity {
+	return Severity(alert.Labels["severity"])
+}
+
+// Get the formatted title of an alert for backends that display it as a message
+func getAlertTitle(alert *models.PostableAlert) string {
+	return fmt.Sprintf("[%s] %s", getAlertSeverity(alert), alert.Annotations["summary"])
+}
+
+// Post a JSON body to a URL with optional extra headers
+func postJson(url string, body interface{}, headers map[string]string) error {
+	bytesToSend, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error serializing request body: %w", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bytesToSend))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	return doRequest(request)
+}
+
+// Send an HTTP request and check that it succeeded
+func doRequest(request *http.Request) error {
+	client := http.Client{
+		Timeout: notifierHttpTimeout,
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("request failed with code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// A backup is a gzipped tarball inside an encrypted stream. The manifest is the last entry, so the hashes of every file can be
+// recorded while the files are streamed into the archive.
+const (
+	ArchiveVersion   int    = 1
+	ManifestFilename string = "manifest.json"
+	FileExtension    string = ".rpbackup"
+)
+
+// The paths of each part of the node inside a backup
+const (
+	WalletPath             string = "wallet"
+	PasswordPath           string = "password"
+	SettingsPath           string = "user-settings.yml"
+	OverrideFolder         string = "override"
+	CustomKeysFolder       string = "custom-keys"
+	CustomKeyPasswordsPath string = "custom-key-passwords"
+	RewardsTreesFolder     string = "rewards-trees"
+	WatchtowerFolder       string = "watchtower"
+	SlashingProtectionPath string = "slashing-protection.json"
+)
+
+// Describes the contents of a backup
+type Manifest struct {
+	Version                  int                     `json:"version"`
+	SmartnodeVersion         string                  `json:"smartnodeVersion"`
+	CreatedAt                time.Time               `json:"createdAt"`
+	Network                  string                  `json:"network"`
+	NodeAddress              common.Address          `json:"nodeAddress"`
+	MinipoolPubkeys          []types.ValidatorPubkey `json:"minipoolPubkeys"`
+	IncludesPassword         bool                    `json:"includesPassword"`
+	SlashingProtectionClient string                  `json:"slashingProtectionClient,omitempty"`
+	Files                    []FileEntry             `json:"files"`
+}
+
+// A file in a backup
+type FileEntry struct {
+	Path   string      `json:"path"`
+	Size   int64       `json:"size"`
+	Mode   fs.FileMode `json:"mode"`
+	Sha256 string      `json:"sha256"`
+}
+
+// Check if the backup has a file or folder
+func (m *Manifest) Has(archivePath string) bool {
+	for _, file := range m.Files {
+		if file.Path == archivePath || strings.HasPrefix(file.Path, archivePath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Writes a backup
+type Writer struct {
+	encryptor *encryptingWriter
+	gzip      *gzip.Writer
+	tar       *tar.Writer
+	files     []FileEntry
+}
+
+// Start writing a backup encrypted with the given passphrase
+func NewWriter(output io.Writer, passphrase string) (*Writer, error) {
+	encryptor, err := newEncryptingWriter(output, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	gzipWriter := gzip.NewWriter(encryptor)
+	return &Writer{
+		encryptor: encryptor,
+		gzip:      gzipWriter,
+		tar:       tar.NewWriter(gzipWriter),
+		files:     []FileEntry{},
+	}, nil
+}
+
+// Add a file to the backup
+func (w *Writer) AddFile(archivePath string, sourcePath string) error {
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", sourcePath, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", sourcePath, err)
+	}
+
+	header := &tar.Header{
+		Name:    archivePath,
+		Size:    info.Size(),
+		Mode:    int64(info.Mode().Perm()),
+		ModTime: info.ModTime(),
+	}
+	if err := w.tar.WriteHeader(header); err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", sourcePath, err)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w.tar, hash), file); err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", sourcePath, err)
+	}
+	w.files = append(w.files, FileEntry{
+		Path:   archivePath,
+		Size:   info.Size(),
+		Mode:   info.Mode().Perm(),
+		Sha256: hex.EncodeToString(hash.Sum(nil)),
+	})
+	return nil
+}
+
+// Add every regular file in a folder to the backup. A folder that doesn't exist is skipped.
+func (w *Writer) AddFolder(archivePath string, sourceFolder string) error {
+	if _, err := os.Stat(sourceFolder); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(sourceFolder, func(sourcePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", sourcePath, err)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(sourceFolder, sourcePath)
+		if err != nil {
+			return err
+		}
+		return w.AddFile(path.Join(archivePath, filepath.ToSlash(relativePath)), sourcePath)
+	})
+}
+
+// Get the files that have been added so far
+func (w *Writer) Files() []FileEntry {
+	return w.files
+}
+
+// Write the manifest and finish the backup
+func (w *Writer) Close(manifest Manifest) error {
+	manifest.Version = ArchiveVersion
+	manifest.Files = w.files
+	bytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing backup manifest: %w", err)
+	}
+	header := &tar.Header{
+		Name:    ManifestFilename,
+		Size:    int64(len(bytes)),
+		Mode:    0600,
+		ModTime: manifest.CreatedAt,
+	}
+	if err := w.tar.WriteHeader(header); err != nil {
+		return fmt.Errorf("error writing backup manifest: %w", err)
+	}
+	if _, err := w.tar.Write(bytes); err != nil {
+		return fmt.Errorf("error writing backup manifest: %w", err)
+	}
+
+	if err := w.tar.Close(); err != nil {
+		return fmt.Errorf("error finishing backup: %w", err)
+	}
+	if err := w.gzip.Close(); err != nil {
+		return fmt.Errorf("error finishing backup: %w", err)
+	}
+	return w.encryptor.Close()
+}
+
+// Decrypt a backup into a folder, checking every file against the manifest
+func Extract(input io.Reader, passphrase string, targetFolder string) (*Manifest, error) {
+	decryptor, err := newDecryptingReader(input, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	gzipReader, err := gzip.NewReader(decryptor)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup: %w", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	hashes := map[string]string{}
+	var manifest *Manifest
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+		if manifest != nil {
+			return nil, errors.New("the backup has files after its manifest")
+		}
+
+		if header.Name == ManifestFilename {
+			manifest = new(Manifest)
+			if err := json.NewDecoder(tarReader).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("error reading backup manifest: %w", err)
+			}
+			continue
+		}
+
+		// Don't let entries escape the target folder
+		cleanPath := path.Clean(header.Name)
+		if header.Typeflag != tar.TypeReg || path.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+			return nil, fmt.Errorf("the backup has an invalid entry [%s]", header.Name)
+		}
+		targetPath := filepath.Join(targetFolder, filepath.FromSlash(cleanPath))
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0700); err != nil {
+			return nil, fmt.Errorf("error creating folder for %s: %w", targetPath, err)
+		}
+		file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("error creating %s: %w", targetPath, err)
+		}
+		hash := sha256.New()
+		_, err = io.Copy(io.MultiWriter(file, hash), tarReader)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error extracting %s: %w", header.Name, err)
+		}
+		hashes[cleanPath] = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	// Check the contents
+	if manifest == nil {
+		return nil, errors.New("the backup doesn't have a manifest")
+	}
+	if manifest.Version > ArchiveVersion {
+		return nil, fmt.Errorf("the backup has version %d, but this version of the Smartnode only supports up to version %d; please upgrade it first", manifest.Version, ArchiveVersion)
+	}
+	if len(hashes) != len(manifest.Files) {
+		return nil, fmt.Errorf("the backup has %d files, but its manifest lists %d", len(hashes), len(manifest.Files))
+	}
+	for _, file := range manifest.Files {
+		hash, exists := hashes[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("the backup is missing %s", file.Path)
+		}
+		if hash != file.Sha256 {
+			return nil, fmt.Errorf("%s in the backup doesn't match its checksum", file.Path)
+		}
+	}
+	return manifest, nil
+}
@@ -0,0 +1,196 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+// An entry in a crafted archive
+type testEntry struct {
+	header  tar.Header
+	content string
+}
+
+// Create a regular file entry
+func regularEntry(name string, content string) testEntry {
+	return testEntry{
+		header: tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Size:     int64(len(content)),
+			Mode:     0600,
+		},
+		content: content,
+	}
+}
+
+// Create a manifest entry listing the given files
+func manifestEntry(t *testing.T, version int, files ...FileEntry) testEntry {
+	t.Helper()
+	bytes, err := json.Marshal(Manifest{
+		Version: version,
+		Files:   files,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return regularEntry(ManifestFilename, string(bytes))
+}
+
+// Create the manifest listing of a file
+func fileListing(name string, content string) FileEntry {
+	hash := sha256.Sum256([]byte(content))
+	return FileEntry{
+		Path:   name,
+		Size:   int64(len(content)),
+		Mode:   0600,
+		Sha256: hex.EncodeToString(hash[:]),
+	}
+}
+
+// Write a backup with arbitrary entries, bypassing the checks of Writer
+func craftBackup(t *testing.T, entries ...testEntry) []byte {
+	t.Helper()
+	var output bytes.Buffer
+	encryptor, err := newEncryptingWriter(&output, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzipWriter := gzip.NewWriter(encryptor)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		header := entry.header
+		if err := tarWriter.WriteHeader(&header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, closer := range []interface{ Close() error }{tarWriter, gzipWriter, encryptor} {
+		if err := closer.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return output.Bytes()
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	sourceFolder := t.TempDir()
+	files := map[string]string{
+		"wallet":                     `{"crypto":{}}`,
+		"custom-keys/a.json":         "key a",
+		"custom-keys/nested/b.json":  "key b",
+		"custom-keys/nested/c/empty": "",
+	}
+	for name, content := range files {
+		sourcePath := filepath.Join(sourceFolder, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(sourcePath), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(sourcePath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var output bytes.Buffer
+	writer, err := NewWriter(&output, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.AddFile(WalletPath, filepath.Join(sourceFolder, "wallet")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.AddFolder(CustomKeysFolder, filepath.Join(sourceFolder, "custom-keys")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.AddFolder(WatchtowerFolder, filepath.Join(sourceFolder, "missing")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(Manifest{Network: "mainnet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	targetFolder := t.TempDir()
+	manifest, err := Extract(&output, testPassphrase, targetFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Version != ArchiveVersion || manifest.Network != "mainnet" {
+		t.Errorf("unexpected manifest: version %d, network %s", manifest.Version, manifest.Network)
+	}
+	if len(manifest.Files) != len(files) {
+		t.Errorf("expected %d files in the manifest, got %d", len(files), len(manifest.Files))
+	}
+	for name, content := range files {
+		extracted, err := os.ReadFile(filepath.Join(targetFolder, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("%s: %s", name, err.Error())
+			continue
+		}
+		if string(extracted) != content {
+			t.Errorf("%s: expected %q, got %q", name, content, string(extracted))
+		}
+	}
+	if !manifest.Has(WalletPath) || !manifest.Has(CustomKeysFolder) || manifest.Has(WatchtowerFolder) || manifest.Has("custom") {
+		t.Error("the manifest doesn't report the files it has")
+	}
+}
+
+func TestExtractInvalidBackups(t *testing.T) {
+	symlink := regularEntry("custom-keys/link", "")
+	symlink.header.Typeflag = tar.TypeSymlink
+	symlink.header.Linkname = "/etc/passwd"
+	folder := regularEntry("custom-keys/", "")
+	folder.header.Typeflag = tar.TypeDir
+	wallet := regularEntry("wallet", "wallet")
+	tampered := fileListing("wallet", "wallet")
+	tampered.Sha256 = strings.Repeat("0", 64)
+
+	tests := []struct {
+		name     string
+		entries  []testEntry
+		expected string
+	}{
+		{"parent folder", []testEntry{regularEntry("../escape", "x")}, "invalid entry"},
+		{"parent folder inside a path", []testEntry{regularEntry("custom-keys/../../escape", "x")}, "invalid entry"},
+		{"only the parent folder", []testEntry{regularEntry("..", "x")}, "invalid entry"},
+		{"absolute path", []testEntry{regularEntry("/tmp/escape", "x")}, "invalid entry"},
+		{"symlink", []testEntry{symlink}, "invalid entry"},
+		{"folder", []testEntry{folder}, "invalid entry"},
+		{"entry after the manifest", []testEntry{manifestEntry(t, ArchiveVersion), wallet}, "after its manifest"},
+		{"no manifest", []testEntry{wallet}, "doesn't have a manifest"},
+		{"newer version", []testEntry{manifestEntry(t, ArchiveVersion+1)}, "only supports up to version"},
+		{"checksum mismatch", []testEntry{wallet, manifestEntry(t, ArchiveVersion, tampered)}, "doesn't match its checksum"},
+		{"unlisted file", []testEntry{wallet, manifestEntry(t, ArchiveVersion)}, "has 1 files, but its manifest lists 0"},
+		{"missing file", []testEntry{manifestEntry(t, ArchiveVersion, fileListing("wallet", "wallet"))}, "has 0 files, but its manifest lists 1"},
+		{"renamed file", []testEntry{wallet, manifestEntry(t, ArchiveVersion, fileListing("password", "wallet"))}, "missing password"},
+	}
+	for _, test := range tests {
+		parentFolder := t.TempDir()
+		targetFolder := filepath.Join(parentFolder, "target")
+		if err := os.Mkdir(targetFolder, 0700); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Extract(bytes.NewReader(craftBackup(t, test.entries...)), testPassphrase, targetFolder)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.expected, err)
+		}
+		if _, err := os.Stat(filepath.Join(parentFolder, "escape")); !os.IsNotExist(err) {
+			t.Errorf("%s: expected nothing to be written outside of the target folder", test.name)
+		}
+	}
+
+	if _, err := Extract(bytes.NewReader(craftBackup(t, wallet)), "wrong passphrase", t.TempDir()); err == nil || !strings.Contains(err.Error(), "passphrase is incorrect") {
+		t.Errorf("expected an incorrect passphrase error, got %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Backups are encrypted with AES-256-GCM in fixed-size chunks so they can be streamed without holding the whole archive in memory.
+// Each chunk's nonce is the header's nonce prefix followed by the chunk counter and a flag for the final chunk, which stops chunks
+// from being reordered, dropped or truncated without detection.
+const (
+	encryptionMagic   string = "RPBACKUP"
+	encryptionVersion byte   = 1
+	chunkSize         int    = 64 * 1024
+	saltSize          int    = 16
+	noncePrefixSize   int    = 7
+	keySize           int    = 32
+
+	// The scrypt parameters, which match the ones Ethereum keystores use
+	scryptLogN uint8 = 18
+	scryptR    uint8 = 8
+	scryptP    uint8 = 1
+)
+
+// The scrypt cost new backups are written with; tests lower it to keep them fast
+var writeScryptLogN uint8 = scryptLogN
+
+// The header at the start of an encrypted backup
+type encryptionHeader struct {
+	version     byte
+	scryptLogN  uint8
+	scryptR     uint8
+	scryptP     uint8
+	salt        []byte
+	noncePrefix []byte
+}
+
+// Serialize the header; it's also used as the additional data for every chunk
+func (h *encryptionHeader) bytes() []byte {
+	buffer := bytes.NewBufferString(encryptionMagic)
+	buffer.Write([]byte{h.version, h.scryptLogN, h.scryptR, h.scryptP})
+	buffer.Write(h.salt)
+	buffer.Write(h.noncePrefix)
+	return buffer.Bytes()
+}
+
+// Derive the encryption key from a passphrase
+func (h *encryptionHeader) getCipher(passphrase string) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), h.salt, 1<<h.scryptLogN, int(h.scryptR), int(h.scryptP), keySize)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving backup encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating backup cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Get the nonce for a chunk
+func (h *encryptionHeader) getNonce(counter uint32, final bool) []byte {
+	nonce := make([]byte, noncePrefixSize+5)
+	copy(nonce, h.noncePrefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], counter)
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// Writes an encrypted stream
+type encryptingWriter struct {
+	output  io.Writer
+	header  *encryptionHeader
+	aead    cipher.AEAD
+	aad     []byte
+	buffer  []byte
+	counter uint32
+	closed  bool
+}
+
+// Start an encrypted stream, writing its header to the output
+func newEncryptingWriter(output io.Writer, passphrase string) (*encryptingWriter, error) {
+	header := &encryptionHeader{
+		version:     encryptionVersion,
+		scryptLogN:  writeScryptLogN,
+		scryptR:     scryptR,
+		scryptP:     scryptP,
+		salt:        make([]byte, saltSize),
+		noncePrefix: make([]byte, noncePrefixSize),
+	}
+	if _, err := rand.Read(header.salt); err != nil {
+		return nil, fmt.Errorf("error generating backup salt: %w", err)
+	}
+	if _, err := rand.Read(header.noncePrefix); err != nil {
+		return nil, fmt.Errorf("error generating backup nonce: %w", err)
+	}
+	aead, err := header.getCipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aad := header.bytes()
+	if _, err := output.Write(aad); err != nil {
+		return nil, fmt.Errorf("error writing backup header: %w", err)
+	}
+	return &encryptingWriter{
+		output: output,
+		header: header,
+		aead:   aead,
+		aad:    aad,
+		buffer: make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (w *encryptingWriter) Write(data []byte) (int, error) {
+	written := 0
+	for len(data) > 0 {
+		// Only flush a full chunk once more data arrives, so the last chunk can always be marked as final
+		if len(w.buffer) == chunkSize {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+		count := copy(w.buffer[len(w.buffer):chunkSize], data)
+		w.buffer = w.buffer[:len(w.buffer)+count]
+		data = data[count:]
+		written += count
+	}
+	return written, nil
+}
+
+// Encrypt and write the final chunk
+func (w *encryptingWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush(true)
+}
+
+// Encrypt and write the buffered chunk
+func (w *encryptingWriter) flush(final bool) error {
+	ciphertext := w.aead.Seal(nil, w.header.getNonce(w.counter, final), w.buffer, w.aad)
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(ciphertext)))
+	if _, err := w.output.Write(length); err != nil {
+		return fmt.Errorf("error writing backup: %w", err)
+	}
+	if _, err := w.output.Write(ciphertext); err != nil {
+		return fmt.Errorf("error writing backup: %w", err)
+	}
+	w.counter++
+	w.buffer = w.buffer[:0]
+	return nil
+}
+
+// Reads an encrypted stream
+type decryptingReader struct {
+	input   *bufio.Reader
+	header  *encryptionHeader
+	aead    cipher.AEAD
+	aad     []byte
+	buffer  []byte
+	counter uint32
+	done    bool
+}
+
+// Open an encrypted stream, checking its header
+func newDecryptingReader(input io.Reader, passphrase string) (*decryptingReader, error) {
+	reader := bufio.NewReader(input)
+	prefix := make([]byte, len(encryptionMagic)+4)
+	if _, err := io.ReadFull(reader, prefix); err != nil || string(prefix[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, fmt.Errorf("this is not a Rocket Pool backup")
+	}
+	header := &encryptionHeader{
+		version:     prefix[len(encryptionMagic)],
+		scryptLogN:  prefix[len(encryptionMagic)+1],
+		scryptR:     prefix[len(encryptionMagic)+2],
+		scryptP:     prefix[len(encryptionMagic)+3],
+		salt:        make([]byte, saltSize),
+		noncePrefix: make([]byte, noncePrefixSize),
+	}
+	if header.version != encryptionVersion {
+		return nil, fmt.Errorf("backup encryption version %d is not supported by this version of the Smartnode", header.version)
+	}
+
+	// Reject scrypt parameters that need more memory or time than the ones backups are written with, since they'd
+	// let a crafted file exhaust this machine's memory before the passphrase is even checked
+	if header.scryptLogN > scryptLogN || header.scryptR > scryptR || header.scryptP > scryptP {
+		return nil, fmt.Errorf("backup scrypt parameters (N=2^%d, r=%d, p=%d) exceed the maximum of N=2^%d, r=%d, p=%d", header.scryptLogN, header.scryptR, header.scryptP, scryptLogN, scryptR, scryptP)
+	}
+	if _, err := io.ReadFull(reader, header.salt); err != nil {
+		return nil, fmt.Errorf("error reading backup header: %w", err)
+	}
+	if _, err := io.ReadFull(reader, header.noncePrefix); err != nil {
+		return nil, fmt.Errorf("error reading backup header: %w", err)
+	}
+	aead, err := header.getCipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptingReader{
+		input:  reader,
+		header: header,
+		aead:   aead,
+		aad:    header.bytes(),
+	}, nil
+}
+
+func (r *decryptingReader) Read(data []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	count := copy(data, r.buffer)
+	r.buffer = r.buffer[count:]
+	return count, nil
+}
+
+// Read and decrypt the next chunk
+func (r *decryptingReader) readChunk() error {
+	length := make([]byte, 4)
+	if _, err := io.ReadFull(r.input, length); err != nil {
+		return fmt.Errorf("the backup is truncated")
+	}
+	ciphertextLength := binary.BigEndian.Uint32(length)
+	if ciphertextLength > uint32(chunkSize+r.aead.Overhead()) {
+		return fmt.Errorf("the backup is corrupted")
+	}
+	ciphertext := make([]byte, ciphertextLength)
+	if _, err := io.ReadFull(r.input, ciphertext); err != nil {
+		return fmt.Errorf("the backup is truncated")
+	}
+
+	// The final chunk is the only one that decrypts with the final flag set
+	plaintext, err := r.aead.Open(nil, r.header.getNonce(r.counter, false), ciphertext, r.aad)
+	if err != nil {
+		plaintext, err = r.aead.Open(nil, r.header.getNonce(r.counter, true), ciphertext, r.aad)
+		if err != nil {
+			if r.counter == 0 {
+				return errors.New("the passphrase is incorrect or the backup is corrupted")
+			}
+			return errors.New("the backup is corrupted")
+		}
+		r.done = true
+		if _, err := r.input.Peek(1); err != io.EOF {
+			return errors.New("the backup has unexpected data after its end")
+		}
+	}
+	r.buffer = plaintext
+	r.counter++
+	return nil
+}
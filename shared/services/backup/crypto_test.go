@@ -0,0 +1,187 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+const testPassphrase string = "correct horse battery staple"
+
+// The length of an encrypted backup's header
+const headerLength int = len(encryptionMagic) + 4 + saltSize + noncePrefixSize
+
+func TestMain(m *testing.M) {
+	// The real scrypt cost takes a quarter of a GB and most of a second per key
+	writeScryptLogN = 10
+	os.Exit(m.Run())
+}
+
+// Encrypt data, writing it in pieces of the given size
+func encrypt(t *testing.T, data []byte, pieceSize int) []byte {
+	t.Helper()
+	var output bytes.Buffer
+	writer, err := newEncryptingWriter(&output, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(data) > 0 {
+		piece := data[:min(pieceSize, len(data))]
+		if n, err := writer.Write(piece); err != nil || n != len(piece) {
+			t.Fatalf("expected to write %d bytes, wrote %d (%v)", len(piece), n, err)
+		}
+		data = data[len(piece):]
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return output.Bytes()
+}
+
+// Decrypt an encrypted stream
+func decrypt(encrypted []byte, passphrase string) ([]byte, error) {
+	reader, err := newDecryptingReader(bytes.NewReader(encrypted), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+// Split an encrypted stream into its header and its chunks, each with its length prefix
+func splitChunks(t *testing.T, encrypted []byte) ([]byte, [][]byte) {
+	t.Helper()
+	header := encrypted[:headerLength]
+	chunks := [][]byte{}
+	for remaining := encrypted[headerLength:]; len(remaining) > 0; {
+		length := 4 + int(binary.BigEndian.Uint32(remaining))
+		chunks = append(chunks, remaining[:length])
+		remaining = remaining[length:]
+	}
+	return header, chunks
+}
+
+// Join a header and chunks back into a stream
+func joinChunks(header []byte, chunks ...[]byte) []byte {
+	joined := append([]byte{}, header...)
+	for _, chunk := range chunks {
+		joined = append(joined, chunk...)
+	}
+	return joined
+}
+
+// Get some random data
+func randomData(t *testing.T, size int) []byte {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		chunks int
+	}{
+		{"empty", 0, 1},
+		{"one byte", 1, 1},
+		{"exactly one chunk", chunkSize, 1},
+		{"one byte over a chunk", chunkSize + 1, 2},
+		{"several chunks", 3*chunkSize + 100, 4},
+		{"exactly several chunks", 3 * chunkSize, 3},
+	}
+	for _, test := range tests {
+		data := randomData(t, test.size)
+		for _, pieceSize := range []int{1000, chunkSize, 5 * chunkSize} {
+			encrypted := encrypt(t, data, pieceSize)
+			if _, chunks := splitChunks(t, encrypted); len(chunks) != test.chunks {
+				t.Errorf("%s: expected %d chunks, got %d", test.name, test.chunks, len(chunks))
+			}
+			decrypted, err := decrypt(encrypted, testPassphrase)
+			if err != nil {
+				t.Errorf("%s: %s", test.name, err.Error())
+				continue
+			}
+			if !bytes.Equal(decrypted, data) {
+				t.Errorf("%s: decrypted data doesn't match when written in pieces of %d", test.name, pieceSize)
+			}
+		}
+	}
+}
+
+func TestDecryptWithWrongPassphrase(t *testing.T) {
+	encrypted := encrypt(t, randomData(t, 2*chunkSize), chunkSize)
+	_, err := decrypt(encrypted, "wrong passphrase")
+	if err == nil || !strings.Contains(err.Error(), "passphrase is incorrect") {
+		t.Fatalf("expected an incorrect passphrase error, got %v", err)
+	}
+}
+
+func TestDecryptTamperedChunks(t *testing.T) {
+	encrypted := encrypt(t, randomData(t, 3*chunkSize+100), chunkSize)
+	header, chunks := splitChunks(t, encrypted)
+	last := chunks[len(chunks)-1]
+	flipped := append([]byte{}, chunks[1]...)
+	flipped[10] ^= 0x01
+
+	tests := []struct {
+		name      string
+		encrypted []byte
+		expected  string
+	}{
+		{"truncated final chunk", joinChunks(header, chunks[0], chunks[1], chunks[2], last[:len(last)-1]), "truncated"},
+		{"final chunk dropped", joinChunks(header, chunks[0], chunks[1], chunks[2]), "truncated"},
+		{"middle chunk dropped", joinChunks(header, chunks[0], chunks[2], chunks[3]), "corrupted"},
+		{"chunks reordered", joinChunks(header, chunks[0], chunks[2], chunks[1], chunks[3]), "corrupted"},
+		{"first chunk moved", joinChunks(header, chunks[1], chunks[0], chunks[2], chunks[3]), "corrupted"},
+		{"final chunk repeated", joinChunks(header, chunks[0], chunks[1], chunks[2], last, last), "after its end"},
+		{"byte after the final chunk", append(joinChunks(header, chunks...), 0), "after its end"},
+		{"flipped bit", joinChunks(header, chunks[0], flipped, chunks[2], chunks[3]), "corrupted"},
+		{"oversized chunk length", joinChunks(header, []byte{0xff, 0xff, 0xff, 0xff}), "corrupted"},
+		{"no chunks", header, "truncated"},
+	}
+	for _, test := range tests {
+		_, err := decrypt(test.encrypted, testPassphrase)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestDecryptHeader(t *testing.T) {
+	encrypted := encrypt(t, []byte("data"), chunkSize)
+
+	// Scrypt parameters over the cap are rejected before any key is derived
+	for i, name := range []string{"N", "r", "p"} {
+		tampered := append([]byte{}, encrypted...)
+		tampered[len(encryptionMagic)+1+i] = []byte{scryptLogN, scryptR, scryptP}[i] + 1
+		_, err := decrypt(tampered, testPassphrase)
+		if err == nil || !strings.Contains(err.Error(), "exceed the maximum") {
+			t.Errorf("expected an error for scrypt %s over the cap, got %v", name, err)
+		}
+	}
+
+	// Any change to the header changes the additional data of every chunk
+	tampered := append([]byte{}, encrypted...)
+	tampered[headerLength-1] ^= 0x01
+	if _, err := decrypt(tampered, testPassphrase); err == nil {
+		t.Error("expected an error for a changed nonce prefix")
+	}
+
+	tampered = append([]byte{}, encrypted...)
+	tampered[len(encryptionMagic)] = encryptionVersion + 1
+	if _, err := decrypt(tampered, testPassphrase); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expected an error for an unknown version, got %v", err)
+	}
+	if _, err := decrypt([]byte("not a backup at all"), testPassphrase); err == nil || !strings.Contains(err.Error(), "not a Rocket Pool backup") {
+		t.Errorf("expected an error for a file that isn't a backup, got %v", err)
+	}
+	if _, err := decrypt(encrypted[:headerLength-1], testPassphrase); err == nil {
+		t.Error("expected an error for a truncated header")
+	}
+}
@@ -117,6 +117,17 @@ func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 	return result.(beacon.SyncStatus), nil
 }
 
+// Get the number of peers the client is connected to
+func (m *BeaconClientManager) GetNodePeerCount() (uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetNodePeerCount()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -312,6 +323,13 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex string,
 /// Internal Functions
 /// ==================
 
+// Reset the client readiness flags to the state a freshly created manager would have for a call with the provided flags
+func (m *BeaconClientManager) applyCallFlags(ignoreSyncCheck bool, forceFallbacks bool) {
+	m.ignoreSyncCheck = ignoreSyncCheck
+	m.primaryReady = !forceFallbacks
+	m.fallbackReady = m.fallbackBc != nil
+}
+
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
@@ -369,6 +387,12 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.IsSynced = false
 		status.SyncProgress = syncStatus.Progress
 	}
+
+	// Get the peer count; not every client reports it, so it's optional
+	peerCount, err := client.GetNodePeerCount()
+	if err == nil {
+		status.PeerCount = &peerCount
+	}
 	return status
 
 }
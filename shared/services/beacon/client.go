@@ -16,10 +16,12 @@ type ValidatorStatusOptions struct {
 type SyncStatus struct {
 	Syncing  bool
 	Progress float64
+	HeadSlot uint64
 }
 type Eth2Config struct {
 	GenesisForkVersion           []byte
 	GenesisValidatorsRoot        []byte
+	CapellaForkVersion           []byte
 	GenesisEpoch                 uint64
 	GenesisTime                  uint64
 	SecondsPerSlot               uint64
@@ -134,6 +136,7 @@ const (
 type Client interface {
 	GetClientType() (BeaconClientType, error)
 	GetSyncStatus() (SyncStatus, error)
+	GetNodePeerCount() (uint64, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetAttestations(blockId string) ([]AttestationInfo, bool, error)
@@ -29,6 +29,7 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath                  = "/eth/v1/node/syncing"
+	RequestPeerCountPath                   = "/eth/v1/node/peer_count"
 	RequestEth2ConfigPath                  = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath                     = "/eth/v1/beacon/genesis"
@@ -86,10 +87,20 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 	return beacon.SyncStatus{
 		Syncing:  syncStatus.Data.IsSyncing,
 		Progress: progress,
+		HeadSlot: uint64(syncStatus.Data.HeadSlot),
 	}, nil
 
 }
 
+// Get the number of peers the node is connected to
+func (c *StandardHttpClient) GetNodePeerCount() (uint64, error) {
+	peerCount, err := c.getPeerCount()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(peerCount.Data.Connected), nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -121,6 +132,7 @@ func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 	return beacon.Eth2Config{
 		GenesisForkVersion:           genesis.Data.GenesisForkVersion,
 		GenesisValidatorsRoot:        genesis.Data.GenesisValidatorsRoot,
+		CapellaForkVersion:           eth2Config.Data.CapellaForkVersion,
 		GenesisEpoch:                 0,
 		GenesisTime:                  uint64(genesis.Data.GenesisTime),
 		SecondsPerSlot:               uint64(eth2Config.Data.SecondsPerSlot),
@@ -596,6 +608,22 @@ func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	return syncStatus, nil
 }
 
+// Get node peer count
+func (c *StandardHttpClient) getPeerCount() (PeerCountResponse, error) {
+	responseBody, status, err := c.getRequest(RequestPeerCountPath)
+	if err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: %w", err)
+	}
+	if status != http.StatusOK {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var peerCount PeerCountResponse
+	if err := json.Unmarshal(responseBody, &peerCount); err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not decode node peer count: %w", err)
+	}
+	return peerCount, nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) getEth2Config() (Eth2ConfigResponse, error) {
 	responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
@@ -37,6 +37,11 @@ type SyncStatusResponse struct {
 		SyncDistance uinteger `json:"sync_distance"`
 	} `json:"data"`
 }
+type PeerCountResponse struct {
+	Data struct {
+		Connected uinteger `json:"connected"`
+	} `json:"data"`
+}
 type Eth2ConfigResponse struct {
 	Data struct {
 		SecondsPerSlot               uinteger  `json:"SECONDS_PER_SLOT"`
@@ -24,6 +24,8 @@ const AlertingRulesConfigFile string = "alerting/rules/default.yml"
 const defaultAlertmanagerPort uint16 = 9093
 const defaultAlertmanagerHost string = "localhost"
 const defaultAlertmanagerOpenPort config.RPCMode = config.RPC_Closed
+const defaultMissedAttestationsWindow uint64 = 3
+const defaultLowNodeBalanceThreshold float64 = 0.05
 
 // Configuration for Alertmanager
 type AlertmanagerConfig struct {
@@ -70,8 +72,20 @@ type AlertmanagerConfig struct {
 	AlertEnabled_MinipoolBalanceDistributed  config.Parameter `yaml:"alertEnabled_MinipoolBalanceDistributed,omitempty"`
 	AlertEnabled_MinipoolPromoted            config.Parameter `yaml:"alertEnabled_MinipoolPromoted,omitempty"`
 	AlertEnabled_MinipoolStaked              config.Parameter `yaml:"alertEnabled_MinipoolStaked,omitempty"`
+	AlertEnabled_RewardsClaimed              config.Parameter `yaml:"alertEnabled_RewardsClaimed,omitempty"`
 	AlertEnabled_ExecutionClientSyncComplete config.Parameter `yaml:"alertEnabled_ExecutionClientSyncComplete,omitempty"`
 	AlertEnabled_BeaconClientSyncComplete    config.Parameter `yaml:"alertEnabled_BeaconClientSyncComplete,omitempty"`
+	// Alerts sent by the node daemon's validator duty monitor:
+	AlertEnabled_MissedAttestations config.Parameter `yaml:"alertEnabled_MissedAttestations,omitempty"`
+	AlertEnabled_MissedProposal     config.Parameter `yaml:"alertEnabled_MissedProposal,omitempty"`
+	AlertEnabled_SyncCommitteeDuty  config.Parameter `yaml:"alertEnabled_SyncCommitteeDuty,omitempty"`
+	AlertEnabled_LowNodeBalance     config.Parameter `yaml:"alertEnabled_LowNodeBalance,omitempty"`
+
+	// The number of consecutive epochs a validator must miss attestations for before an alert is sent
+	MissedAttestationsWindow config.Parameter `yaml:"missedAttestationsWindow,omitempty"`
+
+	// The node wallet balance (in ETH) below which a low balance alert is sent
+	LowNodeBalanceThreshold config.Parameter `yaml:"lowNodeBalanceThreshold,omitempty"`
 }
 
 func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
@@ -219,6 +233,10 @@ func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
 			"MinipoolStaked",
 			"Minipool Staked"),
 
+		AlertEnabled_RewardsClaimed: createParameterForAlertEnablement(
+			"RewardsClaimed",
+			"Rewards Automatically Claimed"),
+
 		AlertEnabled_ExecutionClientSyncComplete: createParameterForAlertEnablement(
 			"ExecutionClientSyncComplete",
 			"execution client is synced"),
@@ -226,6 +244,44 @@ func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
 		AlertEnabled_BeaconClientSyncComplete: createParameterForAlertEnablement(
 			"BeaconClientSyncComplete",
 			"beacon client is synced"),
+
+		AlertEnabled_MissedAttestations: createParameterForAlertEnablement(
+			"MissedAttestations",
+			"validators are missing attestations"),
+
+		AlertEnabled_MissedProposal: createParameterForAlertEnablement(
+			"MissedProposal",
+			"a validator missed a block proposal"),
+
+		AlertEnabled_SyncCommitteeDuty: createParameterForAlertEnablement(
+			"SyncCommitteeDuty",
+			"a validator is scheduled for sync committee duty"),
+
+		AlertEnabled_LowNodeBalance: createParameterForAlertEnablement(
+			"LowNodeBalance",
+			"the node wallet balance is too low to pay for gas"),
+
+		MissedAttestationsWindow: config.Parameter{
+			ID:                 "missedAttestationsWindow",
+			Name:               "Missed Attestations Window",
+			Description:        "The number of consecutive epochs a validator must miss its attestations for before the node sends a missed attestations alert.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultMissedAttestationsWindow},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		LowNodeBalanceThreshold: config.Parameter{
+			ID:                 "lowNodeBalanceThreshold",
+			Name:               "Low Node Balance Threshold",
+			Description:        "The node wallet's ETH balance below which the node sends a low balance alert, since it won't be able to pay for the gas of its automatic transactions.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultLowNodeBalanceThreshold},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
 	}
 }
 
@@ -267,8 +323,15 @@ func (cfg *AlertmanagerConfig) GetParameters() []*config.Parameter {
 		&cfg.AlertEnabled_MinipoolBalanceDistributed,
 		&cfg.AlertEnabled_MinipoolPromoted,
 		&cfg.AlertEnabled_MinipoolStaked,
+		&cfg.AlertEnabled_RewardsClaimed,
 		&cfg.AlertEnabled_ExecutionClientSyncComplete,
 		&cfg.AlertEnabled_BeaconClientSyncComplete,
+		&cfg.AlertEnabled_MissedAttestations,
+		&cfg.AlertEnabled_MissedProposal,
+		&cfg.AlertEnabled_SyncCommitteeDuty,
+		&cfg.AlertEnabled_LowNodeBalance,
+		&cfg.MissedAttestationsWindow,
+		&cfg.LowNodeBalanceThreshold,
 	}
 }
 
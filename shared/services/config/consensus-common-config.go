@@ -12,6 +12,8 @@ const P2pQuicPortID string = "p2pQuicPort"
 const ApiPortID string = "apiPort"
 const OpenApiPortID string = "openApiPort"
 const DoppelgangerDetectionID string = "doppelgangerDetection"
+const EnableKeymanagerApiID string = "enableKeymanagerApi"
+const KeymanagerPortID string = "keymanagerPort"
 
 // Defaults
 const defaultGraffiti string = ""
@@ -21,6 +23,8 @@ const defaultP2pQuicPort uint16 = 8001
 const defaultBnApiPort uint16 = 5052
 const defaultOpenBnApiPort string = string(config.RPC_Closed)
 const defaultDoppelgangerDetection bool = true
+const defaultEnableKeymanagerApi bool = true
+const defaultKeymanagerPort uint16 = 5062
 
 // Common parameters shared by all of the Beacon Clients
 type ConsensusCommonConfig struct {
@@ -46,6 +50,12 @@ type ConsensusCommonConfig struct {
 
 	// Toggle for enabling doppelganger detection
 	DoppelgangerDetection config.Parameter `yaml:"doppelgangerDetection,omitempty"`
+
+	// Toggle for managing validator keys and fee recipients through the VC's keymanager API
+	EnableKeymanagerApi config.Parameter `yaml:"enableKeymanagerApi,omitempty"`
+
+	// The port the VC should run its keymanager API on
+	KeymanagerPort config.Parameter `yaml:"keymanagerPort,omitempty"`
 }
 
 // Create a new ConsensusCommonParams struct
@@ -135,6 +145,28 @@ func NewConsensusCommonConfig(cfg *RocketPoolConfig) *ConsensusCommonConfig {
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
 		},
+
+		EnableKeymanagerApi: config.Parameter{
+			ID:                 EnableKeymanagerApiID,
+			Name:               "Enable Keymanager API",
+			Description:        "If enabled, your Validator Client will run the standard keymanager API on the Docker network so the Smartnode can load new validator keys and change fee recipients without restarting it. Restarts cost you attestations, and some clients wait for several epochs after one before validating again.\n\nPrysm doesn't support this yet, so the Smartnode will restart it instead.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultEnableKeymanagerApi},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		KeymanagerPort: config.Parameter{
+			ID:                 KeymanagerPortID,
+			Name:               "Keymanager API Port",
+			Description:        "The port your Validator Client should run its keymanager API on. It is only available inside the Docker network.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultKeymanagerPort},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
 	}
 }
 
@@ -148,6 +180,8 @@ func (cfg *ConsensusCommonConfig) GetParameters() []*config.Parameter {
 		&cfg.ApiPort,
 		&cfg.OpenApiPort,
 		&cfg.DoppelgangerDetection,
+		&cfg.EnableKeymanagerApi,
+		&cfg.KeymanagerPort,
 	}
 }
 
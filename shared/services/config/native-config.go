@@ -24,6 +24,9 @@ type NativeConfig struct {
 
 	// The command for stopping the validator container in native mode
 	ValidatorStopCommand config.Parameter `yaml:"validatorStopCommand,omitempty"`
+
+	// The URL of the VC's keymanager API
+	VcKeymanagerUrl config.Parameter `yaml:"vcKeymanagerUrl,omitempty"`
 }
 
 // Generates a new Smartnode configuration
@@ -107,6 +110,17 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
 		},
+
+		VcKeymanagerUrl: config.Parameter{
+			ID:                 "vcKeymanagerUrl",
+			Name:               "VC Keymanager API URL",
+			Description:        "The URL of your Validator Client's keymanager API (e.g. http://localhost:5062), if you've enabled it. Rocket Pool will use it to load new validator keys and change fee recipients without restarting your Validator Client, using the bearer token in the `keymanager-token.txt` file in your data folder's `validators` directory.\n\nLeave this blank to restart your Validator Client with the VC Restart Script instead.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
 	}
 
 }
@@ -119,6 +133,7 @@ func (cfg *NativeConfig) GetParameters() []*config.Parameter {
 		&cfg.CcHttpUrl,
 		&cfg.ValidatorRestartCommand,
 		&cfg.ValidatorStopCommand,
+		&cfg.VcKeymanagerUrl,
 	}
 }
 
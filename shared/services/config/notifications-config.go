@@ -0,0 +1,268 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const defaultNotificationsMinSeverity config.AlertSeverity = config.AlertSeverity_Info
+const defaultSmtpPort uint16 = 587
+
+// Configuration for the notification backends that alerts are delivered to, in addition to (or instead of) Alertmanager
+type NotificationsConfig struct {
+	// The parent Rocket Pool Config
+	Parent *RocketPoolConfig `yaml:"-"`
+
+	Title string `yaml:"-"`
+
+	// The minimum severity of alerts sent to Alertmanager
+	AlertmanagerMinSeverity config.Parameter `yaml:"alertmanagerMinSeverity,omitempty"`
+
+	// Generic JSON webhook
+	WebhookURL         config.Parameter `yaml:"webhookURL,omitempty"`
+	WebhookMinSeverity config.Parameter `yaml:"webhookMinSeverity,omitempty"`
+
+	// Discord / Slack chat webhook
+	ChatWebhookURL    config.Parameter `yaml:"chatWebhookURL,omitempty"`
+	ChatWebhookFormat config.Parameter `yaml:"chatWebhookFormat,omitempty"`
+	ChatMinSeverity   config.Parameter `yaml:"chatMinSeverity,omitempty"`
+
+	// SMTP email
+	SmtpHost         config.Parameter `yaml:"smtpHost,omitempty"`
+	SmtpPort         config.Parameter `yaml:"smtpPort,omitempty"`
+	SmtpUsername     config.Parameter `yaml:"smtpUsername,omitempty"`
+	SmtpPassword     config.Parameter `yaml:"smtpPassword,omitempty"`
+	EmailFrom        config.Parameter `yaml:"emailFrom,omitempty"`
+	EmailTo          config.Parameter `yaml:"emailTo,omitempty"`
+	EmailMinSeverity config.Parameter `yaml:"emailMinSeverity,omitempty"`
+
+	// ntfy / Gotify push notifications
+	PushService     config.Parameter `yaml:"pushService,omitempty"`
+	PushURL         config.Parameter `yaml:"pushURL,omitempty"`
+	PushToken       config.Parameter `yaml:"pushToken,omitempty"`
+	PushMinSeverity config.Parameter `yaml:"pushMinSeverity,omitempty"`
+}
+
+// Generates a new notifications configuration
+func NewNotificationsConfig(cfg *RocketPoolConfig) *NotificationsConfig {
+	return &NotificationsConfig{
+		Parent: cfg,
+
+		Title: "Notification Settings",
+
+		AlertmanagerMinSeverity: createMinSeverityParameter("alertmanagerMinSeverity", "Alertmanager"),
+
+		WebhookURL: config.Parameter{
+			ID:                 "webhookURL",
+			Name:               "Webhook URL",
+			Description:        "The URL of a generic webhook that should receive alerts. Each alert is sent as an HTTP POST with a JSON body containing its name, summary, description, severity, and labels.\n\nLeave this blank to disable the webhook.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		WebhookMinSeverity: createMinSeverityParameter("webhookMinSeverity", "Webhook"),
+
+		ChatWebhookURL: config.Parameter{
+			ID:                 "chatWebhookURL",
+			Name:               "Chat Webhook URL",
+			Description:        "The URL of a Discord or Slack incoming webhook that should receive alerts as chat messages.\n\nLeave this blank to disable chat notifications.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		ChatWebhookFormat: config.Parameter{
+			ID:                 "chatWebhookFormat",
+			Name:               "Chat Webhook Format",
+			Description:        "The chat service that the chat webhook URL belongs to.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.ChatWebhookFormat_Discord},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Discord",
+				Description: "Send alerts to a Discord channel webhook.",
+				Value:       config.ChatWebhookFormat_Discord,
+			}, {
+				Name:        "Slack",
+				Description: "Send alerts to a Slack incoming webhook.",
+				Value:       config.ChatWebhookFormat_Slack,
+			}},
+		},
+
+		ChatMinSeverity: createMinSeverityParameter("chatMinSeverity", "Chat Webhook"),
+
+		SmtpHost: config.Parameter{
+			ID:                 "smtpHost",
+			Name:               "SMTP Host",
+			Description:        "The hostname of the SMTP server that alert emails should be sent through.\n\nLeave this blank to disable email notifications.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpPort: config.Parameter{
+			ID:                 "smtpPort",
+			Name:               "SMTP Port",
+			Description:        "The port of the SMTP server. The connection will be upgraded with STARTTLS if the server supports it.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultSmtpPort},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpUsername: config.Parameter{
+			ID:                 "smtpUsername",
+			Name:               "SMTP Username",
+			Description:        "The username to authenticate with the SMTP server. Leave this blank if the server doesn't require authentication.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpPassword: config.Parameter{
+			ID:                 "smtpPassword",
+			Name:               "SMTP Password",
+			Description:        "The password to authenticate with the SMTP server.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EmailFrom: config.Parameter{
+			ID:                 "emailFrom",
+			Name:               "Email Sender",
+			Description:        "The address that alert emails will be sent from.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EmailTo: config.Parameter{
+			ID:                 "emailTo",
+			Name:               "Email Recipients",
+			Description:        "A comma-separated list of addresses that alert emails will be sent to.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		EmailMinSeverity: createMinSeverityParameter("emailMinSeverity", "Email"),
+
+		PushService: config.Parameter{
+			ID:                 "pushService",
+			Name:               "Push Service",
+			Description:        "The push notification service that alerts should be sent to.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.PushService_Ntfy},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "ntfy",
+				Description: "Publish alerts to an ntfy topic. The push URL should include the topic, e.g. https://ntfy.sh/my-node-alerts.",
+				Value:       config.PushService_Ntfy,
+			}, {
+				Name:        "Gotify",
+				Description: "Publish alerts to a Gotify server. The push URL should be the root URL of the server, and the push token should be an application token.",
+				Value:       config.PushService_Gotify,
+			}},
+		},
+
+		PushURL: config.Parameter{
+			ID:                 "pushURL",
+			Name:               "Push URL",
+			Description:        "The URL that push notifications should be published to.\n\nLeave this blank to disable push notifications.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PushToken: config.Parameter{
+			ID:                 "pushToken",
+			Name:               "Push Token",
+			Description:        "The access token for the push service. This is required for Gotify, and optional for ntfy (only needed for protected topics).",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		PushMinSeverity: createMinSeverityParameter("pushMinSeverity", "Push"),
+	}
+}
+
+// Creates a parameter for the minimum alert severity that a notification backend should receive
+func createMinSeverityParameter(id string, backendName string) config.Parameter {
+	return config.Parameter{
+		ID:                 id,
+		Name:               backendName + " Minimum Severity",
+		Description:        "The minimum severity an alert must have to be sent to this backend.",
+		Type:               config.ParameterType_Choice,
+		Default:            map[config.Network]interface{}{config.Network_All: defaultNotificationsMinSeverity},
+		AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+		CanBeBlank:         false,
+		OverwriteOnUpgrade: false,
+		Options: []config.ParameterOption{{
+			Name:        "Info",
+			Description: "Send all alerts.",
+			Value:       config.AlertSeverity_Info,
+		}, {
+			Name:        "Warning",
+			Description: "Send warnings and critical alerts.",
+			Value:       config.AlertSeverity_Warning,
+		}, {
+			Name:        "Critical",
+			Description: "Only send critical alerts.",
+			Value:       config.AlertSeverity_Critical,
+		}},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *NotificationsConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.AlertmanagerMinSeverity,
+		&cfg.WebhookURL,
+		&cfg.WebhookMinSeverity,
+		&cfg.ChatWebhookURL,
+		&cfg.ChatWebhookFormat,
+		&cfg.ChatMinSeverity,
+		&cfg.SmtpHost,
+		&cfg.SmtpPort,
+		&cfg.SmtpUsername,
+		&cfg.SmtpPassword,
+		&cfg.EmailFrom,
+		&cfg.EmailTo,
+		&cfg.EmailMinSeverity,
+		&cfg.PushService,
+		&cfg.PushURL,
+		&cfg.PushToken,
+		&cfg.PushMinSeverity,
+	}
+}
+
+// The title for the config
+func (cfg *NotificationsConfig) GetConfigTitle() string {
+	return cfg.Title
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultRemoteSignerUrl string = "http://web3signer:9000"
+)
+
+// Configuration for a Web3Signer-compatible remote signer that holds the validator keys
+type RemoteSignerConfig struct {
+	Title string `yaml:"-"`
+
+	// The URL of the remote signer's signing and keymanager APIs
+	Url config.Parameter `yaml:"url,omitempty"`
+
+	// The bearer token for the remote signer's keymanager API
+	KeymanagerToken config.Parameter `yaml:"keymanagerToken,omitempty"`
+}
+
+// Generates a new remote signer config
+func NewRemoteSignerConfig(cfg *RocketPoolConfig) *RemoteSignerConfig {
+	return &RemoteSignerConfig{
+		Title: "Remote Signer Settings",
+
+		Url: config.Parameter{
+			ID:                 "url",
+			Name:               "Remote Signer URL",
+			Description:        "The URL of your Web3Signer-compatible remote signer, including the port. The Smartnode will import new validator keys into it through its keymanager API (so it must be started with `--key-manager-api-enabled`), and your Validator Client will load its keys from it and use it for all of its signatures.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultRemoteSignerUrl},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		KeymanagerToken: config.Parameter{
+			ID:                 "keymanagerToken",
+			Name:               "Keymanager API Token",
+			Description:        "The bearer token to authenticate with the remote signer's keymanager API, if it requires one.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *RemoteSignerConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Url,
+		&cfg.KeymanagerToken,
+	}
+}
+
+// The the title for the config
+func (cfg *RemoteSignerConfig) GetConfigTitle() string {
+	return cfg.Title
+}
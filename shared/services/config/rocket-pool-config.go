@@ -118,9 +118,16 @@ type RocketPoolConfig struct {
 	Exporter          *ExporterConfig          `yaml:"exporter,omitempty"`
 	BitflyNodeMetrics *BitflyNodeMetricsConfig `yaml:"bitflyNodeMetrics,omitempty"`
 
+	// Notification backends
+	Notifications *NotificationsConfig `yaml:"notifications,omitempty"`
+
 	// Native mode
 	Native *NativeConfig `yaml:"native,omitempty"`
 
+	// Remote signer
+	EnableRemoteSigner config.Parameter    `yaml:"enableRemoteSigner,omitempty"`
+	RemoteSigner       *RemoteSignerConfig `yaml:"remoteSigner,omitempty"`
+
 	// MEV-Boost
 	EnableMevBoost config.Parameter `yaml:"enableMevBoost,omitempty"`
 	MevBoost       *MevBoostConfig  `yaml:"mevBoost,omitempty"`
@@ -369,6 +376,17 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		EnableRemoteSigner: config.Parameter{
+			ID:                 "enableRemoteSigner",
+			Name:               "Enable Remote Signer",
+			Description:        "Enable this to keep your validator keys in a Web3Signer-compatible remote signer instead of on this machine. New validator keys will be imported into the signer instead of being saved to disk, and your Validator Client will use the signer for all of its signatures.\n\nLighthouse finds the keys in the signer through its validator definitions file, so run `rocketpool wallet rebuild` after switching to Lighthouse to add the keys you already have.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		EcMetricsPort: config.Parameter{
 			ID:                 "ecMetricsPort",
 			Name:               "Execution Client Metrics Port",
@@ -476,7 +494,9 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.Alertmanager = NewAlertmanagerConfig(cfg)
 	cfg.Exporter = NewExporterConfig(cfg)
 	cfg.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(cfg)
+	cfg.Notifications = NewNotificationsConfig(cfg)
 	cfg.Native = NewNativeConfig(cfg)
+	cfg.RemoteSigner = NewRemoteSignerConfig(cfg)
 	cfg.MevBoost = NewMevBoostConfig(cfg)
 
 	// Addons
@@ -544,6 +564,7 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.EnableMetrics,
 		&cfg.EnableODaoMetrics,
 		&cfg.EnableBitflyNodeMetrics,
+		&cfg.EnableRemoteSigner,
 		&cfg.EcMetricsPort,
 		&cfg.BnMetricsPort,
 		&cfg.VcMetricsPort,
@@ -582,7 +603,9 @@ func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 		"alertmanager":       cfg.Alertmanager,
 		"exporter":           cfg.Exporter,
 		"bitflyNodeMetrics":  cfg.BitflyNodeMetrics,
+		"notifications":      cfg.Notifications,
 		"native":             cfg.Native,
+		"remoteSigner":       cfg.RemoteSigner,
 		"mevBoost":           cfg.MevBoost,
 		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
 		"addons-rescue-node": cfg.RescueNode.GetConfig(),
@@ -1058,9 +1081,10 @@ func (cfg *RocketPoolConfig) VcAdditionalFlags() (string, error) {
 	}
 
 	var addtlFlags string
+	var client config.ConsensusClient
 	switch mode {
 	case config.Mode_Local:
-		client := cfg.ConsensusClient.Value.(config.ConsensusClient)
+		client = cfg.ConsensusClient.Value.(config.ConsensusClient)
 		switch client {
 		case config.ConsensusClient_Lighthouse:
 			addtlFlags = cfg.Lighthouse.AdditionalVcFlags.Value.(string)
@@ -1077,7 +1101,7 @@ func (cfg *RocketPoolConfig) VcAdditionalFlags() (string, error) {
 		}
 
 	case config.Mode_External:
-		client := cfg.ExternalConsensusClient.Value.(config.ConsensusClient)
+		client = cfg.ExternalConsensusClient.Value.(config.ConsensusClient)
 		switch client {
 		case config.ConsensusClient_Lighthouse:
 			addtlFlags = cfg.ExternalLighthouse.AdditionalVcFlags.Value.(string)
@@ -1109,9 +1133,85 @@ func (cfg *RocketPoolConfig) VcAdditionalFlags() (string, error) {
 		}
 		out = out + overrides.VcAdditionalFlags
 	}
+
+	// Point the VC at the remote signer for its keys and signatures
+	if cfg.EnableRemoteSigner.Value.(bool) {
+		signerFlags, err := cfg.vcRemoteSignerFlags(client)
+		if err != nil {
+			return "", err
+		}
+		if out != "" {
+			out = out + " "
+		}
+		out = out + signerFlags
+	}
+
+	// Run the keymanager API so the Smartnode can manage keys and fee recipients without restarting the VC
+	if cfg.ConsensusCommon.EnableKeymanagerApi.Value.(bool) {
+		keymanagerFlags := cfg.vcKeymanagerFlags(client)
+		if keymanagerFlags != "" {
+			if out != "" {
+				out = out + " "
+			}
+			out = out + keymanagerFlags
+		}
+	}
 	return out, nil
 }
 
+// Get the flags that make a VC run the keymanager API on the Docker network, or an empty string if it doesn't support it
+func (cfg *RocketPoolConfig) vcKeymanagerFlags(client config.ConsensusClient) string {
+	port := cfg.ConsensusCommon.KeymanagerPort.Value
+	tokenFile := filepath.Join("/validators", KeymanagerTokenFilename)
+	switch client {
+	case config.ConsensusClient_Lighthouse:
+		return fmt.Sprintf("--http --http-address=0.0.0.0 --http-port=%d --unencrypted-http-transport --http-token-path=%s", port, tokenFile)
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--keymanager --keymanager.address=0.0.0.0 --keymanager.port=%d --keymanager.tokenFile=%s", port, tokenFile)
+	case config.ConsensusClient_Nimbus:
+		return fmt.Sprintf("--keymanager --keymanager-address=0.0.0.0 --keymanager-port=%d --keymanager-token-file=%s", port, tokenFile)
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validator-api-enabled=true --validator-api-interface=0.0.0.0 --validator-api-port=%d --validator-api-host-allowlist=%s --validator-api-ssl-enabled=false --validator-api-bearer-file=%s", port, ValidatorContainerName, tokenFile)
+	default:
+		return ""
+	}
+}
+
+// Get the URL of the VC's keymanager API, or an empty string if the Smartnode should restart the VC instead
+func (cfg *RocketPoolConfig) GetVcKeymanagerUrl() string {
+	if cfg.IsNativeMode {
+		return cfg.Native.VcKeymanagerUrl.Value.(string)
+	}
+	if !cfg.ConsensusCommon.EnableKeymanagerApi.Value.(bool) {
+		return ""
+	}
+	client, _ := cfg.GetSelectedConsensusClient()
+	if cfg.vcKeymanagerFlags(client) == "" {
+		return ""
+	}
+	return fmt.Sprintf("http://%s:%d", ValidatorContainerName, cfg.ConsensusCommon.KeymanagerPort.Value)
+}
+
+// Get the flags that make a VC load its validator keys from the remote signer and sign with it
+func (cfg *RocketPoolConfig) vcRemoteSignerFlags(client config.ConsensusClient) (string, error) {
+	url := strings.TrimSuffix(cfg.RemoteSigner.Url.Value.(string), "/")
+	switch client {
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--externalSigner.url=%s --externalSigner.fetch", url), nil
+	case config.ConsensusClient_Nimbus:
+		return fmt.Sprintf("--web3-signer-url=%s", url), nil
+	case config.ConsensusClient_Prysm:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=%s/api/v1/eth2/publicKeys", url, url), nil
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validators-external-signer-url=%s --validators-external-signer-public-keys=external-signer", url), nil
+	case config.ConsensusClient_Lighthouse:
+		// Lighthouse reads the signer's keys from web3signer entries in its validator definitions file instead
+		return "", nil
+	default:
+		return "", fmt.Errorf("unknown consensus client [%v] selected", client)
+	}
+}
+
 // Used by text/template to format validator.yml
 func (cfg *RocketPoolConfig) FeeRecipientFile() string {
 	return FeeRecipientFilename
@@ -1363,6 +1463,18 @@ func (cfg *RocketPoolConfig) GetPrometheusOpenPorts() string {
 	return fmt.Sprintf("\"%s\"", portMode.DockerPortMapping(cfg.Prometheus.Port.Value.(uint16)))
 }
 
+// Used by text/template to format node.yml
+func (cfg *RocketPoolConfig) GetDashboardOpenPorts() string {
+	if cfg.Smartnode.EnableDashboard.Value != true {
+		return ""
+	}
+	portMode := cfg.Smartnode.OpenDashboardPort.Value.(config.RPCMode)
+	if !portMode.Open() {
+		return ""
+	}
+	return fmt.Sprintf("\"%s\"", portMode.DockerPortMapping(cfg.Smartnode.DashboardPort.Value.(uint16)))
+}
+
 // Used by text/template to format mev-boost.yml
 func (cfg *RocketPoolConfig) GetMevBoostOpenPorts() string {
 	portMode := cfg.MevBoost.OpenRpcPort.Value.(config.RPCMode)
@@ -1479,6 +1591,13 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// The remote signer needs somewhere to reach it
+	if !cfg.IsNativeMode && cfg.EnableRemoteSigner.Value == true {
+		if cfg.RemoteSigner.Url.Value.(string) == "" {
+			errors = append(errors, "You have the remote signer enabled but don't have a URL set. Please enter the remote signer's URL to use it.")
+		}
+	}
+
 	// Technically not required since native mode doesn't support addons, but defensively check to make sure a native mode
 	// user hasn't tried to configure the rescue node via the TUI
 	if cfg.RescueNode.GetEnabledParameter().Value.(bool) {
@@ -1517,6 +1636,9 @@ func (cfg *RocketPoolConfig) Validate() []string {
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.MevBoost.Port, errors)
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.Prometheus.Port, errors)
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.Alertmanager.Port, errors)
+	if cfg.Smartnode.EnableDashboard.Value == true {
+		portMap, errors = addAndCheckForDuplicate(portMap, cfg.Smartnode.DashboardPort, errors)
+	}
 	_, errors = addAndCheckForDuplicate(portMap, cfg.Lighthouse.P2pQuicPort, errors)
 
 	return errors
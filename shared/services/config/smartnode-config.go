@@ -12,34 +12,53 @@ import (
 
 // Constants
 const (
-	smartnodeTag                       string = "rocketpool/smartnode:v" + shared.RocketPoolVersion
-	pruneProvisionerTag                string = "rocketpool/eth1-prune-provision:v0.0.1"
-	ecMigratorTag                      string = "rocketpool/ec-migrator:v1.0.0"
-	NetworkID                          string = "network"
-	ProjectNameID                      string = "projectName"
-	SnapshotID                         string = "rocketpool-dao.eth"
-	RewardsTreeFilenameFormat          string = "rp-rewards-%s-%d.json"
-	MinipoolPerformanceFilenameFormat  string = "rp-minipool-performance-%s-%d.json"
-	RewardsTreeIpfsExtension           string = ".zst"
-	RewardsTreesFolder                 string = "rewards-trees"
-	ChecksumTableFilename              string = "checksums.sha384"
-	DaemonDataPath                     string = "/.rocketpool/data"
-	WatchtowerFolder                   string = "watchtower"
-	WatchtowerStateFile                string = "state.yml"
-	RegenerateRewardsTreeRequestSuffix string = ".request"
-	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
-	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
-	SecondaryRewardsFileUrl            string = "https://ipfs.io/ipfs/%s/%s"
-	GithubRewardsFileUrl               string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
-	FeeRecipientFilename               string = "rp-fee-recipient.txt"
-	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	smartnodeTag                        string = "rocketpool/smartnode:v" + shared.RocketPoolVersion
+	pruneProvisionerTag                 string = "rocketpool/eth1-prune-provision:v0.0.1"
+	ecMigratorTag                       string = "rocketpool/ec-migrator:v1.0.0"
+	NetworkID                           string = "network"
+	ProjectNameID                       string = "projectName"
+	SnapshotID                          string = "rocketpool-dao.eth"
+	RewardsTreeFilenameFormat           string = "rp-rewards-%s-%d.json"
+	MinipoolPerformanceFilenameFormat   string = "rp-minipool-performance-%s-%d.json"
+	RewardsTreeIpfsExtension            string = ".zst"
+	RewardsTreesFolder                  string = "rewards-trees"
+	ChecksumTableFilename               string = "checksums.sha384"
+	DaemonDataPath                      string = "/.rocketpool/data"
+	WatchtowerFolder                    string = "watchtower"
+	WatchtowerStateFile                 string = "state.yml"
+	RegenerateRewardsTreeRequestSuffix  string = ".request"
+	RegenerateRewardsTreeRequestFormat  string = "%d" + RegenerateRewardsTreeRequestSuffix
+	VerifyRewardsTreeRequestSuffix      string = ".verify"
+	VerifyRewardsTreeRequestFormat      string = "%d" + VerifyRewardsTreeRequestSuffix
+	RewardsTreeVerificationFormat       string = "rp-rewards-verification-%s-%d.json"
+	RecordRewardsFixtureRequestSuffix   string = ".record"
+	RecordRewardsFixtureRequestFormat   string = "%d" + RecordRewardsFixtureRequestSuffix
+	RewardsFixtureFormat                string = "rp-rewards-fixture-%s-%d.json.zst"
+	PrimaryRewardsFileUrl               string = "https://%s.ipfs.dweb.link/%s"
+	SecondaryRewardsFileUrl             string = "https://ipfs.io/ipfs/%s/%s"
+	GithubRewardsFileUrl                string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
+	FeeRecipientFilename                string = "rp-fee-recipient.txt"
+	NativeFeeRecipientFilename          string = "rp-fee-recipient-env.txt"
+	KeymanagerTokenFilename             string = "keymanager-token.txt"
+	ApiSocketFilename                   string = "api.sock"
+	WalletUnlockSocketFormat            string = "unlock-%s.sock"
+	PendingTxFolder                     string = "pending-txs"
+	HistoryFolder                       string = "history"
+	DashboardTokenFilename              string = "dashboard-token.txt"
+	SlashingProtectionFolder            string = "slashing-protection"
+	DeletedKeysSlashingProtectionFormat string = "deleted-keys-%d.json"
 )
 
+// The long-running processes that hold the node wallet password in memory if it isn't saved to disk
+var WalletUnlockProcesses = []string{"api", "node", "watchtower"}
+
 // Defaults
 const (
 	defaultProjectName       string = "rocketpool"
 	WatchtowerMaxFeeDefault  uint64 = 200
 	WatchtowerPrioFeeDefault uint64 = 3
+	defaultDashboardPort     uint16 = 9110
+	defaultTreeGenWorkers    uint64 = 4
 )
 
 // Configuration for the Smartnode
@@ -65,6 +84,9 @@ type SmartnodeConfig struct {
 	// Which network we're on
 	Network config.Parameter `yaml:"network,omitempty"`
 
+	// Toggle for saving the node wallet password to disk
+	SaveWalletPassword config.Parameter `yaml:"saveWalletPassword,omitempty"`
+
 	// Manual max fee override
 	ManualMaxFee config.Parameter `yaml:"manualMaxFee,omitempty"`
 
@@ -74,9 +96,36 @@ type SmartnodeConfig struct {
 	// Threshold for automatic transactions
 	AutoTxGasThreshold config.Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
+	// How long to wait for a transaction to be included before replacing it with higher fees
+	StuckTxTimeout config.Parameter `yaml:"stuckTxTimeout,omitempty"`
+
+	// The highest max fee stuck transactions can be replaced with
+	StuckTxMaxFee config.Parameter `yaml:"stuckTxMaxFee,omitempty"`
+
+	// How many days of node and minipool history to keep
+	HistoryRetentionDays config.Parameter `yaml:"historyRetentionDays,omitempty"`
+
+	// Toggle for the node daemon's web dashboard
+	EnableDashboard config.Parameter `yaml:"enableDashboard,omitempty"`
+
+	// The port to serve the web dashboard on
+	DashboardPort config.Parameter `yaml:"dashboardPort,omitempty"`
+
+	// Toggle for forwarding the web dashboard port outside of Docker
+	OpenDashboardPort config.Parameter `yaml:"openDashboardPort,omitempty"`
+
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// The amount of unclaimed RPL rewards before auto-claim kicks in
+	AutoClaimRplThreshold config.Parameter `yaml:"autoClaimRplThreshold,omitempty"`
+
+	// The amount of unclaimed Smoothing Pool ETH rewards before auto-claim kicks in
+	AutoClaimEthThreshold config.Parameter `yaml:"autoClaimEthThreshold,omitempty"`
+
+	// The percentage of automatically claimed RPL to restake
+	AutoClaimRestakePercent config.Parameter `yaml:"autoClaimRestakePercent,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
@@ -89,6 +138,9 @@ type SmartnodeConfig struct {
 	// URL for an EC with archive mode, for manual rewards tree generation
 	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
 
+	// The number of epochs to fetch from the Beacon node in parallel during rewards tree generation
+	TreeGenerationWorkers config.Parameter `yaml:"treeGenerationWorkers,omitempty"`
+
 	// Manual override for the watchtower's max fee
 	WatchtowerMaxFeeOverride config.Parameter `yaml:"watchtowerMaxFeeOverride,omitempty"`
 
@@ -304,6 +356,84 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		StuckTxTimeout: config.Parameter{
+			ID:                 "stuckTxTimeout",
+			Name:               "Stuck TX Timeout",
+			Description:        "The number of minutes the node and watchtower will wait for one of their transactions to be included in a block before considering it stuck. A stuck transaction will be replaced with a copy that has higher fees, and this will repeat until it's included or the fees reach the Stuck TX Max Fee.\n\nSet this to 0 to never replace stuck transactions.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(10)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		StuckTxMaxFee: config.Parameter{
+			ID:                 "stuckTxMaxFee",
+			Name:               "Stuck TX Max Fee",
+			Description:        "The highest max fee (in gwei) that a stuck transaction can be replaced with. Once a transaction's fees reach this limit, the Smartnode will stop replacing it and wait for the network's fees to come down.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(200)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		HistoryRetentionDays: config.Parameter{
+			ID:                 "historyRetentionDays",
+			Name:               "History Retention",
+			Description:        "The number of days of history the node daemon keeps for your node and minipools (RPL stake, collateral ratios, rewards and Beacon Chain balances), which you can view with `rocketpool node history`. It records a sample every few minutes in a compact local store, so it works without the Grafana stack.\n\nSet this to 0 to stop recording history.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(365)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableDashboard: config.Parameter{
+			ID:                 "enableDashboard",
+			Name:               "Enable Web Dashboard",
+			Description:        "Enable a read-only web dashboard served by the node daemon. It shows your node's status, minipools, rewards, Smoothing Pool status, pending Protocol DAO proposals, active alerts and the health of your clients, without needing Grafana and Prometheus.\n\nThe dashboard is protected by a generated token; run `rocketpool node dashboard` to get its address.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		DashboardPort: config.Parameter{
+			ID:                 "dashboardPort",
+			Name:               "Web Dashboard Port",
+			Description:        "The port the node daemon should serve the web dashboard on.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultDashboardPort},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		OpenDashboardPort: config.Parameter{
+			ID:                 "openDashboardPort",
+			Name:               "Expose Web Dashboard Port",
+			Description:        "Expose the web dashboard's port to other processes on your machine, or to your local network so other machines can access it too.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.RPC_OpenLocalhost},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options:            config.PortModes("Allow connections from external hosts. The dashboard is read-only and protected by a token, but it is served over plain HTTP and shows your node's details. Only do this on a network you trust, and never on a VPS without a firewall."),
+		},
+
+		SaveWalletPassword: config.Parameter{
+			ID:                 "saveWalletPassword",
+			Name:               "Save Wallet Password",
+			Description:        "Enable this to save the node wallet's password in the data folder, so the Smartnode can use the wallet as soon as it starts.\n\nDisable it to keep the password off of the disk. The node, watchtower, and API processes will start locked, and will only hold the password in memory once you run `rocketpool wallet unlock`. You will need to unlock them again every time they restart; until then, the Smartnode will not be able to submit any transactions.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		DistributeThreshold: config.Parameter{
 			ID:                 "distributeThreshold",
 			Name:               "Auto-Distribute Threshold",
@@ -315,6 +445,39 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		AutoClaimRplThreshold: config.Parameter{
+			ID:                 "autoClaimRplThreshold",
+			Name:               "Auto-Claim RPL Threshold",
+			Description:        "The Smartnode will regularly check your unclaimed rewards from past intervals.\nIf the RPL you can claim is at least this amount, the Smartnode will automatically claim all of your unclaimed rewards, ETH included. It follows the Automatic TX Gas Threshold, so this is disabled if that is 0.\n\nSet this to 0 to not claim automatically based on RPL.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimEthThreshold: config.Parameter{
+			ID:                 "autoClaimEthThreshold",
+			Name:               "Auto-Claim ETH Threshold",
+			Description:        "The Smartnode will regularly check your unclaimed rewards from past intervals.\nIf the Smoothing Pool ETH you can claim is at least this amount, the Smartnode will automatically claim all of your unclaimed rewards, RPL included. It follows the Automatic TX Gas Threshold, so this is disabled if that is 0.\n\nSet this to 0 to not claim automatically based on ETH.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimRestakePercent: config.Parameter{
+			ID:                 "autoClaimRestakePercent",
+			Name:               "Auto-Claim Restake Percent",
+			Description:        "The percentage (0 to 100) of the RPL rewards that the Smartnode should restake when it claims automatically. The rest will be sent to your withdrawal address.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		VerifyProposals: config.Parameter{
 			ID:                 "verifyProposals",
 			Name:               "Enable PDAO Proposal Checker",
@@ -392,6 +555,17 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		TreeGenerationWorkers: config.Parameter{
+			ID:                 "treeGenerationWorkers",
+			Name:               "Tree Generation Workers",
+			Description:        "[orange]**For Merkle rewards tree generation only.**[white]\n\nThe number of epochs to fetch from your Beacon node at the same time while checking attestation performance during rewards tree generation. Epochs are still processed in order, so this doesn't change the resulting tree.\n\nHigher values make generation faster but use more memory and put more load on your Beacon node. Set this to 1 to fetch one epoch at a time.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultTreeGenWorkers},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		WatchtowerMaxFeeOverride: config.Parameter{
 			ID:                 "watchtowerMaxFeeOverride",
 			Name:               "Watchtower Max Fee Override",
@@ -672,16 +846,27 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.Network,
 		&cfg.ProjectName,
 		&cfg.DataPath,
+		&cfg.SaveWalletPassword,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
 		&cfg.AutoTxGasThreshold,
+		&cfg.StuckTxTimeout,
+		&cfg.StuckTxMaxFee,
+		&cfg.HistoryRetentionDays,
+		&cfg.EnableDashboard,
+		&cfg.DashboardPort,
+		&cfg.OpenDashboardPort,
 		&cfg.DistributeThreshold,
+		&cfg.AutoClaimRplThreshold,
+		&cfg.AutoClaimEthThreshold,
+		&cfg.AutoClaimRestakePercent,
 		&cfg.VerifyProposals,
 		&cfg.AutoInitVPThreshold,
 		&cfg.RewardsTreeMode,
 		&cfg.PriceBalanceSubmissionReferenceTimestamp,
 		&cfg.RewardsTreeCustomUrl,
 		&cfg.ArchiveECUrl,
+		&cfg.TreeGenerationWorkers,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
 		&cfg.UseRollingRecords,
@@ -737,6 +922,14 @@ func (cfg *SmartnodeConfig) GetRecordsPath() string {
 	return filepath.Join(DaemonDataPath, "records")
 }
 
+func (cfg *SmartnodeConfig) GetRewardsCheckpointPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "rewards-checkpoints")
+	}
+
+	return filepath.Join(DaemonDataPath, "rewards-checkpoints")
+}
+
 func (cfg *SmartnodeConfig) GetVotingPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "voting", string(cfg.Network.Value.(config.Network)))
@@ -745,6 +938,22 @@ func (cfg *SmartnodeConfig) GetVotingPath() string {
 	return filepath.Join(DaemonDataPath, "voting", string(cfg.Network.Value.(config.Network)))
 }
 
+func (cfg *SmartnodeConfig) GetApiSocketPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ApiSocketFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, ApiSocketFilename)
+}
+
+func (cfg *SmartnodeConfig) GetWalletUnlockSocketPath(process string) string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), fmt.Sprintf(WalletUnlockSocketFormat, process))
+	}
+
+	return filepath.Join(DaemonDataPath, fmt.Sprintf(WalletUnlockSocketFormat, process))
+}
+
 func (cfg *SmartnodeConfig) GetWalletPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "wallet")
 }
@@ -757,6 +966,14 @@ func (cfg *SmartnodeConfig) GetValidatorKeychainPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators")
 }
 
+func (cfg *SmartnodeConfig) GetApiSocketPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), ApiSocketFilename)
+}
+
+func (cfg *SmartnodeConfig) GetWalletUnlockSocketPathInCLI(process string) string {
+	return filepath.Join(cfg.DataPath.Value.(string), fmt.Sprintf(WalletUnlockSocketFormat, process))
+}
+
 func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	if config.parent.IsNativeMode {
 		return filepath.Join(config.DataPath.Value.(string), WatchtowerFolder, "state.yml")
@@ -862,6 +1079,38 @@ func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64,
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
 }
 
+func (cfg *SmartnodeConfig) GetVerifyRewardsTreeRequestPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(VerifyRewardsTreeRequestFormat, interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(VerifyRewardsTreeRequestFormat, interval))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsTreeVerificationPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsTreeVerificationFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsTreeVerificationFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+func (cfg *SmartnodeConfig) GetRecordRewardsFixtureRequestPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RecordRewardsFixtureRequestFormat, interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RecordRewardsFixtureRequestFormat, interval))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsFixturePath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsFixtureFormat, string(cfg.Network.Value.(config.Network)), interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsFixtureFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
 func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder)
@@ -870,6 +1119,14 @@ func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder)
 }
 
+func (cfg *SmartnodeConfig) GetSlashingProtectionFolder(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, SlashingProtectionFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionFolder)
+}
+
 func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	if !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, "validators", FeeRecipientFilename)
@@ -878,6 +1135,38 @@ func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators", NativeFeeRecipientFilename)
 }
 
+func (cfg *SmartnodeConfig) GetKeymanagerTokenPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", KeymanagerTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), "validators", KeymanagerTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) GetPendingTxPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, PendingTxFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), PendingTxFolder)
+}
+
+func (cfg *SmartnodeConfig) GetHistoryPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, HistoryFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), HistoryFolder)
+}
+
+func (cfg *SmartnodeConfig) GetDashboardTokenPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, DashboardTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), DashboardTokenFilename)
+}
+
 func (cfg *SmartnodeConfig) GetV100RewardsPoolAddress() common.Address {
 	return common.HexToAddress(cfg.v1_0_0_RewardsPoolAddress[cfg.Network.Value.(config.Network)])
 }
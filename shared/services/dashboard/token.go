@@ -0,0 +1,70 @@
+package dashboard
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	tokenFileMode os.FileMode = 0600
+	tokenDirMode  os.FileMode = 0700
+)
+
+// Create the web dashboard's access token if it doesn't exist yet
+func CreateTokenFile(tokenPath string) error {
+	_, err := os.Stat(tokenPath)
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("error checking dashboard token file [%s]: %w", tokenPath, err)
+	}
+	_, err = ResetTokenFile(tokenPath)
+	return err
+}
+
+// Replace the web dashboard's access token with a new one, which invalidates every session that used the old one
+func ResetTokenFile(tokenPath string) (string, error) {
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", fmt.Errorf("error generating dashboard token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	err = os.MkdirAll(filepath.Dir(tokenPath), tokenDirMode)
+	if err != nil {
+		return "", fmt.Errorf("error creating folder for dashboard token file [%s]: %w", tokenPath, err)
+	}
+	err = os.WriteFile(tokenPath, []byte(token), tokenFileMode)
+	if err != nil {
+		return "", fmt.Errorf("error writing dashboard token file [%s]: %w", tokenPath, err)
+	}
+	return token, nil
+}
+
+// Load the web dashboard's access token
+func LoadToken(tokenPath string) (string, error) {
+	bytes, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading dashboard token file [%s]: %w", tokenPath, err)
+	}
+	token := strings.TrimSpace(string(bytes))
+	if token == "" {
+		return "", fmt.Errorf("dashboard token file [%s] is empty", tokenPath)
+	}
+	return token, nil
+}
+
+// Check a token provided by a client against the dashboard's token in constant time
+func CheckToken(tokenPath string, provided string) bool {
+	token, err := LoadToken(tokenPath)
+	if err != nil || provided == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(provided)) == 1
+}
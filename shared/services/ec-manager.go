@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/fatih/color"
@@ -29,6 +30,8 @@ type ExecutionClientManager struct {
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+	dryRun          bool
+	unsigned        bool
 }
 
 // This is a signature for a wrapped ethclient.Client function
@@ -206,7 +209,15 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 }
 
 // SendTransaction injects the transaction into the pending pool for execution.
+// In dry-run mode, it simulates the transaction instead and returns the result as an *api.DryRunError.
+// In unsigned mode, it returns the unsigned transaction as an *api.UnsignedTransactionError so it can be signed offline.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if p.dryRun {
+		return p.simulateTransaction(ctx, tx)
+	}
+	if p.unsigned {
+		return p.exportUnsignedTransaction(ctx, tx)
+	}
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
@@ -332,6 +343,15 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 /// Internal functions
 /// ==================
 
+// Reset the client readiness flags to the state a freshly created manager would have for a call with the provided flags
+func (p *ExecutionClientManager) applyCallFlags(ignoreSyncCheck bool, forceFallbacks bool, dryRun bool, unsigned bool) {
+	p.ignoreSyncCheck = ignoreSyncCheck
+	p.dryRun = dryRun
+	p.unsigned = unsigned
+	p.primaryReady = !forceFallbacks
+	p.fallbackReady = p.fallbackEc != nil
+}
+
 func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
@@ -404,6 +424,13 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 		status.NetworkId = uint(networkId.Uint64())
 	}
 
+	// Get the peer count; providers often disable the net namespace, so it's optional
+	var peerCount hexutil.Uint64
+	if err := client.Client().CallContext(context.Background(), &peerCount, "net_peerCount"); err == nil {
+		count := uint64(peerCount)
+		status.PeerCount = &count
+	}
+
 	// Get the fallback's sync progress
 	progress, err := client.SyncProgress(context.Background())
 	if err != nil {
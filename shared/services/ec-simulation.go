@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/storage"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The Rocket Pool contracts whose return values and events can be decoded in a transaction simulation
+var simulationContractNames = []string{
+	"rocketAuctionManager",
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedProposals",
+	"rocketDAOProtocolProposal",
+	"rocketDAOProtocolProposals",
+	"rocketDAOProtocolVerifier",
+	"rocketDepositPool",
+	"rocketMerkleDistributorMainnet",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketNetworkBalances",
+	"rocketNetworkPrices",
+	"rocketNetworkVoting",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketSmoothingPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketTokenRPLFixedSupply",
+	"rocketVault",
+}
+
+// A contract that a simulated transaction might interact with
+type simulationContract struct {
+	label string
+	abi   *abi.ABI
+}
+
+// A call frame from the callTracer
+type simulationCallFrame struct {
+	Logs []struct {
+		Address common.Address `json:"address"`
+		Topics  []common.Hash  `json:"topics"`
+		Data    hexutil.Bytes  `json:"data"`
+	} `json:"logs"`
+	Calls []simulationCallFrame `json:"calls"`
+}
+
+// The state diff from the prestateTracer
+type simulationStateDiff struct {
+	Pre  map[common.Address]simulationAccount `json:"pre"`
+	Post map[common.Address]simulationAccount `json:"post"`
+}
+type simulationAccount struct {
+	Balance *hexutil.Big `json:"balance"`
+}
+
+// Simulate a transaction at the pending block instead of submitting it
+func (p *ExecutionClientManager) simulateTransaction(ctx context.Context, tx *types.Transaction) error {
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("error getting transaction sender: %w", err)
+	}
+	simulation := api.TransactionSimulation{
+		From:           from,
+		To:             tx.To(),
+		Value:          tx.Value(),
+		GasLimit:       tx.Gas(),
+		BalanceChanges: []api.SimulatedBalanceChange{},
+		Events:         []api.SimulatedEvent{},
+	}
+	contracts := getSimulationContracts(from, tx.To())
+
+	// Run the call at the pending block
+	msg := ethereum.CallMsg{
+		From:      from,
+		To:        tx.To(),
+		Gas:       tx.Gas(),
+		GasFeeCap: tx.GasFeeCap(),
+		GasTipCap: tx.GasTipCap(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.PendingCallContract(ctx, msg)
+	})
+	if err != nil {
+		simulation.Reverted = true
+		simulation.RevertReason = getSimulationRevertReason(err)
+	} else {
+		simulation.ReturnData = result.([]byte)
+	}
+
+	// Decode the method and its return values
+	if tx.To() != nil {
+		contract, exists := contracts[*tx.To()]
+		if exists {
+			simulation.ToLabel = contract.label
+			if contract.abi != nil && len(tx.Data()) >= 4 {
+				method, err := contract.abi.MethodById(tx.Data()[:4])
+				if err == nil {
+					simulation.Method = method.Name
+					if !simulation.Reverted && len(simulation.ReturnData) > 0 {
+						values, err := method.Outputs.Unpack(simulation.ReturnData)
+						if err == nil {
+							for _, value := range values {
+								simulation.ReturnValues = append(simulation.ReturnValues, formatSimulationValue(value))
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Trace the call for its balance changes and events; not every client supports this
+	if !simulation.Reverted {
+		err = p.traceSimulation(ctx, msg, contracts, &simulation)
+		if err != nil {
+			simulation.TraceError = err.Error()
+		}
+	}
+
+	return &api.DryRunError{
+		Simulation: simulation,
+	}
+
+}
+
+// Get the balance changes and events of a simulated transaction with debug_traceCall
+func (p *ExecutionClientManager) traceSimulation(ctx context.Context, msg ethereum.CallMsg, contracts map[common.Address]simulationContract, simulation *api.TransactionSimulation) error {
+
+	callArgs := map[string]interface{}{
+		"from":                 msg.From,
+		"gas":                  hexutil.Uint64(msg.Gas),
+		"maxFeePerGas":         (*hexutil.Big)(msg.GasFeeCap),
+		"maxPriorityFeePerGas": (*hexutil.Big)(msg.GasTipCap),
+		"value":                (*hexutil.Big)(msg.Value),
+		"input":                hexutil.Bytes(msg.Data),
+	}
+	if msg.To != nil {
+		callArgs["to"] = *msg.To
+	}
+
+	// Get the balance changes
+	var stateDiff simulationStateDiff
+	err := p.traceCall(ctx, &stateDiff, callArgs, map[string]interface{}{
+		"tracer":       "prestateTracer",
+		"tracerConfig": map[string]interface{}{"diffMode": true},
+	})
+	if err != nil {
+		return err
+	}
+	addresses := map[common.Address]bool{}
+	for address := range stateDiff.Pre {
+		addresses[address] = true
+	}
+	for address := range stateDiff.Post {
+		addresses[address] = true
+	}
+	for address := range addresses {
+		contract, exists := contracts[address]
+		if !exists {
+			continue
+		}
+		before := big.NewInt(0)
+		if pre, exists := stateDiff.Pre[address]; exists && pre.Balance != nil {
+			before = pre.Balance.ToInt()
+		}
+		after := before
+		if post, exists := stateDiff.Post[address]; exists && post.Balance != nil {
+			after = post.Balance.ToInt()
+		}
+		if before.Cmp(after) == 0 {
+			continue
+		}
+		simulation.BalanceChanges = append(simulation.BalanceChanges, api.SimulatedBalanceChange{
+			Address: address,
+			Label:   contract.label,
+			Before:  before,
+			After:   after,
+		})
+	}
+
+	// Get the events
+	var callFrame simulationCallFrame
+	err = p.traceCall(ctx, &callFrame, callArgs, map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	})
+	if err != nil {
+		return err
+	}
+	addSimulationEvents(callFrame, contracts, simulation)
+	return nil
+
+}
+
+// Run debug_traceCall at the pending block, falling back to the latest block for clients that can't trace pending state
+func (p *ExecutionClientManager) traceCall(ctx context.Context, result interface{}, callArgs map[string]interface{}, tracerConfig map[string]interface{}) error {
+	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		err := client.Client().CallContext(ctx, result, "debug_traceCall", callArgs, "pending", tracerConfig)
+		if err != nil {
+			err = client.Client().CallContext(ctx, result, "debug_traceCall", callArgs, "latest", tracerConfig)
+		}
+		return nil, err
+	})
+	if err != nil {
+		return fmt.Errorf("error tracing transaction: %w", err)
+	}
+	return nil
+}
+
+// Add the events from a call frame and its subcalls to a simulation
+func addSimulationEvents(callFrame simulationCallFrame, contracts map[common.Address]simulationContract, simulation *api.TransactionSimulation) {
+
+	for _, log := range callFrame.Logs {
+		event := api.SimulatedEvent{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+		}
+		contract, exists := contracts[log.Address]
+		if exists {
+			event.Contract = contract.label
+			if contract.abi != nil && len(log.Topics) > 0 {
+				eventAbi, err := contract.abi.EventByID(log.Topics[0])
+				if err == nil {
+					event.Name = eventAbi.Name
+					args := map[string]interface{}{}
+					var indexed abi.Arguments
+					for _, input := range eventAbi.Inputs {
+						if input.Indexed {
+							indexed = append(indexed, input)
+						}
+					}
+					if abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]) == nil && contract.abi.UnpackIntoMap(args, eventAbi.Name, log.Data) == nil {
+						event.Arguments = map[string]string{}
+						for name, value := range args {
+							event.Arguments[name] = formatSimulationValue(value)
+						}
+					}
+				}
+			}
+		}
+		simulation.Events = append(simulation.Events, event)
+	}
+
+	for _, call := range callFrame.Calls {
+		addSimulationEvents(call, contracts, simulation)
+	}
+
+}
+
+// Get the labels and ABIs of the addresses a simulated transaction from the node might interact with
+func getSimulationContracts(from common.Address, to *common.Address) map[common.Address]simulationContract {
+
+	contracts := map[common.Address]simulationContract{}
+	if to != nil {
+		contracts[*to] = simulationContract{label: "recipient"}
+	}
+	if rocketPool == nil {
+		contracts[from] = simulationContract{label: "node"}
+		return contracts
+	}
+
+	// Rocket Pool contracts
+	for _, name := range simulationContractNames {
+		address, err := rocketPool.GetAddress(name, nil)
+		if err != nil || address == nil || *address == (common.Address{}) {
+			continue
+		}
+		contractAbi, err := rocketPool.GetABI(name, nil)
+		if err != nil {
+			contractAbi = nil
+		}
+		contracts[*address] = simulationContract{label: name, abi: contractAbi}
+	}
+
+	// Minipools
+	delegateAbi, err := rocketPool.GetABI("rocketMinipoolDelegate", nil)
+	if err != nil {
+		delegateAbi = nil
+	}
+	minipoolAddresses, err := minipool.GetNodeMinipoolAddresses(rocketPool, from, nil)
+	if err == nil {
+		for _, address := range minipoolAddresses {
+			contracts[address] = simulationContract{label: "minipool", abi: delegateAbi}
+		}
+	}
+
+	// Withdrawal addresses; these override any labels above, since they're the ones the node cares about the most
+	withdrawalAddress, err := storage.GetNodeWithdrawalAddress(rocketPool, from, nil)
+	if err == nil && withdrawalAddress != from {
+		contracts[withdrawalAddress] = simulationContract{label: "primary withdrawal address"}
+	}
+	rplWithdrawalAddress, err := node.GetNodeRPLWithdrawalAddress(rocketPool, from, nil)
+	if err == nil && rplWithdrawalAddress != from && rplWithdrawalAddress != (common.Address{}) {
+		contracts[rplWithdrawalAddress] = simulationContract{label: "RPL withdrawal address"}
+	}
+	contracts[from] = simulationContract{label: "node"}
+
+	return contracts
+
+}
+
+// Get the reason a simulated call reverted
+func getSimulationRevertReason(err error) string {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			reason, unpackErr := abi.UnpackRevert(common.FromHex(data))
+			if unpackErr == nil {
+				return reason
+			}
+		}
+	}
+	return err.Error()
+}
+
+// Format a decoded value for display
+func formatSimulationValue(value interface{}) string {
+	switch value := value.(type) {
+	case common.Address:
+		return value.Hex()
+	case common.Hash:
+		return value.Hex()
+	case [32]byte:
+		return hexutil.Encode(value[:])
+	case []byte:
+		return hexutil.Encode(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Return a transaction built by the node wallet's unsigned transactor instead of submitting it, so it can be signed on an offline machine
+func (p *ExecutionClientManager) exportUnsignedTransaction(ctx context.Context, tx *types.Transaction) error {
+
+	// The transaction was never signed, so the sender comes from the transactor instead of the signature
+	from, ok := wallet.GetUnsignedSender(ctx)
+	if !ok {
+		return errors.New("the transaction wasn't built by the node wallet's unsigned transactor, so it can't be exported")
+	}
+
+	// Normalize the transaction to the EIP-1559 format that `wallet sign-tx` expects
+	unsignedTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    tx.ChainId(),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  tx.GasTipCap(),
+		GasFeeCap:  tx.GasFeeCap(),
+		Gas:        tx.Gas(),
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	})
+	payload, err := unsignedTx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error serializing unsigned transaction: %w", err)
+	}
+
+	return &api.UnsignedTransactionError{
+		Transaction: api.UnsignedTransaction{
+			Version:        api.OfflineTransactionVersion,
+			ChainID:        unsignedTx.ChainId(),
+			From:           from,
+			To:             unsignedTx.To(),
+			Nonce:          unsignedTx.Nonce(),
+			Value:          unsignedTx.Value(),
+			Data:           unsignedTx.Data(),
+			GasLimit:       unsignedTx.Gas(),
+			MaxFee:         unsignedTx.GasFeeCap(),
+			MaxPriorityFee: unsignedTx.GasTipCap(),
+			Payload:        payload,
+		},
+	}
+
+}
@@ -0,0 +1,211 @@
+package fixture
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// A committee captured from the Beacon node
+type recordedCommittee struct {
+	Index      uint64   `json:"index"`
+	Slot       uint64   `json:"slot"`
+	Validators []string `json:"validators"`
+}
+
+// Committees replayed from a fixture
+type recordedCommittees []recordedCommittee
+
+func (c recordedCommittees) Index(idx int) uint64        { return c[idx].Index }
+func (c recordedCommittees) Slot(idx int) uint64         { return c[idx].Slot }
+func (c recordedCommittees) Validators(idx int) []string { return c[idx].Validators }
+func (c recordedCommittees) Count() int                  { return len(c) }
+func (c recordedCommittees) Release()                    {}
+
+// A validator status keyed by its pubkey, since pubkeys can't be used as JSON map keys
+type recordedValidatorStatus struct {
+	Pubkey types.ValidatorPubkey  `json:"pubkey"`
+	Status beacon.ValidatorStatus `json:"status"`
+}
+
+// A Beacon client that records the responses of a live client into a fixture, or replays them if there is no live client
+type BeaconClient struct {
+	fixture *Fixture
+	client  beacon.Client
+}
+
+// Create a new Beacon client for the fixture. If client is nil, responses are replayed from the fixture.
+func NewBeaconClient(fixture *Fixture, client beacon.Client) *BeaconClient {
+	return &BeaconClient{
+		fixture: fixture,
+		client:  client,
+	}
+}
+
+func (c *BeaconClient) GetClientType() (beacon.BeaconClientType, error) {
+	return call(c.fixture, c.client != nil, "GetClientType", nil, func() (beacon.BeaconClientType, error) {
+		return c.client.GetClientType()
+	})
+}
+
+func (c *BeaconClient) GetSyncStatus() (beacon.SyncStatus, error) {
+	return call(c.fixture, c.client != nil, "GetSyncStatus", nil, func() (beacon.SyncStatus, error) {
+		return c.client.GetSyncStatus()
+	})
+}
+
+func (c *BeaconClient) GetNodePeerCount() (uint64, error) {
+	return call(c.fixture, c.client != nil, "GetNodePeerCount", nil, func() (uint64, error) {
+		return c.client.GetNodePeerCount()
+	})
+}
+
+func (c *BeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	return call(c.fixture, c.client != nil, "GetEth2Config", nil, func() (beacon.Eth2Config, error) {
+		return c.client.GetEth2Config()
+	})
+}
+
+func (c *BeaconClient) GetEth2DepositContract() (beacon.Eth2DepositContract, error) {
+	return call(c.fixture, c.client != nil, "GetEth2DepositContract", nil, func() (beacon.Eth2DepositContract, error) {
+		return c.client.GetEth2DepositContract()
+	})
+}
+
+func (c *BeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	return call2(c.fixture, c.client != nil, "GetAttestations", []any{blockId}, func() ([]beacon.AttestationInfo, bool, error) {
+		return c.client.GetAttestations(blockId)
+	})
+}
+
+func (c *BeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	return call2(c.fixture, c.client != nil, "GetBeaconBlock", []any{blockId}, func() (beacon.BeaconBlock, bool, error) {
+		return c.client.GetBeaconBlock(blockId)
+	})
+}
+
+func (c *BeaconClient) GetBeaconBlockHeader(blockId string) (beacon.BeaconBlockHeader, bool, error) {
+	return call2(c.fixture, c.client != nil, "GetBeaconBlockHeader", []any{blockId}, func() (beacon.BeaconBlockHeader, bool, error) {
+		return c.client.GetBeaconBlockHeader(blockId)
+	})
+}
+
+func (c *BeaconClient) GetBeaconHead() (beacon.BeaconHead, error) {
+	return call(c.fixture, c.client != nil, "GetBeaconHead", nil, func() (beacon.BeaconHead, error) {
+		return c.client.GetBeaconHead()
+	})
+}
+
+func (c *BeaconClient) GetValidatorStatusByIndex(index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	return call(c.fixture, c.client != nil, "GetValidatorStatusByIndex", []any{index, opts}, func() (beacon.ValidatorStatus, error) {
+		return c.client.GetValidatorStatusByIndex(index, opts)
+	})
+}
+
+func (c *BeaconClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	return call(c.fixture, c.client != nil, "GetValidatorStatus", []any{pubkey, opts}, func() (beacon.ValidatorStatus, error) {
+		return c.client.GetValidatorStatus(pubkey, opts)
+	})
+}
+
+func (c *BeaconClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	statuses, err := call(c.fixture, c.client != nil, "GetValidatorStatuses", []any{pubkeys, opts}, func() ([]recordedValidatorStatus, error) {
+		statusMap, err := c.client.GetValidatorStatuses(pubkeys, opts)
+		if err != nil {
+			return nil, err
+		}
+		statuses := make([]recordedValidatorStatus, 0, len(pubkeys))
+		for _, pubkey := range pubkeys {
+			status, exists := statusMap[pubkey]
+			if exists {
+				statuses = append(statuses, recordedValidatorStatus{Pubkey: pubkey, Status: status})
+			}
+		}
+		return statuses, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statusMap := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(statuses))
+	for _, status := range statuses {
+		statusMap[status.Pubkey] = status.Status
+	}
+	return statusMap, nil
+}
+
+func (c *BeaconClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
+	return call(c.fixture, c.client != nil, "GetValidatorIndex", []any{pubkey}, func() (string, error) {
+		return c.client.GetValidatorIndex(pubkey)
+	})
+}
+
+func (c *BeaconClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
+	return call(c.fixture, c.client != nil, "GetValidatorSyncDuties", []any{indices, epoch}, func() (map[string]bool, error) {
+		return c.client.GetValidatorSyncDuties(indices, epoch)
+	})
+}
+
+func (c *BeaconClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
+	return call(c.fixture, c.client != nil, "GetValidatorProposerDuties", []any{indices, epoch}, func() (map[string]uint64, error) {
+		return c.client.GetValidatorProposerDuties(indices, epoch)
+	})
+}
+
+func (c *BeaconClient) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	return call(c.fixture, c.client != nil, "GetDomainData", []any{domainType, epoch, useGenesisFork}, func() ([]byte, error) {
+		return c.client.GetDomainData(domainType, epoch, useGenesisFork)
+	})
+}
+
+func (c *BeaconClient) ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error {
+	if c.client == nil {
+		return ErrReadOnly
+	}
+	return c.client.ExitValidator(validatorIndex, epoch, signature)
+}
+
+func (c *BeaconClient) Close() error {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Close()
+}
+
+func (c *BeaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	return call2(c.fixture, c.client != nil, "GetEth1DataForEth2Block", []any{blockId}, func() (beacon.Eth1Data, bool, error) {
+		return c.client.GetEth1DataForEth2Block(blockId)
+	})
+}
+
+func (c *BeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	committees, err := call(c.fixture, c.client != nil, "GetCommitteesForEpoch", []any{epoch}, func() (recordedCommittees, error) {
+		committees, err := c.client.GetCommitteesForEpoch(epoch)
+		if err != nil {
+			return nil, err
+		}
+		defer committees.Release()
+
+		// Copy the committees since the live client reuses its buffers
+		recorded := make(recordedCommittees, committees.Count())
+		for i := range recorded {
+			recorded[i] = recordedCommittee{
+				Index:      committees.Index(i),
+				Slot:       committees.Slot(i),
+				Validators: append([]string{}, committees.Validators(i)...),
+			}
+		}
+		return recorded, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return committees, nil
+}
+
+func (c *BeaconClient) ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
+	if c.client == nil {
+		return ErrReadOnly
+	}
+	return c.client.ChangeWithdrawalCredentials(validatorIndex, fromBlsPubkey, toExecutionAddress, signature)
+}
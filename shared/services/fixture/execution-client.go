@@ -0,0 +1,136 @@
+package fixture
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An Execution client that records the responses of a live client into a fixture, or replays them if there is no live client.
+// Calls that modify the chain are passed through to the live client and are never recorded.
+type ExecutionClient struct {
+	fixture *Fixture
+	client  rocketpool.ExecutionClient
+}
+
+// Create a new Execution client for the fixture. If client is nil, responses are replayed from the fixture.
+func NewExecutionClient(fixture *Fixture, client rocketpool.ExecutionClient) *ExecutionClient {
+	return &ExecutionClient{
+		fixture: fixture,
+		client:  client,
+	}
+}
+
+func (c *ExecutionClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return call(c.fixture, c.client != nil, "CodeAt", []any{contract, blockNumber}, func() ([]byte, error) {
+		return c.client.CodeAt(ctx, contract, blockNumber)
+	})
+}
+
+func (c *ExecutionClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return call(c.fixture, c.client != nil, "CallContract", []any{msg, blockNumber}, func() ([]byte, error) {
+		return c.client.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (c *ExecutionClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return call(c.fixture, c.client != nil, "HeaderByHash", []any{hash}, func() (*types.Header, error) {
+		return c.client.HeaderByHash(ctx, hash)
+	})
+}
+
+func (c *ExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return call(c.fixture, c.client != nil, "HeaderByNumber", []any{number}, func() (*types.Header, error) {
+		return c.client.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *ExecutionClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return call(c.fixture, c.client != nil, "PendingCodeAt", []any{account}, func() ([]byte, error) {
+		return c.client.PendingCodeAt(ctx, account)
+	})
+}
+
+func (c *ExecutionClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return call(c.fixture, c.client != nil, "PendingNonceAt", []any{account}, func() (uint64, error) {
+		return c.client.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *ExecutionClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(c.fixture, c.client != nil, "SuggestGasPrice", nil, func() (*big.Int, error) {
+		return c.client.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *ExecutionClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return call(c.fixture, c.client != nil, "SuggestGasTipCap", nil, func() (*big.Int, error) {
+		return c.client.SuggestGasTipCap(ctx)
+	})
+}
+
+func (c *ExecutionClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return call(c.fixture, c.client != nil, "EstimateGas", []any{msg}, func() (uint64, error) {
+		return c.client.EstimateGas(ctx, msg)
+	})
+}
+
+func (c *ExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if c.client == nil {
+		return ErrReadOnly
+	}
+	return c.client.SendTransaction(ctx, tx)
+}
+
+func (c *ExecutionClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return call(c.fixture, c.client != nil, "FilterLogs", []any{query}, func() ([]types.Log, error) {
+		return c.client.FilterLogs(ctx, query)
+	})
+}
+
+func (c *ExecutionClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if c.client == nil {
+		return nil, ErrReadOnly
+	}
+	return c.client.SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (c *ExecutionClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return call(c.fixture, c.client != nil, "TransactionReceipt", []any{txHash}, func() (*types.Receipt, error) {
+		return c.client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (c *ExecutionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return call(c.fixture, c.client != nil, "BlockNumber", nil, func() (uint64, error) {
+		return c.client.BlockNumber(ctx)
+	})
+}
+
+func (c *ExecutionClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return call(c.fixture, c.client != nil, "BalanceAt", []any{account, blockNumber}, func() (*big.Int, error) {
+		return c.client.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *ExecutionClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	return call2(c.fixture, c.client != nil, "TransactionByHash", []any{hash}, func() (*types.Transaction, bool, error) {
+		return c.client.TransactionByHash(ctx, hash)
+	})
+}
+
+func (c *ExecutionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return call(c.fixture, c.client != nil, "NonceAt", []any{account, blockNumber}, func() (uint64, error) {
+		return c.client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *ExecutionClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return call(c.fixture, c.client != nil, "SyncProgress", nil, func() (*ethereum.SyncProgress, error) {
+		return c.client.SyncProgress(ctx)
+	})
+}
@@ -0,0 +1,207 @@
+package fixture
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The version of the fixture file format
+const fixtureVersion uint64 = 1
+
+// Returned when replaying a call that was never recorded
+var ErrNotRecorded = errors.New("no response was recorded for this call")
+
+// Returned when replaying a call that would modify the chain
+var ErrReadOnly = errors.New("fixtures can't replay calls that modify the chain")
+
+// The response to a single call
+type recordedResponse struct {
+	Values []json.RawMessage `json:"values"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// The serialized form of a fixture
+type fixtureFile struct {
+	Version   uint64                       `json:"version"`
+	Responses map[string]*recordedResponse `json:"responses"`
+}
+
+// A set of recorded client responses, keyed by the method name and its arguments.
+// Clients wrapping a live client record into the fixture; clients without one replay from it.
+type Fixture struct {
+	responses map[string]*recordedResponse
+	lock      sync.Mutex
+}
+
+// Create an empty fixture to record into
+func New() *Fixture {
+	return &Fixture{
+		responses: map[string]*recordedResponse{},
+	}
+}
+
+// Load a fixture from a zstd-compressed file
+func Load(path string) (*Fixture, error) {
+	compressedBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture [%s]: %w", path, err)
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor: %w", err)
+	}
+	defer decoder.Close()
+	bytes, err := decoder.DecodeAll(compressedBytes, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing fixture [%s]: %w", path, err)
+	}
+
+	var file fixtureFile
+	err = json.Unmarshal(bytes, &file)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing fixture [%s]: %w", path, err)
+	}
+	if file.Version != fixtureVersion {
+		return nil, fmt.Errorf("fixture [%s] has version %d but version %d is required", path, file.Version, fixtureVersion)
+	}
+	if file.Responses == nil {
+		file.Responses = map[string]*recordedResponse{}
+	}
+	return &Fixture{
+		responses: file.Responses,
+	}, nil
+}
+
+// Save the fixture to a zstd-compressed file
+func (f *Fixture) Save(path string) error {
+	f.lock.Lock()
+	bytes, err := json.Marshal(fixtureFile{
+		Version:   fixtureVersion,
+		Responses: f.responses,
+	})
+	f.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("error serializing fixture: %w", err)
+	}
+
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return fmt.Errorf("error creating zstd compressor: %w", err)
+	}
+	defer encoder.Close()
+	compressedBytes := encoder.EncodeAll(bytes, make([]byte, 0, len(bytes)))
+
+	err = os.WriteFile(path, compressedBytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing fixture [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the number of recorded responses
+func (f *Fixture) Len() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.responses)
+}
+
+// Get the key for a call from its method name and arguments
+func getKey(method string, args ...any) (string, error) {
+	bytes, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("error serializing arguments for %s: %w", method, err)
+	}
+	return method + string(bytes), nil
+}
+
+// Store the response to a call
+func (f *Fixture) record(key string, callErr error, values ...any) error {
+	response := &recordedResponse{
+		Values: []json.RawMessage{},
+	}
+	if callErr != nil {
+		response.Error = callErr.Error()
+	} else {
+		for _, value := range values {
+			bytes, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("error serializing response for %s: %w", key, err)
+			}
+			response.Values = append(response.Values, bytes)
+		}
+	}
+
+	f.lock.Lock()
+	f.responses[key] = response
+	f.lock.Unlock()
+	return nil
+}
+
+// Load the response to a call into the provided pointers, returning the call's original error if it had one
+func (f *Fixture) replay(key string, values ...any) error {
+	f.lock.Lock()
+	response, exists := f.responses[key]
+	f.lock.Unlock()
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrNotRecorded, key)
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	if len(response.Values) != len(values) {
+		return fmt.Errorf("response for %s has %d values but %d were expected", key, len(response.Values), len(values))
+	}
+	for i, value := range values {
+		err := json.Unmarshal(response.Values[i], value)
+		if err != nil {
+			return fmt.Errorf("error deserializing response for %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Run a call against the live client and record it, or replay it if there's no live client
+func call[T any](f *Fixture, live bool, method string, args []any, run func() (T, error)) (T, error) {
+	var value T
+	key, err := getKey(method, args...)
+	if err != nil {
+		return value, err
+	}
+	if !live {
+		err = f.replay(key, &value)
+		return value, err
+	}
+
+	value, callErr := run()
+	err = f.record(key, callErr, value)
+	if err != nil {
+		return value, err
+	}
+	return value, callErr
+}
+
+// Like call, but for methods with two return values
+func call2[T1 any, T2 any](f *Fixture, live bool, method string, args []any, run func() (T1, T2, error)) (T1, T2, error) {
+	var first T1
+	var second T2
+	key, err := getKey(method, args...)
+	if err != nil {
+		return first, second, err
+	}
+	if !live {
+		err = f.replay(key, &first, &second)
+		return first, second, err
+	}
+
+	first, second, callErr := run()
+	err = f.record(key, callErr, first, second)
+	if err != nil {
+		return first, second, err
+	}
+	return first, second, callErr
+}
@@ -0,0 +1,107 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An Execution client that serves a fixed chain and counts how often it's called
+type stubExecutionClient struct {
+	rocketpool.ExecutionClient
+	calls int
+}
+
+func (c *stubExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.calls++
+	if number.Uint64() > 100 {
+		return nil, errors.New("block not found")
+	}
+	return &types.Header{
+		Number:     number,
+		Time:       1700000000 + number.Uint64()*12,
+		Difficulty: big.NewInt(0),
+		GasLimit:   30000000,
+	}, nil
+}
+
+func (c *stubExecutionClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls++
+	return append([]byte{byte(blockNumber.Uint64())}, msg.Data...), nil
+}
+
+func TestExecutionClientRecordAndReplay(t *testing.T) {
+	stub := &stubExecutionClient{}
+	recording := New()
+	ec := NewExecutionClient(recording, stub)
+	ctx := context.Background()
+	contract := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	msg := ethereum.CallMsg{To: &contract, Data: []byte{0xde, 0xad}}
+
+	liveHeader, err := ec.HeaderByNumber(ctx, big.NewInt(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, liveErr := ec.HeaderByNumber(ctx, big.NewInt(200))
+	if liveErr == nil {
+		t.Fatal("expected an error for a missing block")
+	}
+	liveResult, err := ec.CallContract(ctx, msg, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "ec.json.zst")
+	err = recording.Save(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 3 {
+		t.Fatalf("expected 3 recorded responses but there were %d", loaded.Len())
+	}
+
+	// Replay everything without the live client
+	replay := NewExecutionClient(loaded, nil)
+	header, err := replay.HeaderByNumber(ctx, big.NewInt(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Hash() != liveHeader.Hash() {
+		t.Fatalf("replayed header hash %s doesn't match recorded hash %s", header.Hash().Hex(), liveHeader.Hash().Hex())
+	}
+	_, err = replay.HeaderByNumber(ctx, big.NewInt(200))
+	if err == nil || err.Error() != liveErr.Error() {
+		t.Fatalf("expected replayed error [%v] but got [%v]", liveErr, err)
+	}
+	result, err := replay.CallContract(ctx, msg, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != string(liveResult) {
+		t.Fatalf("replayed call result %x doesn't match recorded result %x", result, liveResult)
+	}
+
+	// Calls that weren't recorded and calls that modify the chain should fail
+	_, err = replay.CallContract(ctx, msg, big.NewInt(8))
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("expected ErrNotRecorded but got %v", err)
+	}
+	err = replay.SendTransaction(ctx, nil)
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly but got %v", err)
+	}
+	if stub.calls != 3 {
+		t.Fatalf("expected the live client to be called 3 times but it was called %d times", stub.calls)
+	}
+}
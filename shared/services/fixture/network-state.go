@@ -0,0 +1,187 @@
+package fixture
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Builds a synthetic network state for tests without an Execution or Beacon client
+type NetworkStateBuilder struct {
+	state     *state.NetworkState
+	minipools []rpstate.NativeMinipoolDetails
+	nodes     []rpstate.NativeNodeDetails
+}
+
+// Create a new builder for a network state at the given slot and EL block
+func NewNetworkStateBuilder(beaconConfig beacon.Eth2Config, slot uint64, elBlock uint64) *NetworkStateBuilder {
+	return &NetworkStateBuilder{
+		state: &state.NetworkState{
+			ElBlockNumber:    elBlock,
+			BeaconSlotNumber: slot,
+			BeaconConfig:     beaconConfig,
+			NetworkDetails:   NewNetworkDetails(),
+			ValidatorDetails: map[rptypes.ValidatorPubkey]beacon.ValidatorStatus{},
+		},
+		minipools: []rpstate.NativeMinipoolDetails{},
+		nodes:     []rpstate.NativeNodeDetails{},
+	}
+}
+
+// Create network details with every amount set to zero
+func NewNetworkDetails() *rpstate.NetworkDetails {
+	return &rpstate.NetworkDetails{
+		RplPrice:                          big.NewInt(0),
+		MinCollateralFraction:             big.NewInt(0),
+		MaxCollateralFraction:             big.NewInt(0),
+		NodeOperatorRewardsPercent:        big.NewInt(0),
+		TrustedNodeOperatorRewardsPercent: big.NewInt(0),
+		ProtocolDaoRewardsPercent:         big.NewInt(0),
+		PendingRPLRewards:                 big.NewInt(0),
+		DepositPoolBalance:                big.NewInt(0),
+		DepositPoolExcess:                 big.NewInt(0),
+		QueueLength:                       big.NewInt(0),
+		RPLInflationIntervalRate:          big.NewInt(0),
+		RPLTotalSupply:                    big.NewInt(0),
+		StakingETHBalance:                 big.NewInt(0),
+		TotalETHBalance:                   big.NewInt(0),
+		RETHBalance:                       big.NewInt(0),
+		TotalRETHSupply:                   big.NewInt(0),
+		TotalRPLStake:                     big.NewInt(0),
+		SmoothingPoolBalance:              big.NewInt(0),
+		MinipoolLaunchTimeout:             big.NewInt(0),
+		DepositPoolUserBalance:            big.NewInt(0),
+	}
+}
+
+// Create the details for a node that has been registered since the given time, with no RPL staked
+func NewNode(address common.Address, registrationTime time.Time) rpstate.NativeNodeDetails {
+	registration := big.NewInt(registrationTime.Unix())
+	return rpstate.NativeNodeDetails{
+		Exists:                           true,
+		NodeAddress:                      address,
+		RegistrationTime:                 registration,
+		RewardNetwork:                    big.NewInt(0),
+		RplStake:                         big.NewInt(0),
+		EffectiveRPLStake:                big.NewInt(0),
+		MinimumRPLStake:                  big.NewInt(0),
+		MaximumRPLStake:                  big.NewInt(0),
+		EthMatched:                       big.NewInt(0),
+		EthMatchedLimit:                  big.NewInt(0),
+		MinipoolCount:                    big.NewInt(0),
+		BalanceETH:                       big.NewInt(0),
+		BalanceRETH:                      big.NewInt(0),
+		BalanceRPL:                       big.NewInt(0),
+		BalanceOldRPL:                    big.NewInt(0),
+		DepositCreditBalance:             big.NewInt(0),
+		DistributorBalanceUserETH:        big.NewInt(0),
+		DistributorBalanceNodeETH:        big.NewInt(0),
+		SmoothingPoolRegistrationChanged: registration,
+		AverageNodeFee:                   big.NewInt(0),
+		CollateralisationRatio:           big.NewInt(0),
+		DistributorBalance:               big.NewInt(0),
+	}
+}
+
+// Create the details for a staking minipool with the given bond and fee (both in wei) that hasn't had its bond reduced
+func NewMinipool(address common.Address, nodeAddress common.Address, pubkey rptypes.ValidatorPubkey, bond *big.Int, fee *big.Int, statusTime time.Time) rpstate.NativeMinipoolDetails {
+	return rpstate.NativeMinipoolDetails{
+		Exists:                       true,
+		MinipoolAddress:              address,
+		NodeAddress:                  nodeAddress,
+		Pubkey:                       pubkey,
+		Status:                       rptypes.Staking,
+		StatusRaw:                    uint8(rptypes.Staking),
+		StatusBlock:                  big.NewInt(0),
+		StatusTime:                   big.NewInt(statusTime.Unix()),
+		Finalised:                    false,
+		NodeFee:                      new(big.Int).Set(fee),
+		NodeDepositBalance:           new(big.Int).Set(bond),
+		UserDepositBalance:           big.NewInt(0).Sub(big.NewInt(0).Mul(big.NewInt(32), big.NewInt(1e18)), bond),
+		UserDepositAssignedTime:      big.NewInt(0),
+		PenaltyCount:                 big.NewInt(0),
+		PenaltyRate:                  big.NewInt(0),
+		Balance:                      big.NewInt(0),
+		DistributableBalance:         big.NewInt(0),
+		NodeShareOfBalance:           big.NewInt(0),
+		UserShareOfBalance:           big.NewInt(0),
+		NodeRefundBalance:            big.NewInt(0),
+		LastBondReductionTime:        big.NewInt(0),
+		LastBondReductionPrevValue:   big.NewInt(0),
+		LastBondReductionPrevNodeFee: big.NewInt(0),
+		ReduceBondTime:               big.NewInt(0),
+		ReduceBondValue:              big.NewInt(0),
+		PreMigrationBalance:          big.NewInt(0),
+	}
+}
+
+// Create the status for an active validator
+func NewValidator(pubkey rptypes.ValidatorPubkey, index string, activationEpoch uint64) beacon.ValidatorStatus {
+	return beacon.ValidatorStatus{
+		Pubkey:           pubkey,
+		Index:            index,
+		Balance:          32e9,
+		EffectiveBalance: 32e9,
+		Status:           beacon.ValidatorState_ActiveOngoing,
+		ActivationEpoch:  activationEpoch,
+		ExitEpoch:        ^uint64(0),
+		Exists:           true,
+	}
+}
+
+// Get the network details so they can be customized
+func (b *NetworkStateBuilder) NetworkDetails() *rpstate.NetworkDetails {
+	return b.state.NetworkDetails
+}
+
+// Add a node to the network
+func (b *NetworkStateBuilder) AddNode(node rpstate.NativeNodeDetails) *NetworkStateBuilder {
+	b.nodes = append(b.nodes, node)
+	return b
+}
+
+// Add a minipool to the network, along with the status of its validator on the Beacon chain
+func (b *NetworkStateBuilder) AddMinipool(minipool rpstate.NativeMinipoolDetails, validator beacon.ValidatorStatus) *NetworkStateBuilder {
+	b.minipools = append(b.minipools, minipool)
+	b.state.ValidatorDetails[minipool.Pubkey] = validator
+	return b
+}
+
+// Add a member to the Oracle DAO that joined at the given time; its node must be added separately
+func (b *NetworkStateBuilder) AddOracleDaoMember(address common.Address, joinedTime time.Time) *NetworkStateBuilder {
+	b.state.OracleDaoMemberDetails = append(b.state.OracleDaoMemberDetails, rpstate.OracleDaoMemberDetails{
+		Address:          address,
+		Exists:           true,
+		JoinedTime:       joinedTime,
+		LastProposalTime: joinedTime,
+		RPLBondAmount:    big.NewInt(0),
+	})
+	return b
+}
+
+// Create the network state, including all of its lookup maps
+func (b *NetworkStateBuilder) Build() *state.NetworkState {
+	networkState := b.state
+	networkState.NodeDetails = append([]rpstate.NativeNodeDetails{}, b.nodes...)
+	networkState.MinipoolDetails = append([]rpstate.NativeMinipoolDetails{}, b.minipools...)
+	networkState.NodeDetailsByAddress = map[common.Address]*rpstate.NativeNodeDetails{}
+	networkState.MinipoolDetailsByAddress = map[common.Address]*rpstate.NativeMinipoolDetails{}
+	networkState.MinipoolDetailsByNode = map[common.Address][]*rpstate.NativeMinipoolDetails{}
+
+	for i, details := range networkState.NodeDetails {
+		networkState.NodeDetailsByAddress[details.NodeAddress] = &networkState.NodeDetails[i]
+	}
+	for i, details := range networkState.MinipoolDetails {
+		networkState.MinipoolDetailsByAddress[details.MinipoolAddress] = &networkState.MinipoolDetails[i]
+		networkState.MinipoolDetailsByNode[details.NodeAddress] = append(networkState.MinipoolDetailsByNode[details.NodeAddress], &networkState.MinipoolDetails[i])
+	}
+	for i, details := range networkState.NodeDetails {
+		networkState.NodeDetails[i].MinipoolCount = big.NewInt(int64(len(networkState.MinipoolDetailsByNode[details.NodeAddress])))
+	}
+	return networkState
+}
@@ -0,0 +1,298 @@
+package history
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// The store keeps one segment file per UTC day. The current day's segment is appended to as plain JSON lines;
+// older segments are gzipped since they won't change again.
+const (
+	segmentDateFormat   string = "2006-01-02"
+	segmentExtension    string = ".jsonl"
+	compressedExtension string = ".gz"
+)
+
+// A snapshot of the node's metrics taken by the node daemon during one of its update cycles.
+// ETH and RPL amounts are in whole tokens rather than wei to keep the store compact.
+type Sample struct {
+	Time                    time.Time        `json:"time"`
+	Block                   uint64           `json:"block"`
+	RplStake                float64          `json:"rplStake"`
+	EffectiveRplStake       float64          `json:"effectiveRplStake"`
+	RplPrice                float64          `json:"rplPrice"`
+	BorrowedCollateralRatio float64          `json:"borrowedCollateralRatio"`
+	BondedCollateralRatio   float64          `json:"bondedCollateralRatio"`
+	ClaimedRplRewards       float64          `json:"claimedRplRewards"`
+	UnclaimedRplRewards     float64          `json:"unclaimedRplRewards"`
+	ClaimedEthRewards       float64          `json:"claimedEthRewards"`
+	UnclaimedEthRewards     float64          `json:"unclaimedEthRewards"`
+	BeaconBalance           float64          `json:"beaconBalance"`
+	BeaconShare             float64          `json:"beaconShare"`
+	Minipools               []MinipoolSample `json:"minipools,omitempty"`
+}
+
+// A snapshot of one of the node's minipools
+type MinipoolSample struct {
+	Address       common.Address `json:"address"`
+	BeaconBalance float64        `json:"beaconBalance"`
+	NodeShare     float64        `json:"nodeShare"`
+}
+
+// An embedded, append-only time-series store for the node's history
+type Store struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a store that keeps its segments in the given folder
+func NewStore(path string) *Store {
+	return &Store{
+		path: path,
+	}
+}
+
+// Add a sample to the store
+func (s *Store) Append(sample Sample) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := os.MkdirAll(s.path, 0755); err != nil {
+		return fmt.Errorf("error creating history folder %s: %w", s.path, err)
+	}
+
+	// Compress the segments of previous days the first time a new day is written to
+	day := sample.Time.UTC().Format(segmentDateFormat)
+	segmentPath := filepath.Join(s.path, day+segmentExtension)
+	if _, err := os.Stat(segmentPath); os.IsNotExist(err) {
+		if err := s.compressSegments(day); err != nil {
+			return err
+		}
+	}
+
+	bytes, err := json.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("error serializing history sample: %w", err)
+	}
+	file, err := os.OpenFile(segmentPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening history segment %s: %w", segmentPath, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(bytes, '\n')); err != nil {
+		return fmt.Errorf("error writing history segment %s: %w", segmentPath, err)
+	}
+	return nil
+}
+
+// Delete the segments of days that ended more than the retention period ago
+func (s *Store) Prune(retention time.Duration, now time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	segments, err := s.getSegments()
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-retention)
+	for _, segment := range segments {
+		if segment.day.Add(24 * time.Hour).Before(cutoff) {
+			if err := os.Remove(segment.path); err != nil {
+				return fmt.Errorf("error deleting history segment %s: %w", segment.path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Get the samples taken in the given time range, oldest first. A zero time leaves that end of the range open.
+func (s *Store) Query(from time.Time, to time.Time) ([]Sample, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	segments, err := s.getSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	samples := []Sample{}
+	for _, segment := range segments {
+		if !from.IsZero() && segment.day.Add(24*time.Hour).Before(from) {
+			continue
+		}
+		if !to.IsZero() && segment.day.After(to) {
+			continue
+		}
+		segmentSamples, err := readSegment(segment.path)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range segmentSamples {
+			if (from.IsZero() || !sample.Time.Before(from)) && (to.IsZero() || !sample.Time.After(to)) {
+				samples = append(samples, sample)
+			}
+		}
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+	return samples, nil
+}
+
+// Reduce samples to at most one per step, aligned to multiples of the step since the Unix epoch.
+// Each bucket keeps its last sample, so values are exact snapshots rather than averages and cumulative values stay correct.
+func Downsample(samples []Sample, step time.Duration) []Sample {
+	if step <= 0 || len(samples) == 0 {
+		return samples
+	}
+	downsampled := []Sample{}
+	var currentBucket int64
+	for i, sample := range samples {
+		bucket := sample.Time.UnixNano() / int64(step)
+		if i > 0 && bucket == currentBucket {
+			downsampled[len(downsampled)-1] = sample
+			continue
+		}
+		currentBucket = bucket
+		downsampled = append(downsampled, sample)
+	}
+	return downsampled
+}
+
+// A day's segment file
+type segment struct {
+	day  time.Time
+	path string
+}
+
+// Get the store's segments, oldest first
+func (s *Store) getSegments() ([]segment, error) {
+	entries, err := os.ReadDir(s.path)
+	if os.IsNotExist(err) {
+		return []segment{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading history folder %s: %w", s.path, err)
+	}
+
+	segments := []segment{}
+	for _, entry := range entries {
+		name := entry.Name()
+		dayString := strings.TrimSuffix(strings.TrimSuffix(name, compressedExtension), segmentExtension)
+		if entry.IsDir() || dayString == name {
+			continue
+		}
+		day, err := time.Parse(segmentDateFormat, dayString)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{
+			day:  day,
+			path: filepath.Join(s.path, name),
+		})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].day.Before(segments[j].day)
+	})
+	return segments, nil
+}
+
+// Gzip the uncompressed segments of every day before the given one
+func (s *Store) compressSegments(currentDay string) error {
+	segments, err := s.getSegments()
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		if strings.HasSuffix(segment.path, compressedExtension) || segment.day.Format(segmentDateFormat) >= currentDay {
+			continue
+		}
+		if err := compressFile(segment.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replace a file with a gzipped copy of it
+func compressFile(path string) error {
+	input, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening history segment %s: %w", path, err)
+	}
+	defer input.Close()
+
+	compressedPath := path + compressedExtension
+	tempPath := compressedPath + ".tmp"
+	output, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("error creating compressed history segment %s: %w", tempPath, err)
+	}
+	writer := gzip.NewWriter(output)
+	_, err = io.Copy(writer, input)
+	if err == nil {
+		err = writer.Close()
+	}
+	if closeErr := output.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error compressing history segment %s: %w", path, err)
+	}
+
+	if err := os.Rename(tempPath, compressedPath); err != nil {
+		return fmt.Errorf("error saving compressed history segment %s: %w", compressedPath, err)
+	}
+	return os.Remove(path)
+}
+
+// Read the samples in a segment. A partially-written last line (e.g. from a crash) is skipped.
+func readSegment(path string) ([]Sample, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening history segment %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, compressedExtension) {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing history segment %s: %w", path, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	samples := []Sample{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var sample Sample
+		if err := json.Unmarshal(line, &sample); err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("error reading history segment %s: %w", path, err)
+	}
+	return samples, nil
+}
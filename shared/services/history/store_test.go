@@ -0,0 +1,115 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Get a sample taken at the given time with its block set to tell it apart
+func newSample(sampleTime time.Time, block uint64) Sample {
+	return Sample{
+		Time:  sampleTime,
+		Block: block,
+	}
+}
+
+// Get the blocks of the given samples, in order
+func getBlocks(samples []Sample) []uint64 {
+	blocks := []uint64{}
+	for _, sample := range samples {
+		blocks = append(blocks, sample.Block)
+	}
+	return blocks
+}
+
+func checkBlocks(t *testing.T, name string, samples []Sample, expected ...uint64) {
+	t.Helper()
+	blocks := getBlocks(samples)
+	if len(blocks) != len(expected) {
+		t.Fatalf("%s: expected blocks %v, got %v", name, expected, blocks)
+	}
+	for i := range blocks {
+		if blocks[i] != expected[i] {
+			t.Fatalf("%s: expected blocks %v, got %v", name, expected, blocks)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	store := NewStore(t.TempDir())
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for day := 0; day < 5; day++ {
+		if err := store.Append(newSample(start.Add(time.Duration(day)*24*time.Hour), uint64(day))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the days that ended before the cutoff are deleted; the first two days end at midnight on the 3rd
+	now := time.Date(2024, 3, 5, 6, 0, 0, 0, time.UTC)
+	if err := store.Prune(2*24*time.Hour, now); err != nil {
+		t.Fatal(err)
+	}
+	samples, err := store.Query(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBlocks(t, "pruned", samples, 2, 3, 4)
+
+	// The older segments were compressed, and the pruned ones are gone entirely
+	entries, err := os.ReadDir(store.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+	expected := []string{"2024-03-03.jsonl.gz", "2024-03-04.jsonl.gz", "2024-03-05.jsonl"}
+	if len(names) != len(expected) {
+		t.Fatalf("expected segments %v, got %v", expected, names)
+	}
+	for _, name := range expected {
+		if !names[name] {
+			t.Errorf("expected segment %s to be kept, got %v", name, names)
+		}
+	}
+
+	// Pruning an empty store does nothing
+	if err := NewStore(filepath.Join(t.TempDir(), "missing")).Prune(time.Hour, now); err != nil {
+		t.Errorf("expected pruning a missing store to succeed, got %s", err.Error())
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	samples := []Sample{
+		newSample(start, 1),
+		newSample(start.Add(20*time.Minute), 2),
+		newSample(start.Add(59*time.Minute), 3),
+		newSample(start.Add(time.Hour), 4),
+		newSample(start.Add(3*time.Hour+time.Minute), 5),
+		newSample(start.Add(3*time.Hour+30*time.Minute), 6),
+	}
+
+	// Each hour keeps its last sample, and empty hours are skipped
+	checkBlocks(t, "hourly", Downsample(samples, time.Hour), 3, 4, 6)
+
+	// A step finer than the samples keeps all of them
+	checkBlocks(t, "per minute", Downsample(samples, time.Minute), 1, 2, 3, 4, 5, 6)
+
+	// Buckets are aligned to the Unix epoch rather than the first sample
+	checkBlocks(t, "daily", Downsample(samples[1:], 24*time.Hour), 6)
+	offset := []Sample{
+		newSample(start.Add(-time.Minute), 1),
+		newSample(start.Add(time.Minute), 2),
+	}
+	checkBlocks(t, "across midnight", Downsample(offset, time.Hour), 1, 2)
+
+	// No step leaves the samples alone
+	checkBlocks(t, "no step", Downsample(samples, 0), 1, 2, 3, 4, 5, 6)
+	if downsampled := Downsample([]Sample{}, time.Hour); len(downsampled) != 0 {
+		t.Errorf("expected no samples, got %d", len(downsampled))
+	}
+}
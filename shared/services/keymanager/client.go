@@ -0,0 +1,423 @@
+package keymanager
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	KeystoresPath          = "/eth/v1/keystores"
+	RemoteKeysPath         = "/eth/v1/remotekeys"
+	FeeRecipientPathFormat = "/eth/v1/validator/%s/feerecipient"
+	GraffitiPathFormat     = "/eth/v1/validator/%s/graffiti"
+	RequestTimeout         = 30 * time.Second
+
+	tokenFileMode os.FileMode = 0600
+	tokenDirMode  os.FileMode = 0700
+)
+
+// A client for the standard Ethereum keymanager API, served by Validator Clients and remote signers
+type Client struct {
+	url        string
+	token      string
+	tokenPath  string
+	encryptor  *eth2ks.Encryptor
+	httpClient *http.Client
+}
+
+// The result of importing or deleting a single key
+type keyStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// A request to import keystores
+type importKeystoresRequest struct {
+	Keystores []string `json:"keystores"`
+	Passwords []string `json:"passwords"`
+}
+
+// A key that's held by a remote signer
+type remoteKey struct {
+	Pubkey string `json:"pubkey"`
+	Url    string `json:"url,omitempty"`
+}
+
+// A request to import remote keys
+type importRemoteKeysRequest struct {
+	RemoteKeys []remoteKey `json:"remote_keys"`
+}
+
+// A request to delete keystores or remote keys
+type deleteKeysRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+// The response to a request that imports or deletes keys
+type keyStatusResponse struct {
+	Data               []keyStatus `json:"data"`
+	SlashingProtection string      `json:"slashing_protection"`
+}
+
+// The response to a request for the loaded keystores
+type listKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+	} `json:"data"`
+}
+
+// The response to a request for the loaded remote keys
+type listRemoteKeysResponse struct {
+	Data []remoteKey `json:"data"`
+}
+
+// A request to set a validator's fee recipient
+type setFeeRecipientRequest struct {
+	EthAddress string `json:"ethaddress"`
+}
+
+// A request to set a validator's graffiti
+type setGraffitiRequest struct {
+	Graffiti string `json:"graffiti"`
+}
+
+// Create a new keymanager API client with a fixed bearer token, which may be blank if the API doesn't require one
+func NewClient(url string, token string) *Client {
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		token:     token,
+		encryptor: eth2ks.New(),
+		httpClient: &http.Client{
+			Timeout: RequestTimeout,
+		},
+	}
+}
+
+// Create a new keymanager API client that reads its bearer token from a file before each request, since the Validator Client may replace it
+func NewClientWithTokenFile(url string, tokenPath string) *Client {
+	client := NewClient(url, "")
+	client.tokenPath = tokenPath
+	return client
+}
+
+// Create a random bearer token at the given path if there isn't one there already, so the Validator Client can be started with it
+func CreateTokenFile(tokenPath string) error {
+	_, err := os.Stat(tokenPath)
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("error checking keymanager token file [%s]: %w", tokenPath, err)
+	}
+
+	tokenBytes := make([]byte, 32)
+	_, err = rand.Read(tokenBytes)
+	if err != nil {
+		return fmt.Errorf("error generating keymanager token: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(tokenPath), tokenDirMode)
+	if err != nil {
+		return fmt.Errorf("error creating folder for keymanager token file [%s]: %w", tokenPath, err)
+	}
+	err = os.WriteFile(tokenPath, []byte("api-token-0x"+hex.EncodeToString(tokenBytes)), tokenFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing keymanager token file [%s]: %w", tokenPath, err)
+	}
+	return nil
+}
+
+// Import validator keys as keystores, each encrypted with its own random password.
+// derivationPaths can be nil if the paths aren't known.
+func (c *Client) ImportKeys(keys []*eth2types.BLSPrivateKey, derivationPaths []string) error {
+	request := importKeystoresRequest{
+		Keystores: make([]string, len(keys)),
+		Passwords: make([]string, len(keys)),
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(keys))
+	for i, key := range keys {
+		pubkeys[i] = types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+		derivationPath := ""
+		if derivationPaths != nil {
+			derivationPath = derivationPaths[i]
+		}
+
+		// Create a new password for the keystore; the VC or signer stores it alongside the key
+		password, err := keystore.GenerateRandomPassword()
+		if err != nil {
+			return fmt.Errorf("Could not generate random password: %w", err)
+		}
+
+		// Encrypt key
+		encryptedKey, err := c.encryptor.Encrypt(key.Marshal(), password)
+		if err != nil {
+			return fmt.Errorf("Could not encrypt validator key %s: %w", pubkeys[i].Hex(), err)
+		}
+
+		// Encode key store
+		keyStoreBytes, err := json.Marshal(api.ValidatorKeystore{
+			Crypto:  encryptedKey,
+			Version: c.encryptor.Version(),
+			UUID:    uuid.New(),
+			Path:    derivationPath,
+			Pubkey:  pubkeys[i],
+		})
+		if err != nil {
+			return fmt.Errorf("Could not encode validator key %s: %w", pubkeys[i].Hex(), err)
+		}
+		request.Keystores[i] = string(keyStoreBytes)
+		request.Passwords[i] = password
+	}
+
+	var response keyStatusResponse
+	err := c.request(http.MethodPost, KeystoresPath, request, &response)
+	if err != nil {
+		return fmt.Errorf("Could not import validator keys: %w", err)
+	}
+	err = checkKeyStatuses(pubkeys, response.Data, "imported", "duplicate")
+	if err != nil {
+		return err
+	}
+	return confirmKeysLoaded(pubkeys, c.ListKeys)
+}
+
+// Import keys that are held by a remote signer, so the VC signs with them through that signer
+func (c *Client) ImportRemoteKeys(pubkeys []types.ValidatorPubkey, signerUrl string) error {
+	request := importRemoteKeysRequest{
+		RemoteKeys: make([]remoteKey, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.RemoteKeys[i] = remoteKey{
+			Pubkey: hexutil.AddPrefix(pubkey.Hex()),
+			Url:    signerUrl,
+		}
+	}
+
+	var response keyStatusResponse
+	err := c.request(http.MethodPost, RemoteKeysPath, request, &response)
+	if err != nil {
+		return fmt.Errorf("Could not import remote validator keys: %w", err)
+	}
+	err = checkKeyStatuses(pubkeys, response.Data, "imported", "duplicate")
+	if err != nil {
+		return err
+	}
+	return confirmKeysLoaded(pubkeys, c.ListRemoteKeys)
+}
+
+// Get the pubkeys of the keystores that are loaded
+func (c *Client) ListKeys() ([]types.ValidatorPubkey, error) {
+	var response listKeystoresResponse
+	err := c.request(http.MethodGet, KeystoresPath, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get loaded validator keys: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(response.Data))
+	for i, key := range response.Data {
+		pubkeys[i], err = types.HexToValidatorPubkey(hexutil.RemovePrefix(key.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Could not get loaded validator keys: %w", err)
+		}
+	}
+	return pubkeys, nil
+}
+
+// Get the pubkeys of the remote keys that are loaded
+func (c *Client) ListRemoteKeys() ([]types.ValidatorPubkey, error) {
+	var response listRemoteKeysResponse
+	err := c.request(http.MethodGet, RemoteKeysPath, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get loaded remote validator keys: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(response.Data))
+	for i, key := range response.Data {
+		pubkeys[i], err = types.HexToValidatorPubkey(hexutil.RemovePrefix(key.Pubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Could not get loaded remote validator keys: %w", err)
+		}
+	}
+	return pubkeys, nil
+}
+
+// Delete keystores so they stop validating, returning the EIP-3076 slashing protection data for them
+func (c *Client) DeleteKeys(pubkeys []types.ValidatorPubkey) (string, error) {
+	var response keyStatusResponse
+	err := c.request(http.MethodDelete, KeystoresPath, newDeleteKeysRequest(pubkeys), &response)
+	if err != nil {
+		return "", fmt.Errorf("Could not delete validator keys: %w", err)
+	}
+	err = checkKeyStatuses(pubkeys, response.Data, "deleted", "not_active", "not_found")
+	if err != nil {
+		return "", err
+	}
+	return response.SlashingProtection, nil
+}
+
+// Delete remote keys so the VC stops validating with them; the remote signer keeps their slashing protection
+func (c *Client) DeleteRemoteKeys(pubkeys []types.ValidatorPubkey) error {
+	var response keyStatusResponse
+	err := c.request(http.MethodDelete, RemoteKeysPath, newDeleteKeysRequest(pubkeys), &response)
+	if err != nil {
+		return fmt.Errorf("Could not delete remote validator keys: %w", err)
+	}
+	return checkKeyStatuses(pubkeys, response.Data, "deleted", "not_found")
+}
+
+// Set the fee recipient for a validator
+func (c *Client) SetFeeRecipient(pubkey types.ValidatorPubkey, feeRecipient common.Address) error {
+	path := fmt.Sprintf(FeeRecipientPathFormat, hexutil.AddPrefix(pubkey.Hex()))
+	err := c.request(http.MethodPost, path, setFeeRecipientRequest{EthAddress: feeRecipient.Hex()}, nil)
+	if err != nil {
+		return fmt.Errorf("Could not set the fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Set the graffiti for a validator
+func (c *Client) SetGraffiti(pubkey types.ValidatorPubkey, graffiti string) error {
+	path := fmt.Sprintf(GraffitiPathFormat, hexutil.AddPrefix(pubkey.Hex()))
+	err := c.request(http.MethodPost, path, setGraffitiRequest{Graffiti: graffiti}, nil)
+	if err != nil {
+		return fmt.Errorf("Could not set the graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Create a request to delete the given keys
+func newDeleteKeysRequest(pubkeys []types.ValidatorPubkey) deleteKeysRequest {
+	request := deleteKeysRequest{
+		Pubkeys: make([]string, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.Pubkeys[i] = hexutil.AddPrefix(pubkey.Hex())
+	}
+	return request
+}
+
+// Make sure every key got one of the allowed statuses
+func checkKeyStatuses(pubkeys []types.ValidatorPubkey, statuses []keyStatus, allowed ...string) error {
+	if len(statuses) != len(pubkeys) {
+		return fmt.Errorf("expected %d key results but got %d", len(pubkeys), len(statuses))
+	}
+	for i, status := range statuses {
+		ok := false
+		for _, allowedStatus := range allowed {
+			if strings.EqualFold(status.Status, allowedStatus) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("validator key %s: %s (%s)", pubkeys[i].Hex(), status.Status, status.Message)
+		}
+	}
+	return nil
+}
+
+// Make sure every key is in the list of loaded keys. Some clients report keys that are already in their validators folder as
+// duplicates without ever having loaded them, so an import's statuses alone don't mean the keys are validating.
+func confirmKeysLoaded(pubkeys []types.ValidatorPubkey, listKeys func() ([]types.ValidatorPubkey, error)) error {
+	loadedKeys, err := listKeys()
+	if err != nil {
+		return err
+	}
+	loaded := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range loadedKeys {
+		loaded[pubkey] = true
+	}
+	for _, pubkey := range pubkeys {
+		if !loaded[pubkey] {
+			return fmt.Errorf("validator key %s was imported but isn't active", pubkey.Hex())
+		}
+	}
+	return nil
+}
+
+// Get the bearer token to send with each request
+func (c *Client) getToken() (string, error) {
+	if c.tokenPath == "" {
+		return c.token, nil
+	}
+	tokenBytes, err := os.ReadFile(c.tokenPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading keymanager token file [%s]: %w", c.tokenPath, err)
+	}
+	return strings.TrimSpace(string(tokenBytes)), nil
+}
+
+// Send a request to the keymanager API and decode its JSON response if there is one
+func (c *Client) request(method string, path string, request interface{}, response interface{}) error {
+
+	// Encode the request
+	var body io.Reader
+	if request != nil {
+		requestBytes, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+	httpRequest, err := http.NewRequest(method, c.url+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	if request != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+	token, err := c.getToken()
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Send it
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("error sending request to %s: %w", path, err)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response from %s: %w", path, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK && httpResponse.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("%s returned %s: %s", path, httpResponse.Status, strings.TrimSpace(string(responseBytes)))
+	}
+
+	// Decode the response
+	if response == nil {
+		return nil
+	}
+	err = json.Unmarshal(responseBytes, response)
+	if err != nil {
+		return fmt.Errorf("error deserializing response from %s: %w", path, err)
+	}
+	return nil
+
+}
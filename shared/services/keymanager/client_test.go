@@ -0,0 +1,247 @@
+package keymanager
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// A keymanager API that holds keys in memory and reports each import with a fixed status
+type stubKeymanager struct {
+	t            *testing.T
+	token        string
+	importStatus string
+	loadOnImport bool
+	loaded       []string
+	feeRecipient map[string]string
+	graffiti     map[string]string
+}
+
+// The slashing protection the stub returns for deleted keys
+const stubSlashingProtection string = `{"metadata":{"interchange_format_version":"5"},"data":[]}`
+
+func (s *stubKeymanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"bad token"}`))
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+
+	switch {
+	case r.URL.Path == KeystoresPath && r.Method == http.MethodPost:
+		var request importKeystoresRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			s.t.Fatalf("error decoding import request: %s", err.Error())
+		}
+		if len(request.Keystores) != len(request.Passwords) {
+			s.t.Fatalf("got %d keystores but %d passwords", len(request.Keystores), len(request.Passwords))
+		}
+		response := keyStatusResponse{}
+		for _, keystore := range request.Keystores {
+			var decoded struct {
+				Pubkey string `json:"pubkey"`
+			}
+			if err := json.Unmarshal([]byte(keystore), &decoded); err != nil {
+				s.t.Fatalf("error decoding keystore: %s", err.Error())
+			}
+			if s.loadOnImport {
+				s.loaded = append(s.loaded, hexutil.AddPrefix(decoded.Pubkey))
+			}
+			response.Data = append(response.Data, keyStatus{Status: s.importStatus})
+		}
+		json.NewEncoder(w).Encode(response)
+
+	case r.URL.Path == KeystoresPath && r.Method == http.MethodGet:
+		response := listKeystoresResponse{}
+		for _, pubkey := range s.loaded {
+			response.Data = append(response.Data, struct {
+				ValidatingPubkey string `json:"validating_pubkey"`
+			}{pubkey})
+		}
+		json.NewEncoder(w).Encode(response)
+
+	case r.URL.Path == KeystoresPath && r.Method == http.MethodDelete:
+		var request deleteKeysRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			s.t.Fatalf("error decoding delete request: %s", err.Error())
+		}
+		response := keyStatusResponse{SlashingProtection: stubSlashingProtection}
+		for _, pubkey := range request.Pubkeys {
+			status := "not_found"
+			for i, loaded := range s.loaded {
+				if loaded == pubkey {
+					s.loaded = append(s.loaded[:i], s.loaded[i+1:]...)
+					status = "deleted"
+					break
+				}
+			}
+			response.Data = append(response.Data, keyStatus{Status: status})
+		}
+		json.NewEncoder(w).Encode(response)
+
+	case strings.HasSuffix(r.URL.Path, "/graffiti") && r.Method == http.MethodPost:
+		var request setGraffitiRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			s.t.Fatalf("error decoding graffiti request: %s", err.Error())
+		}
+		s.graffiti[r.URL.Path] = request.Graffiti
+		w.WriteHeader(http.StatusAccepted)
+
+	case strings.HasSuffix(r.URL.Path, "/feerecipient") && r.Method == http.MethodPost:
+		var request setFeeRecipientRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			s.t.Fatalf("error decoding fee recipient request: %s", err.Error())
+		}
+		s.feeRecipient[r.URL.Path] = request.EthAddress
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// Create a stub keymanager API and a client for it that reads its token from a file
+func newStubKeymanager(t *testing.T, importStatus string, loadOnImport bool) (*stubKeymanager, *Client) {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := CreateTokenFile(tokenPath); err != nil {
+		t.Fatal(err)
+	}
+	token, err := os.ReadFile(tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub := &stubKeymanager{
+		t:            t,
+		token:        string(token),
+		importStatus: importStatus,
+		loadOnImport: loadOnImport,
+		feeRecipient: map[string]string{},
+		graffiti:     map[string]string{},
+	}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return stub, NewClientWithTokenFile(server.URL+"/", tokenPath)
+}
+
+func generateKey(t *testing.T) *eth2types.BLSPrivateKey {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestImportKeys(t *testing.T) {
+	stub, client := newStubKeymanager(t, "imported", true)
+	key := generateKey(t)
+	if err := client.ImportKeys([]*eth2types.BLSPrivateKey{key}, nil); err != nil {
+		t.Fatalf("import failed: %s", err.Error())
+	}
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	if len(stub.loaded) != 1 || stub.loaded[0] != hexutil.AddPrefix(pubkey.Hex()) {
+		t.Fatalf("expected %s to be loaded, got %v", pubkey.Hex(), stub.loaded)
+	}
+
+	loaded, err := client.ListKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 1 || loaded[0] != pubkey {
+		t.Fatalf("expected ListKeys to return %s, got %v", pubkey.Hex(), loaded)
+	}
+}
+
+func TestImportDuplicateKeyThatIsLoaded(t *testing.T) {
+	stub, client := newStubKeymanager(t, "duplicate", false)
+	key := generateKey(t)
+	stub.loaded = []string{hexutil.AddPrefix(types.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex())}
+	if err := client.ImportKeys([]*eth2types.BLSPrivateKey{key}, nil); err != nil {
+		t.Fatalf("importing an active duplicate should succeed: %s", err.Error())
+	}
+}
+
+func TestImportDuplicateKeyThatIsNotLoaded(t *testing.T) {
+	_, client := newStubKeymanager(t, "duplicate", false)
+	err := client.ImportKeys([]*eth2types.BLSPrivateKey{generateKey(t)}, nil)
+	if err == nil || !strings.Contains(err.Error(), "isn't active") {
+		t.Fatalf("expected an inactive key error, got %v", err)
+	}
+}
+
+func TestImportKeysWithErrorStatus(t *testing.T) {
+	_, client := newStubKeymanager(t, "error", true)
+	err := client.ImportKeys([]*eth2types.BLSPrivateKey{generateKey(t)}, nil)
+	if err == nil || !strings.Contains(err.Error(), "error") {
+		t.Fatalf("expected an error status to fail the import, got %v", err)
+	}
+}
+
+func TestSetFeeRecipient(t *testing.T) {
+	stub, client := newStubKeymanager(t, "imported", true)
+	pubkey := types.BytesToValidatorPubkey(generateKey(t).PublicKey().Marshal())
+	feeRecipient := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	if err := client.SetFeeRecipient(pubkey, feeRecipient); err != nil {
+		t.Fatal(err)
+	}
+	path := "/eth/v1/validator/" + hexutil.AddPrefix(pubkey.Hex()) + "/feerecipient"
+	if stub.feeRecipient[path] != feeRecipient.Hex() {
+		t.Fatalf("expected %s to be set on %s, got %v", feeRecipient.Hex(), path, stub.feeRecipient)
+	}
+}
+
+func TestDeleteKeys(t *testing.T) {
+	stub, client := newStubKeymanager(t, "imported", true)
+	loadedKey := types.BytesToValidatorPubkey(generateKey(t).PublicKey().Marshal())
+	missingKey := types.BytesToValidatorPubkey(generateKey(t).PublicKey().Marshal())
+	stub.loaded = []string{hexutil.AddPrefix(loadedKey.Hex())}
+
+	slashingProtection, err := client.DeleteKeys([]types.ValidatorPubkey{loadedKey, missingKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slashingProtection != stubSlashingProtection {
+		t.Errorf("expected the slashing protection data, got %q", slashingProtection)
+	}
+	if len(stub.loaded) != 0 {
+		t.Errorf("expected the key to be deleted, got %v", stub.loaded)
+	}
+}
+
+func TestSetGraffiti(t *testing.T) {
+	stub, client := newStubKeymanager(t, "imported", true)
+	pubkey := types.BytesToValidatorPubkey(generateKey(t).PublicKey().Marshal())
+	if err := client.SetGraffiti(pubkey, "RP-GL v1.14.0 (hello)"); err != nil {
+		t.Fatal(err)
+	}
+	path := "/eth/v1/validator/" + hexutil.AddPrefix(pubkey.Hex()) + "/graffiti"
+	if stub.graffiti[path] != "RP-GL v1.14.0 (hello)" {
+		t.Fatalf("expected the graffiti to be set on %s, got %v", path, stub.graffiti)
+	}
+}
+
+func TestRequestWithBadToken(t *testing.T) {
+	stub, client := newStubKeymanager(t, "imported", true)
+	stub.token = "something else"
+	_, err := client.ListKeys()
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "bad token") {
+		t.Fatalf("expected the HTTP status and body in the error, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 )
 
 // Config
@@ -12,27 +13,61 @@ const (
 	FileMode          = 0600
 )
 
+// Returned when the password isn't saved to disk and hasn't been unlocked yet
+var ErrWalletLocked = errors.New("The node wallet is locked")
+
 // Password manager
 type PasswordManager struct {
 	passwordPath string
+
+	// If false, the password is never written to disk and is only held in memory once it's unlocked
+	savePassword   bool
+	memoryPassword string
+	lock           sync.RWMutex
 }
 
 // Create new password manager
-func NewPasswordManager(passwordPath string) *PasswordManager {
+func NewPasswordManager(passwordPath string, savePassword bool) *PasswordManager {
 	return &PasswordManager{
 		passwordPath: passwordPath,
+		savePassword: savePassword,
 	}
 }
 
+// Check if the password is saved to disk, rather than held in memory
+func (pm *PasswordManager) IsPasswordSaved() bool {
+	return pm.savePassword
+}
+
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
+	if !pm.savePassword {
+		pm.lock.RLock()
+		defer pm.lock.RUnlock()
+		return (pm.memoryPassword != "")
+	}
 	_, err := os.ReadFile(pm.passwordPath)
 	return (err == nil)
 }
 
+// Check if the password is held in memory and hasn't been unlocked yet
+func (pm *PasswordManager) IsLocked() bool {
+	return !pm.savePassword && !pm.IsPasswordSet()
+}
+
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
 
+	// Read from memory
+	if !pm.savePassword {
+		pm.lock.RLock()
+		defer pm.lock.RUnlock()
+		if pm.memoryPassword == "" {
+			return "", ErrWalletLocked
+		}
+		return pm.memoryPassword, nil
+	}
+
 	// Read from disk
 	password, err := os.ReadFile(pm.passwordPath)
 	if err != nil {
@@ -57,6 +92,14 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
+	// Hold it in memory if it shouldn't be saved
+	if !pm.savePassword {
+		pm.lock.Lock()
+		defer pm.lock.Unlock()
+		pm.memoryPassword = password
+		return nil
+	}
+
 	// Write to disk
 	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write password to disk: %w", err)
@@ -67,9 +110,45 @@ func (pm *PasswordManager) SetPassword(password string) error {
 
 }
 
+// Unlock the wallet by holding its password in memory.
+// verify is called with the password first, and should return an error if it can't decrypt the wallet.
+func (pm *PasswordManager) Unlock(password string, verify func(password string) error) error {
+
+	// Check the password is meant to be unlocked
+	if pm.savePassword {
+		return errors.New("The node wallet password is saved to disk, so the wallet doesn't need to be unlocked")
+	}
+	pm.lock.RLock()
+	memoryPassword := pm.memoryPassword
+	pm.lock.RUnlock()
+	if memoryPassword != "" {
+		if password != memoryPassword {
+			return errors.New("The node wallet is already unlocked with a different password")
+		}
+		return nil
+	}
+
+	// Make sure it's the right password
+	if err := verify(password); err != nil {
+		return err
+	}
+
+	// Hold it in memory
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	pm.memoryPassword = password
+	return nil
+
+}
+
 // Delete the password
 func (pm *PasswordManager) DeletePassword() error {
 
+	// Forget it if it's held in memory
+	pm.lock.Lock()
+	pm.memoryPassword = ""
+	pm.lock.Unlock()
+
 	// Check if it exists
 	_, err := os.Stat(pm.passwordPath)
 	if os.IsNotExist(err) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"sync"
 	"time"
 
@@ -40,12 +41,27 @@ func RequireNodePassword(c *cli.Context) error {
 		return err
 	}
 	if !nodePasswordSet {
+		nodeWalletLocked, err := IsNodeWalletLocked(c)
+		if err != nil {
+			return err
+		}
+		if nodeWalletLocked {
+			return errors.New("The node wallet is locked. Please run 'rocketpool wallet unlock' and try again.")
+		}
 		return errors.New("The node password has not been set. Please run 'rocketpool wallet init' and try again.")
 	}
 	return nil
 }
 
 func RequireNodeWallet(c *cli.Context) error {
+	// Transactions exported for offline signing only need the node address, which can be provided instead of the wallet
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if w.IsAddressOnly() {
+		return nil
+	}
 	if err := RequireNodePassword(c); err != nil {
 		return err
 	}
@@ -160,7 +176,15 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 			return nil
 		}
 		if verbose {
-			log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
+			nodeWalletLocked, err := IsNodeWalletLocked(c)
+			if err != nil {
+				return err
+			}
+			if nodeWalletLocked {
+				log.Printf("The node wallet is locked, waiting for it to be unlocked with 'rocketpool wallet unlock'; checking again in %s...\n", checkNodePasswordInterval.String())
+			} else {
+				log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
+			}
 		}
 		time.Sleep(checkNodePasswordInterval)
 	}
@@ -249,6 +273,28 @@ func getNodePasswordSet(c *cli.Context) (bool, error) {
 	return pm.IsPasswordSet(), nil
 }
 
+// Check if the node wallet exists but can't be used until its password is unlocked
+func IsNodeWalletLocked(c *cli.Context) (bool, error) {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return false, err
+	}
+	pm, err := GetPasswordManager(c)
+	if err != nil {
+		return false, err
+	}
+	if !pm.IsLocked() {
+		return false, nil
+	}
+	_, err = os.Stat(os.ExpandEnv(cfg.Smartnode.GetWalletPath()))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error checking wallet file: %w", err)
+	}
+	return true, nil
+}
+
 // Check if the node wallet is initialized
 func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 	w, err := GetWallet(c)
@@ -0,0 +1,151 @@
+package rewards
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"golang.org/x/sync/errgroup"
+)
+
+// The committees and attestations for a single epoch
+type epochData struct {
+	epoch               uint64
+	committees          beacon.Committees
+	attestationsPerSlot [][]beacon.AttestationInfo
+	err                 error
+}
+
+// Return the committees to the pool; must be called once the epoch has been processed
+func (d *epochData) release() {
+	if d.committees != nil {
+		d.committees.Release()
+		d.committees = nil
+	}
+}
+
+// Get the committee info (optionally) and attestation records for an epoch from the Beacon node
+func fetchEpochData(bc beacon.Client, slotsPerEpoch uint64, getDuties bool, epoch uint64) *epochData {
+	data := &epochData{
+		epoch:               epoch,
+		attestationsPerSlot: make([][]beacon.AttestationInfo, slotsPerEpoch),
+	}
+	var wg errgroup.Group
+
+	if getDuties {
+		wg.Go(func() error {
+			var err error
+			data.committees, err = bc.GetCommitteesForEpoch(&epoch)
+			return err
+		})
+	}
+
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		i := i
+		slot := epoch*slotsPerEpoch + i
+		wg.Go(func() error {
+			attestations, found, err := bc.GetAttestations(fmt.Sprint(slot))
+			if err != nil {
+				return err
+			}
+			if found {
+				data.attestationsPerSlot[i] = attestations
+			} else {
+				data.attestationsPerSlot[i] = []beacon.AttestationInfo{}
+			}
+			return nil
+		})
+	}
+	err := wg.Wait()
+	if err != nil {
+		data.release()
+		data.err = fmt.Errorf("error getting committee and attestaion records for epoch %d: %w", epoch, err)
+	}
+	return data
+}
+
+// Fetches the data for a range of epochs with a bounded pool of workers, handing the results back in epoch order.
+// At most twice the number of workers (plus the one being processed) are held in memory at any time.
+type epochPrefetcher struct {
+	pending   chan chan *epochData
+	stop      chan struct{}
+	stopOnce  sync.Once
+	nextEpoch uint64
+}
+
+// Start fetching epochs from startEpoch to endEpoch (inclusive). Committees are only fetched for epochs up to lastDutiesEpoch.
+func newEpochPrefetcher(bc beacon.Client, slotsPerEpoch uint64, workers int, startEpoch uint64, endEpoch uint64, lastDutiesEpoch uint64) *epochPrefetcher {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &epochPrefetcher{
+		pending:   make(chan chan *epochData, workers*2),
+		stop:      make(chan struct{}),
+		nextEpoch: startEpoch,
+	}
+
+	type job struct {
+		epoch  uint64
+		result chan *epochData
+	}
+	jobs := make(chan job)
+
+	// Start the workers
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.result <- fetchEpochData(bc, slotsPerEpoch, job.epoch <= lastDutiesEpoch, job.epoch)
+			}
+		}()
+	}
+
+	// Queue the epochs in order, blocking while the window is full
+	go func() {
+		defer close(p.pending)
+		defer close(jobs)
+		for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+			result := make(chan *epochData, 1)
+			select {
+			case p.pending <- result:
+			case <-p.stop:
+				return
+			}
+			select {
+			case jobs <- job{epoch: epoch, result: result}:
+			case <-p.stop:
+				result <- &epochData{epoch: epoch, err: fmt.Errorf("prefetching was stopped")}
+				return
+			}
+		}
+	}()
+
+	return p
+}
+
+// Get the data for the next epoch; epochs must be requested in order
+func (p *epochPrefetcher) next(epoch uint64) (*epochData, error) {
+	if epoch != p.nextEpoch {
+		return nil, fmt.Errorf("requested epoch %d from the prefetcher but expected epoch %d", epoch, p.nextEpoch)
+	}
+	result, ok := <-p.pending
+	if !ok {
+		return nil, fmt.Errorf("epoch %d is outside of the prefetched range", epoch)
+	}
+	data := <-result
+	if data.err != nil {
+		return nil, data.err
+	}
+	p.nextEpoch++
+	return data, nil
+}
+
+// Stop fetching new epochs and return the committees of any unprocessed ones to the pool
+func (p *epochPrefetcher) close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		for result := range p.pending {
+			data := <-result
+			data.release()
+		}
+	})
+}
@@ -0,0 +1,368 @@
+package rewards
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const (
+	// The version of the checkpoint format; checkpoints with a different version are discarded
+	generatorCheckpointVersion uint64 = 1
+
+	// The default number of epochs processed between checkpoints
+	generatorCheckpointEpochInterval uint64 = 100
+
+	checkpointFilenameFormat string = "%d-v%d.json.zst"
+)
+
+// A snapshot of the attestation processing progress for a rewards interval, used to resume tree generation after a restart
+type generatorCheckpoint struct {
+	Version                uint64                                 `json:"version"`
+	Index                  uint64                                 `json:"index"`
+	RulesetVersion         uint64                                 `json:"rulesetVersion"`
+	ConsensusStartBlock    uint64                                 `json:"consensusStartBlock"`
+	ConsensusEndBlock      uint64                                 `json:"consensusEndBlock"`
+	ExecutionEndBlock      uint64                                 `json:"executionEndBlock"`
+	NextEpoch              uint64                                 `json:"nextEpoch"`
+	TotalAttestationScore  *QuotedBigInt                          `json:"totalAttestationScore"`
+	SuccessfulAttestations uint64                                 `json:"successfulAttestations"`
+	Minipools              map[common.Address]*minipoolCheckpoint `json:"minipools"`
+	PendingDuties          []pendingDutyCheckpoint                `json:"pendingDuties"`
+}
+
+// The attestation progress of a single minipool
+type minipoolCheckpoint struct {
+	AttestationScore        *QuotedBigInt `json:"attestationScore"`
+	MissingAttestationSlots []uint64      `json:"missingAttestationSlots"`
+	CompletedAttestations   []uint64      `json:"completedAttestations"`
+}
+
+// An attestation duty that hasn't been seen yet but could still be included in a later epoch
+type pendingDutyCheckpoint struct {
+	Slot      uint64         `json:"slot"`
+	Committee uint64         `json:"committee"`
+	Position  int            `json:"position"`
+	Minipool  common.Address `json:"minipool"`
+}
+
+// Saves and loads tree generation checkpoints, verifying each one against a SHA384 checksum table
+type checkpointManager struct {
+	log          *log.ColorLogger
+	logPrefix    string
+	path         string
+	compressor   *zstd.Encoder
+	decompressor *zstd.Decoder
+}
+
+// Creates a new checkpoint manager, making the checkpoint folder if it doesn't exist
+func newCheckpointManager(log *log.ColorLogger, logPrefix string, cfg *config.RocketPoolConfig) (*checkpointManager, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd compressor for checkpoint manager: %w", err)
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor for checkpoint manager: %w", err)
+	}
+
+	path := cfg.Smartnode.GetRewardsCheckpointPath()
+	err = os.MkdirAll(path, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating rewards checkpoint folder: %w", err)
+	}
+
+	return &checkpointManager{
+		log:          log,
+		logPrefix:    logPrefix,
+		path:         path,
+		compressor:   encoder,
+		decompressor: decoder,
+	}, nil
+}
+
+// Save a checkpoint to disk, replacing any previous checkpoint for the same interval and ruleset
+func (m *checkpointManager) save(checkpoint *generatorCheckpoint) error {
+	// Serialize and compress the checkpoint
+	bytes, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("error serializing checkpoint: %w", err)
+	}
+	compressedBytes := m.compressor.EncodeAll(bytes, make([]byte, 0, len(bytes)))
+
+	// Write it to a file
+	baseFilename := fmt.Sprintf(checkpointFilenameFormat, checkpoint.Index, checkpoint.RulesetVersion)
+	filename := filepath.Join(m.path, baseFilename)
+	err = os.WriteFile(filename, compressedBytes, 0664)
+	if err != nil {
+		return fmt.Errorf("error writing file [%s]: %w", filename, err)
+	}
+
+	// Add or replace its entry in the checksum table
+	checksum := sha512.Sum384(compressedBytes)
+	lines, err := m.parseChecksumFile()
+	if err != nil {
+		return fmt.Errorf("error parsing checksum file: %w", err)
+	}
+	checksumLine := fmt.Sprintf("%s  %s", hex.EncodeToString(checksum[:]), baseFilename)
+	overwritten := false
+	for i, line := range lines {
+		if strings.HasSuffix(line, "  "+baseFilename) {
+			lines[i] = checksumLine
+			overwritten = true
+			break
+		}
+	}
+	if !overwritten {
+		lines = append(lines, checksumLine)
+	}
+	return m.writeChecksumFile(lines)
+}
+
+// Load the checkpoint for the given interval and ruleset, returning nil if there isn't one
+func (m *checkpointManager) load(index uint64, rulesetVersion uint64) (*generatorCheckpoint, error) {
+	baseFilename := fmt.Sprintf(checkpointFilenameFormat, index, rulesetVersion)
+	lines, err := m.parseChecksumFile()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing checksum file: %w", err)
+	}
+
+	// Find the checksum for the checkpoint
+	var expectedChecksum []byte
+	for _, line := range lines {
+		elems := strings.Split(line, "  ")
+		if len(elems) != 2 {
+			return nil, fmt.Errorf("error parsing checksum line (%s): expected 2 elements, but got %d", line, len(elems))
+		}
+		if elems[1] != baseFilename {
+			continue
+		}
+		expectedChecksum, err = hex.DecodeString(elems[0])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding checksum for [%s]: %w", baseFilename, err)
+		}
+		break
+	}
+	if expectedChecksum == nil {
+		return nil, nil
+	}
+
+	// Read the file and validate its checksum
+	filename := filepath.Join(m.path, baseFilename)
+	compressedBytes, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	checksum := sha512.Sum384(compressedBytes)
+	if !bytes.Equal(expectedChecksum, checksum[:]) {
+		return nil, fmt.Errorf("checksum mismatch for [%s] (expected %s, but it was %s)", baseFilename, hex.EncodeToString(expectedChecksum), hex.EncodeToString(checksum[:]))
+	}
+
+	// Decompress and deserialize it
+	bytes, err := m.decompressor.DecodeAll(compressedBytes, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing data: %w", err)
+	}
+	var checkpoint generatorCheckpoint
+	err = json.Unmarshal(bytes, &checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Delete the checkpoint for the given interval and ruleset along with its checksum entry
+func (m *checkpointManager) delete(index uint64, rulesetVersion uint64) error {
+	baseFilename := fmt.Sprintf(checkpointFilenameFormat, index, rulesetVersion)
+	filename := filepath.Join(m.path, baseFilename)
+	err := os.Remove(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting file [%s]: %w", filename, err)
+	}
+
+	lines, err := m.parseChecksumFile()
+	if err != nil {
+		return fmt.Errorf("error parsing checksum file: %w", err)
+	}
+	newLines := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if !strings.HasSuffix(line, "  "+baseFilename) {
+			newLines = append(newLines, line)
+		}
+	}
+	return m.writeChecksumFile(newLines)
+}
+
+// Get the non-empty lines from the checksum file
+func (m *checkpointManager) parseChecksumFile() ([]string, error) {
+	checksumFilename := filepath.Join(m.path, config.ChecksumTableFilename)
+	checksumTable, err := os.ReadFile(checksumFilename)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading checksum table (%s): %w", checksumFilename, err)
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(string(checksumTable), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// Replace the contents of the checksum file
+func (m *checkpointManager) writeChecksumFile(lines []string) error {
+	checksumFilename := filepath.Join(m.path, config.ChecksumTableFilename)
+	err := os.WriteFile(checksumFilename, []byte(strings.Join(lines, "\n")), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing checksum file: %w", err)
+	}
+	return nil
+}
+
+// Capture the attestation progress of the generator, where every epoch before nextEpoch has been processed
+func (r *treeGeneratorImpl_v8) createCheckpoint(nextEpoch uint64) *generatorCheckpoint {
+	checkpoint := &generatorCheckpoint{
+		Version:                generatorCheckpointVersion,
+		Index:                  r.rewardsFile.Index,
+		RulesetVersion:         r.rewardsFile.RulesetVersion,
+		ConsensusStartBlock:    r.rewardsFile.ConsensusStartBlock,
+		ConsensusEndBlock:      r.rewardsFile.ConsensusEndBlock,
+		ExecutionEndBlock:      r.rewardsFile.ExecutionEndBlock,
+		NextEpoch:              nextEpoch,
+		TotalAttestationScore:  NewQuotedBigInt(0),
+		SuccessfulAttestations: r.successfulAttestations,
+		Minipools:              map[common.Address]*minipoolCheckpoint{},
+		PendingDuties:          []pendingDutyCheckpoint{},
+	}
+	checkpoint.TotalAttestationScore.Set(r.totalAttestationScore)
+
+	for _, minipoolInfo := range r.validatorIndexMap {
+		if len(minipoolInfo.MissingAttestationSlots) == 0 && len(minipoolInfo.CompletedAttestations) == 0 {
+			continue
+		}
+		minipoolCheckpoint := &minipoolCheckpoint{
+			AttestationScore:        NewQuotedBigInt(0),
+			MissingAttestationSlots: getSortedSlots(minipoolInfo.MissingAttestationSlots),
+			CompletedAttestations:   getSortedSlots(minipoolInfo.CompletedAttestations),
+		}
+		minipoolCheckpoint.AttestationScore.Set(&minipoolInfo.AttestationScore.Int)
+		checkpoint.Minipools[minipoolInfo.Address] = minipoolCheckpoint
+	}
+
+	// Attestations are only accepted within one epoch of their slot, so older duties can't be completed anymore
+	oldestPendingSlot := uint64(0)
+	if nextEpoch > 0 {
+		oldestPendingSlot = (nextEpoch - 1) * r.slotsPerEpoch
+	}
+	for slotIndex, slotInfo := range r.intervalDutiesInfo.Slots {
+		if slotIndex < oldestPendingSlot {
+			continue
+		}
+		for committeeIndex, committeeInfo := range slotInfo.Committees {
+			for position, minipoolInfo := range committeeInfo.Positions {
+				checkpoint.PendingDuties = append(checkpoint.PendingDuties, pendingDutyCheckpoint{
+					Slot:      slotIndex,
+					Committee: committeeIndex,
+					Position:  position,
+					Minipool:  minipoolInfo.Address,
+				})
+			}
+		}
+	}
+
+	return checkpoint
+}
+
+// Restore the attestation progress from a checkpoint, returning an error if it doesn't belong to this interval
+func (r *treeGeneratorImpl_v8) restoreCheckpoint(checkpoint *generatorCheckpoint) error {
+	if checkpoint.Version != generatorCheckpointVersion {
+		return fmt.Errorf("checkpoint has version %d but this generator requires version %d", checkpoint.Version, generatorCheckpointVersion)
+	}
+	if checkpoint.Index != r.rewardsFile.Index ||
+		checkpoint.RulesetVersion != r.rewardsFile.RulesetVersion ||
+		checkpoint.ConsensusStartBlock != r.rewardsFile.ConsensusStartBlock ||
+		checkpoint.ConsensusEndBlock != r.rewardsFile.ConsensusEndBlock ||
+		checkpoint.ExecutionEndBlock != r.rewardsFile.ExecutionEndBlock {
+		return fmt.Errorf("checkpoint was created for a different interval snapshot")
+	}
+
+	minipools := make(map[common.Address]*MinipoolInfo, len(r.validatorIndexMap))
+	for _, minipoolInfo := range r.validatorIndexMap {
+		minipools[minipoolInfo.Address] = minipoolInfo
+	}
+	for address := range checkpoint.Minipools {
+		if _, exists := minipools[address]; !exists {
+			return fmt.Errorf("checkpoint contains minipool %s which isn't being tracked", address.Hex())
+		}
+	}
+	for _, duty := range checkpoint.PendingDuties {
+		if _, exists := minipools[duty.Minipool]; !exists {
+			return fmt.Errorf("checkpoint contains a duty for minipool %s which isn't being tracked", duty.Minipool.Hex())
+		}
+	}
+
+	// Everything is valid, so apply it
+	r.totalAttestationScore.Set(&checkpoint.TotalAttestationScore.Int)
+	r.successfulAttestations = checkpoint.SuccessfulAttestations
+	for address, minipoolCheckpoint := range checkpoint.Minipools {
+		minipoolInfo := minipools[address]
+		minipoolInfo.AttestationScore.Set(&minipoolCheckpoint.AttestationScore.Int)
+		for _, slot := range minipoolCheckpoint.MissingAttestationSlots {
+			minipoolInfo.MissingAttestationSlots[slot] = true
+		}
+		for _, slot := range minipoolCheckpoint.CompletedAttestations {
+			minipoolInfo.CompletedAttestations[slot] = true
+		}
+	}
+	for _, duty := range checkpoint.PendingDuties {
+		slotInfo, exists := r.intervalDutiesInfo.Slots[duty.Slot]
+		if !exists {
+			slotInfo = &SlotInfo{
+				Index:      duty.Slot,
+				Committees: map[uint64]*CommitteeInfo{},
+			}
+			r.intervalDutiesInfo.Slots[duty.Slot] = slotInfo
+		}
+		committeeInfo, exists := slotInfo.Committees[duty.Committee]
+		if !exists {
+			committeeInfo = &CommitteeInfo{
+				Index:     duty.Committee,
+				Positions: map[int]*MinipoolInfo{},
+			}
+			slotInfo.Committees[duty.Committee] = committeeInfo
+		}
+		committeeInfo.Positions[duty.Position] = minipools[duty.Minipool]
+	}
+
+	return nil
+}
+
+// Get the slots in a slot set in ascending order
+func getSortedSlots(slots map[uint64]bool) []uint64 {
+	sortedSlots := make([]uint64, 0, len(slots))
+	for slot := range slots {
+		sortedSlots = append(sortedSlots, slot)
+	}
+	sort.Slice(sortedSlots, func(i, j int) bool {
+		return sortedSlots[i] < sortedSlots[j]
+	})
+	return sortedSlots
+}
@@ -51,6 +51,10 @@ type treeGeneratorImpl_v8 struct {
 	totalAttestationScore  *big.Int
 	successfulAttestations uint64
 	genesisTime            time.Time
+	epochWorkers           int
+	checkpointInterval     uint64
+	prefetcher             *epochPrefetcher
+	previousIntervalEvent  *rewards.RewardsEvent
 }
 
 // Create a new tree generator
@@ -94,6 +98,7 @@ func newTreeGeneratorImpl_v8(log *log.ColorLogger, logPrefix string, index uint6
 		logPrefix:             logPrefix,
 		totalAttestationScore: big.NewInt(0),
 		networkState:          state,
+		checkpointInterval:    generatorCheckpointEpochInterval,
 	}
 }
 
@@ -113,6 +118,7 @@ func (r *treeGeneratorImpl_v8) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
+	r.epochWorkers = int(cfg.Smartnode.TreeGenerationWorkers.Value.(uint64))
 
 	// Set the network name
 	r.rewardsFile.Network = fmt.Sprint(cfg.Smartnode.Network.Value)
@@ -529,8 +535,7 @@ func (r *treeGeneratorImpl_v8) calculateEthRewards(checkBeaconPerformance bool)
 	}
 
 	// Get the start time of this interval based on the event from the previous one
-	//previousIntervalEvent, err := GetRewardSnapshotEvent(r.rp, r.cfg, r.rewardsFile.Index-1, r.opts) // This is immutable so querying at the head is fine and mitigates issues around calls for pruned EL state
-	previousIntervalEvent, err := GetRewardSnapshotEvent(r.rp, r.cfg, r.rewardsFile.Index-1, nil)
+	previousIntervalEvent, err := r.getPreviousIntervalEvent()
 	if err != nil {
 		return err
 	}
@@ -741,17 +746,43 @@ func (r *treeGeneratorImpl_v8) processAttestationsForInterval() error {
 		return err
 	}
 
+	// Resume from the last checkpoint if there is one
+	firstEpoch := startEpoch
+	checkpoints, err := newCheckpointManager(r.log, r.logPrefix, r.cfg)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't create checkpoint manager, progress won't be saved: %s", r.logPrefix, err.Error())
+		checkpoints = nil
+	} else {
+		firstEpoch = r.resumeFromCheckpoint(checkpoints, startEpoch, endEpoch)
+	}
+
 	// Check all of the attestations for each epoch
 	r.log.Printlnf("%s Checking participation of %d minipools for epochs %d to %d", r.logPrefix, len(r.validatorIndexMap), startEpoch, endEpoch)
-	r.log.Printlnf("%s NOTE: this will take a long time, progress is reported every 100 epochs", r.logPrefix)
+	r.log.Printlnf("%s NOTE: this will take a long time, progress is reported every %d epochs", r.logPrefix, r.checkpointInterval)
+
+	// Fetch the epochs ahead of time in parallel; they're still processed in order so the results are deterministic
+	if r.epochWorkers > 1 {
+		r.prefetcher = newEpochPrefetcher(r.bc, r.slotsPerEpoch, r.epochWorkers, firstEpoch, endEpoch+1, endEpoch)
+		defer func() {
+			r.prefetcher.close()
+			r.prefetcher = nil
+		}()
+	}
 
-	epochsDone := 0
+	epochsDone := uint64(0)
 	reportStartTime := time.Now()
-	for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
-		if epochsDone == 100 {
+	for epoch := firstEpoch; epoch < endEpoch+1; epoch++ {
+		if epochsDone == r.checkpointInterval {
 			timeTaken := time.Since(reportStartTime)
 			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, epoch, endEpoch, float64(epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
 			epochsDone = 0
+
+			if checkpoints != nil {
+				err = checkpoints.save(r.createCheckpoint(epoch))
+				if err != nil {
+					r.log.Printlnf("%s WARNING: couldn't save checkpoint for epoch %d: %s", r.logPrefix, epoch, err.Error())
+				}
+			}
 		}
 
 		err := r.processEpoch(true, epoch)
@@ -769,51 +800,66 @@ func (r *treeGeneratorImpl_v8) processAttestationsForInterval() error {
 		return err
 	}
 
+	// Processing is done so the checkpoint isn't needed anymore
+	if checkpoints != nil {
+		err = checkpoints.delete(r.rewardsFile.Index, r.rewardsFile.RulesetVersion)
+		if err != nil {
+			r.log.Printlnf("%s WARNING: couldn't delete checkpoint: %s", r.logPrefix, err.Error())
+		}
+	}
+
 	r.log.Printlnf("%s Finished participation check (total time = %s)", r.logPrefix, time.Since(reportStartTime))
 	return nil
 
 }
 
+// Load the saved checkpoint for this interval if one exists, returning the epoch that processing should start from
+func (r *treeGeneratorImpl_v8) resumeFromCheckpoint(checkpoints *checkpointManager, startEpoch uint64, endEpoch uint64) uint64 {
+	checkpoint, err := checkpoints.load(r.rewardsFile.Index, r.rewardsFile.RulesetVersion)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't load checkpoint, starting from the beginning of the interval: %s", r.logPrefix, err.Error())
+		return startEpoch
+	}
+	if checkpoint == nil {
+		return startEpoch
+	}
+	if checkpoint.NextEpoch <= startEpoch || checkpoint.NextEpoch > endEpoch+1 {
+		r.log.Printlnf("%s WARNING: checkpoint epoch %d is outside of the interval, starting from the beginning of the interval", r.logPrefix, checkpoint.NextEpoch)
+		return startEpoch
+	}
+
+	err = r.restoreCheckpoint(checkpoint)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't restore checkpoint, starting from the beginning of the interval: %s", r.logPrefix, err.Error())
+		return startEpoch
+	}
+
+	r.log.Printlnf("%s Resuming from checkpoint at epoch %d.", r.logPrefix, checkpoint.NextEpoch)
+	return checkpoint.NextEpoch
+}
+
 // Process an epoch, optionally getting the duties for all eligible minipools in it and checking each one's attestation performance
 func (r *treeGeneratorImpl_v8) processEpoch(getDuties bool, epoch uint64) error {
 
 	// Get the committee info and attestation records for this epoch
-	var committeeData beacon.Committees
-	attestationsPerSlot := make([][]beacon.AttestationInfo, r.slotsPerEpoch)
-	var wg errgroup.Group
-
-	if getDuties {
-		wg.Go(func() error {
-			var err error
-			committeeData, err = r.bc.GetCommitteesForEpoch(&epoch)
+	var data *epochData
+	if r.prefetcher != nil {
+		var err error
+		data, err = r.prefetcher.next(epoch)
+		if err != nil {
 			return err
-		})
-	}
-
-	for i := uint64(0); i < r.slotsPerEpoch; i++ {
-		i := i
-		slot := epoch*r.slotsPerEpoch + i
-		wg.Go(func() error {
-			attestations, found, err := r.bc.GetAttestations(fmt.Sprint(slot))
-			if err != nil {
-				return err
-			}
-			if found {
-				attestationsPerSlot[i] = attestations
-			} else {
-				attestationsPerSlot[i] = []beacon.AttestationInfo{}
-			}
-			return nil
-		})
-	}
-	err := wg.Wait()
-	if err != nil {
-		return fmt.Errorf("error getting committee and attestaion records for epoch %d: %w", epoch, err)
+		}
+	} else {
+		data = fetchEpochData(r.bc, r.slotsPerEpoch, getDuties, epoch)
+		if data.err != nil {
+			return data.err
+		}
 	}
+	defer data.release()
 
-	if getDuties {
+	if data.committees != nil {
 		// Get all of the expected duties for the epoch
-		err = r.getDutiesForEpoch(committeeData)
+		err := r.getDutiesForEpoch(data.committees)
 		if err != nil {
 			return fmt.Errorf("error getting duties for epoch %d: %w", epoch, err)
 		}
@@ -822,7 +868,7 @@ func (r *treeGeneratorImpl_v8) processEpoch(getDuties bool, epoch uint64) error
 	// Process all of the slots in the epoch
 	for i := uint64(0); i < r.slotsPerEpoch; i++ {
 		inclusionSlot := epoch*r.slotsPerEpoch + i
-		attestations := attestationsPerSlot[i]
+		attestations := data.attestationsPerSlot[i]
 		if len(attestations) > 0 {
 			r.checkDutiesForSlot(attestations, inclusionSlot)
 		}
@@ -1121,6 +1167,19 @@ func (r *treeGeneratorImpl_v8) validateNetwork(network uint64) (bool, error) {
 	return valid, nil
 }
 
+// Get the rewards snapshot event for the previous interval, which marks the start of this one
+func (r *treeGeneratorImpl_v8) getPreviousIntervalEvent() (rewards.RewardsEvent, error) {
+	if r.previousIntervalEvent == nil {
+		//event, err := GetRewardSnapshotEvent(r.rp, r.cfg, r.rewardsFile.Index-1, r.opts) // This is immutable so querying at the head is fine and mitigates issues around calls for pruned EL state
+		event, err := GetRewardSnapshotEvent(r.rp, r.cfg, r.rewardsFile.Index-1, nil)
+		if err != nil {
+			return rewards.RewardsEvent{}, err
+		}
+		r.previousIntervalEvent = &event
+	}
+	return *r.previousIntervalEvent, nil
+}
+
 // Gets the start blocks for the given interval
 func (r *treeGeneratorImpl_v8) getStartBlocksForInterval(previousIntervalEvent rewards.RewardsEvent) (*types.Header, error) {
 	// Sanity check to confirm the BN can access the block from the previous interval
@@ -0,0 +1,423 @@
+package rewards
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/fixture"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const (
+	fixtureGenesisTime       uint64 = 1606824023
+	fixtureSecondsPerSlot    uint64 = 12
+	fixtureSlotsPerEpoch     uint64 = 32
+	fixtureValidatorCount    int    = 512
+	fixtureCommitteesPerSlot int    = 2
+	fixtureStartEpoch        uint64 = 10
+	fixtureEndEpoch          uint64 = 40
+)
+
+// A single committee in the beacon fixture
+type fixtureCommittee struct {
+	index      uint64
+	slot       uint64
+	validators []string
+}
+
+// Committees for an epoch served from the beacon fixture, tracking when they're released
+type fixtureCommittees struct {
+	committees []fixtureCommittee
+	released   *atomic.Int64
+}
+
+func (c *fixtureCommittees) Index(idx int) uint64        { return c.committees[idx].index }
+func (c *fixtureCommittees) Slot(idx int) uint64         { return c.committees[idx].slot }
+func (c *fixtureCommittees) Validators(idx int) []string { return c.committees[idx].validators }
+func (c *fixtureCommittees) Count() int                  { return len(c.committees) }
+func (c *fixtureCommittees) Release()                    { c.released.Add(1) }
+
+// A Beacon client that serves committees and attestations from a pre-built fixture
+type fixtureBeaconClient struct {
+	beacon.Client
+	committees   map[uint64][]fixtureCommittee
+	attestations map[uint64][]beacon.AttestationInfo
+	fetched      atomic.Int64
+	released     atomic.Int64
+}
+
+func (c *fixtureBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	committees, exists := c.committees[*epoch]
+	if !exists {
+		return nil, fmt.Errorf("epoch %d is not in the fixture", *epoch)
+	}
+	c.fetched.Add(1)
+	return &fixtureCommittees{
+		committees: committees,
+		released:   &c.released,
+	}, nil
+}
+
+func (c *fixtureBeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		return nil, false, err
+	}
+	attestations, exists := c.attestations[slot]
+	if !exists {
+		return nil, false, nil
+	}
+	return attestations, true, nil
+}
+
+func (c *fixtureBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		return beacon.BeaconBlock{}, false, err
+	}
+	return beacon.BeaconBlock{
+		Slot:                 slot,
+		HasExecutionPayload:  true,
+		ExecutionBlockNumber: slot,
+	}, true, nil
+}
+
+// An Execution client for the fixture chain, which has one block per slot with the same number and time as the slot
+type fixtureExecutionClient struct {
+	rocketpool.ExecutionClient
+}
+
+func (c *fixtureExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return newFixtureHeader(number.Uint64()), nil
+}
+
+// Get the header of a block in the fixture chain
+func newFixtureHeader(block uint64) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(block),
+		Time:       uint64(fixtureSlotTime(block).Unix()),
+		Difficulty: big.NewInt(0),
+	}
+}
+
+// Build a deterministic fixture with shuffled committees, late and missing attestations, and missed blocks
+func newFixtureBeaconClient() *fixtureBeaconClient {
+	random := rand.New(rand.NewSource(8))
+	client := &fixtureBeaconClient{
+		committees:   map[uint64][]fixtureCommittee{},
+		attestations: map[uint64][]beacon.AttestationInfo{},
+	}
+
+	// Every validator is assigned to exactly one committee per epoch
+	committeeSize := fixtureValidatorCount / (int(fixtureSlotsPerEpoch) * fixtureCommitteesPerSlot)
+	for epoch := fixtureStartEpoch; epoch <= fixtureEndEpoch+1; epoch++ {
+		committees := []fixtureCommittee{}
+		permutation := random.Perm(fixtureValidatorCount)
+		for i := uint64(0); i < fixtureSlotsPerEpoch; i++ {
+			slot := epoch*fixtureSlotsPerEpoch + i
+			for committeeIndex := 0; committeeIndex < fixtureCommitteesPerSlot; committeeIndex++ {
+				offset := (int(i)*fixtureCommitteesPerSlot + committeeIndex) * committeeSize
+				validators := make([]string, committeeSize)
+				for position := range validators {
+					validators[position] = fmt.Sprint(permutation[offset+position])
+				}
+				committees = append(committees, fixtureCommittee{
+					index:      uint64(committeeIndex),
+					slot:       slot,
+					validators: validators,
+				})
+
+				// Split the committee's votes into a few aggregates, some of which arrive late or not at all
+				for aggregate := 0; aggregate < 3; aggregate++ {
+					bits := bitfield.NewBitlist(uint64(committeeSize))
+					for position := 0; position < committeeSize; position++ {
+						if random.Intn(4) == 0 {
+							bits.SetBitAt(uint64(position), true)
+						}
+					}
+					inclusionSlot := slot + 1 + uint64(random.Intn(int(fixtureSlotsPerEpoch)+4))
+					client.attestations[inclusionSlot] = append(client.attestations[inclusionSlot], beacon.AttestationInfo{
+						AggregationBits: bits,
+						SlotIndex:       slot,
+						CommitteeIndex:  uint64(committeeIndex),
+					})
+				}
+			}
+		}
+		client.committees[epoch] = committees
+	}
+
+	// Drop some blocks entirely
+	for slot := fixtureStartEpoch * fixtureSlotsPerEpoch; slot < (fixtureEndEpoch+3)*fixtureSlotsPerEpoch; slot++ {
+		if random.Intn(20) == 0 {
+			delete(client.attestations, slot)
+		}
+	}
+	return client
+}
+
+// Get the time of the first slot in an epoch of the fixture chain
+func fixtureEpochTime(epoch uint64) time.Time {
+	return fixtureSlotTime(epoch * fixtureSlotsPerEpoch)
+}
+
+// Get the time of a slot in the fixture chain
+func fixtureSlotTime(slot uint64) time.Time {
+	return time.Unix(int64(fixtureGenesisTime+slot*fixtureSecondsPerSlot), 0)
+}
+
+// Build a synthetic network with two Smoothing Pool nodes, one of which opts out partway through.
+// The first node is also an Oracle DAO member.
+func newFixtureNetworkState() *state.NetworkState {
+	beforeGenesis := time.Unix(int64(fixtureGenesisTime), 0).Add(-1000 * time.Second)
+	optOutTime := fixtureEpochTime(25)
+
+	builder := fixture.NewNetworkStateBuilder(beacon.Eth2Config{
+		GenesisTime:    fixtureGenesisTime,
+		SecondsPerSlot: fixtureSecondsPerSlot,
+		SlotsPerEpoch:  fixtureSlotsPerEpoch,
+	}, (fixtureEndEpoch+1)*fixtureSlotsPerEpoch-1, (fixtureEndEpoch+1)*fixtureSlotsPerEpoch-1)
+	details := builder.NetworkDetails()
+	details.RplPrice = eth.EthToWei(0.01)
+	details.MinCollateralFraction = eth.EthToWei(0.1)
+	details.PendingRPLRewards = eth.EthToWei(10000)
+	details.NodeOperatorRewardsPercent = eth.EthToWei(0.7)
+	details.TrustedNodeOperatorRewardsPercent = eth.EthToWei(0.15)
+	details.ProtocolDaoRewardsPercent = eth.EthToWei(0.15)
+	details.SmoothingPoolBalance = eth.EthToWei(10)
+	details.IntervalDuration = fixtureEpochTime(fixtureEndEpoch + 1).Sub(fixtureEpochTime(fixtureStartEpoch))
+
+	for nodeIndex := 0; nodeIndex < 2; nodeIndex++ {
+		nodeAddress := common.BigToAddress(big.NewInt(int64(1000 + nodeIndex)))
+		node := fixture.NewNode(nodeAddress, beforeGenesis)
+		node.RplStake = eth.EthToWei(float64(50000 * (1 + 3*nodeIndex)))
+		node.SmoothingPoolRegistrationState = true
+		if nodeIndex == 1 {
+			node.SmoothingPoolRegistrationState = false
+			node.SmoothingPoolRegistrationChanged = big.NewInt(optOutTime.Unix())
+		} else {
+			builder.AddOracleDaoMember(nodeAddress, beforeGenesis)
+		}
+		builder.AddNode(node)
+
+		// Give each node every fourth validator, offset by its index
+		for validatorIndex := nodeIndex; validatorIndex < fixtureValidatorCount; validatorIndex += 4 {
+			minipoolAddress := common.BigToAddress(big.NewInt(int64(validatorIndex + 1)))
+			var pubkey rptypes.ValidatorPubkey
+			pubkey[0] = byte((validatorIndex + 1) >> 8)
+			pubkey[1] = byte(validatorIndex + 1)
+			minipool := fixture.NewMinipool(minipoolAddress, nodeAddress, pubkey, eth.EthToWei(8), eth.EthToWei(0.14), beforeGenesis)
+			if validatorIndex%8 == 0 {
+				// Reduce the bond partway through the interval
+				minipool.LastBondReductionTime = big.NewInt(fixtureEpochTime(20).Unix())
+				minipool.LastBondReductionPrevValue = eth.EthToWei(16)
+				minipool.LastBondReductionPrevNodeFee = eth.EthToWei(0.15)
+			}
+			builder.AddMinipool(minipool, fixture.NewValidator(pubkey, fmt.Sprint(validatorIndex), 0))
+		}
+	}
+	return builder.Build()
+}
+
+// Create a config that keeps its data in a temporary directory and fetches the given number of epochs in parallel
+func newFixtureConfig(t *testing.T, workers int) *config.RocketPoolConfig {
+	cfg := config.NewRocketPoolConfig(t.TempDir(), true)
+	cfg.Smartnode.DataPath.Value = t.TempDir()
+	cfg.Smartnode.TreeGenerationWorkers.Value = uint64(workers)
+	return cfg
+}
+
+// Create a generator for the rewards tree of the synthetic network
+func newFixtureTreeGenerator() *treeGeneratorImpl_v8 {
+	logger := log.NewColorLogger(color.FgWhite)
+	endSlot := (fixtureEndEpoch+1)*fixtureSlotsPerEpoch - 1
+	r := newTreeGeneratorImpl_v8(&logger, "[Test]", 1, fixtureEpochTime(fixtureStartEpoch), fixtureSlotTime(endSlot), endSlot, newFixtureHeader(endSlot), 1, newFixtureNetworkState())
+
+	// The previous interval ended on the last slot before the fixture chain starts
+	previousEndSlot := new(big.Int).SetUint64(fixtureStartEpoch*fixtureSlotsPerEpoch - 1)
+	r.previousIntervalEvent = &rewards.RewardsEvent{
+		Index:          big.NewInt(0),
+		ConsensusBlock: previousEndSlot,
+		ExecutionBlock: previousEndSlot,
+	}
+	return r
+}
+
+// Generate the rewards tree, returning it along with the serialized rewards and minipool performance files
+func generateFixtureTree(t *testing.T, r *treeGeneratorImpl_v8, cfg *config.RocketPoolConfig, bc beacon.Client, ec rocketpool.ExecutionClient) (*RewardsFile_v3, []byte) {
+	rewardsFile, err := r.generateTree(&rocketpool.RocketPool{Client: ec}, cfg, bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileBytes, err := rewardsFile.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	performanceBytes, err := rewardsFile.GetMinipoolPerformanceFile().Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.rewardsFile, append(fileBytes, performanceBytes...)
+}
+
+// Record the synthetic chain into a fixture file while generating the tree, checking that every committee was released.
+// Returns the path of the fixture and the serialized tree.
+func recordTreeFixture(t *testing.T) (string, []byte) {
+	live := newFixtureBeaconClient()
+	recording := fixture.New()
+	bc := fixture.NewBeaconClient(recording, live)
+	ec := fixture.NewExecutionClient(recording, &fixtureExecutionClient{})
+	_, tree := generateFixtureTree(t, newFixtureTreeGenerator(), newFixtureConfig(t, 4), bc, ec)
+	if live.fetched.Load() != live.released.Load() {
+		t.Fatalf("fetched committees for %d epochs but only released %d", live.fetched.Load(), live.released.Load())
+	}
+
+	path := filepath.Join(t.TempDir(), "tree.json.zst")
+	err := recording.Save(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path, tree
+}
+
+// Generate the rewards tree from a recorded fixture
+func replayTreeFixture(t *testing.T, path string, workers int) (*RewardsFile_v3, []byte) {
+	recording, err := fixture.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := fixture.NewBeaconClient(recording, nil)
+	ec := fixture.NewExecutionClient(recording, nil)
+	return generateFixtureTree(t, newFixtureTreeGenerator(), newFixtureConfig(t, workers), bc, ec)
+}
+
+func TestGenerateTreeFromFixture(t *testing.T) {
+	path, recorded := recordTreeFixture(t)
+	rewardsFile, replayed := replayTreeFixture(t, path, 4)
+	if !bytes.Equal(recorded, replayed) {
+		t.Fatalf("tree generated from the fixture differs from the recorded one:\nrecorded: %s\nreplayed: %s", recorded, replayed)
+	}
+
+	// Both nodes earn RPL and Smoothing Pool rewards, and the first one also earns Oracle DAO rewards
+	if rewardsFile.MerkleRoot == "" {
+		t.Error("expected a Merkle root")
+	}
+	if rewardsFile.ConsensusStartBlock != fixtureStartEpoch*fixtureSlotsPerEpoch || rewardsFile.ExecutionStartBlock != rewardsFile.ConsensusStartBlock {
+		t.Errorf("expected the interval to start on slot and block %d, got %d and %d", fixtureStartEpoch*fixtureSlotsPerEpoch, rewardsFile.ConsensusStartBlock, rewardsFile.ExecutionStartBlock)
+	}
+	for i, address := range []common.Address{common.BigToAddress(big.NewInt(1000)), common.BigToAddress(big.NewInt(1001))} {
+		nodeRewards, exists := rewardsFile.NodeRewards[address]
+		if !exists {
+			t.Fatalf("node %d didn't get any rewards", i)
+		}
+		if nodeRewards.CollateralRpl.Sign() <= 0 || nodeRewards.SmoothingPoolEth.Sign() <= 0 {
+			t.Errorf("node %d: expected collateral RPL and Smoothing Pool ETH, got %s and %s", i, nodeRewards.CollateralRpl.String(), nodeRewards.SmoothingPoolEth.String())
+		}
+		if (i == 0) != (nodeRewards.OracleDaoRpl.Sign() > 0) {
+			t.Errorf("node %d: unexpected Oracle DAO RPL of %s", i, nodeRewards.OracleDaoRpl.String())
+		}
+	}
+	if rewardsFile.TotalRewards.TotalSmoothingPoolEth.Cmp(eth.EthToWei(10)) != 0 {
+		t.Errorf("expected 10 ETH in the Smoothing Pool, got %s", rewardsFile.TotalRewards.TotalSmoothingPoolEth.String())
+	}
+}
+
+// A Beacon client for the fixture chain that fails once it reaches an epoch, as if the Beacon node went down
+type interruptedBeaconClient struct {
+	*fixtureBeaconClient
+	failEpoch uint64
+}
+
+func (c *interruptedBeaconClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	if *epoch >= c.failEpoch {
+		return nil, fmt.Errorf("beacon node is down")
+	}
+	return c.fixtureBeaconClient.GetCommitteesForEpoch(epoch)
+}
+
+func TestGenerateTreeResumesFromCheckpoint(t *testing.T) {
+	_, uninterrupted := generateFixtureTree(t, newFixtureTreeGenerator(), newFixtureConfig(t, 4), newFixtureBeaconClient(), &fixtureExecutionClient{})
+
+	// Checkpoint every 5 epochs and stop partway through, so the last checkpoint is at epoch 20
+	cfg := newFixtureConfig(t, 4)
+	r := newFixtureTreeGenerator()
+	r.checkpointInterval = 5
+	_, err := r.generateTree(&rocketpool.RocketPool{Client: &fixtureExecutionClient{}}, cfg, &interruptedBeaconClient{newFixtureBeaconClient(), 23})
+	if err == nil {
+		t.Fatal("expected generation to fail when the Beacon node goes down")
+	}
+
+	// A new run with the same data folder picks up from the checkpoint and produces the same tree
+	bc := newFixtureBeaconClient()
+	r = newFixtureTreeGenerator()
+	r.checkpointInterval = 5
+	_, resumed := generateFixtureTree(t, r, cfg, bc, &fixtureExecutionClient{})
+	if fetched := uint64(bc.fetched.Load()); fetched != fixtureEndEpoch+1-20 {
+		t.Errorf("expected to resume from epoch 20 and fetch %d epochs, but fetched %d", fixtureEndEpoch+1-20, fetched)
+	}
+	if !bytes.Equal(uninterrupted, resumed) {
+		t.Fatalf("resumed tree differs from the uninterrupted one:\nuninterrupted: %s\nresumed: %s", uninterrupted, resumed)
+	}
+
+	// The checkpoint is removed once the interval is done
+	checkpoints, err := newCheckpointManager(r.log, r.logPrefix, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := checkpoints.load(1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint != nil {
+		t.Errorf("expected the checkpoint to be deleted, but it's at epoch %d", checkpoint.NextEpoch)
+	}
+}
+
+func TestParallelEpochProcessingMatchesSequential(t *testing.T) {
+	path, _ := recordTreeFixture(t)
+	_, sequential := replayTreeFixture(t, path, 1)
+	for _, workers := range []int{2, 4, 16} {
+		_, parallel := replayTreeFixture(t, path, workers)
+		if !bytes.Equal(sequential, parallel) {
+			t.Fatalf("tree generated with %d workers differs from the sequential one:\nsequential: %s\nparallel: %s", workers, sequential, parallel)
+		}
+	}
+}
+
+func TestEpochPrefetcherCloseReleasesCommittees(t *testing.T) {
+	bc := newFixtureBeaconClient()
+	prefetcher := newEpochPrefetcher(bc, fixtureSlotsPerEpoch, 4, fixtureStartEpoch, fixtureEndEpoch, fixtureEndEpoch)
+
+	// Process a few epochs and stop early
+	for epoch := fixtureStartEpoch; epoch < fixtureStartEpoch+3; epoch++ {
+		data, err := prefetcher.next(epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data.release()
+	}
+	prefetcher.close()
+
+	if bc.fetched.Load() != bc.released.Load() {
+		t.Fatalf("fetched committees for %d epochs but only released %d", bc.fetched.Load(), bc.released.Load())
+	}
+}
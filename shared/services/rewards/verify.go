@@ -0,0 +1,189 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// The fields that can differ between two rewards files
+const (
+	DiffField_Exists                 string = "exists"
+	DiffField_RewardNetwork          string = "rewardNetwork"
+	DiffField_CollateralRpl          string = "collateralRpl"
+	DiffField_OracleDaoRpl           string = "oracleDaoRpl"
+	DiffField_SmoothingPoolEth       string = "smoothingPoolEth"
+	DiffField_SuccessfulAttestations string = "successfulAttestations"
+	DiffField_MissedAttestations     string = "missedAttestations"
+	DiffField_EthEarned              string = "ethEarned"
+)
+
+// A single value that differs between the canonical and the regenerated rewards files
+type RewardsDiffEntry struct {
+	Address   common.Address `json:"address"`
+	Field     string         `json:"field"`
+	Canonical string         `json:"canonical"`
+	Generated string         `json:"generated"`
+}
+
+// The result of comparing a regenerated rewards tree against the canonical one
+type RewardsTreeVerificationReport struct {
+	Index                uint64             `json:"index"`
+	Network              string             `json:"network"`
+	VerifiedAt           time.Time          `json:"verifiedAt"`
+	OnChainMerkleRoot    string             `json:"onChainMerkleRoot"`
+	CanonicalMerkleRoot  string             `json:"canonicalMerkleRoot"`
+	GeneratedMerkleRoot  string             `json:"generatedMerkleRoot"`
+	Matches              bool               `json:"matches"`
+	MinipoolsCompared    bool               `json:"minipoolsCompared"`
+	NodeDifferences      []RewardsDiffEntry `json:"nodeDifferences"`
+	MinipoolDifferences  []RewardsDiffEntry `json:"minipoolDifferences"`
+	GenerationError      string             `json:"generationError,omitempty"`
+	CanonicalFileMissing bool               `json:"canonicalFileMissing,omitempty"`
+}
+
+// Compares a regenerated rewards file against the canonical one, node by node and minipool by minipool.
+// If the canonical minipool performance file is nil, only the node rewards are compared.
+func VerifyRewardsFile(canonical IRewardsFile, canonicalPerformance IMinipoolPerformanceFile, generated IRewardsFile, onChainRoot common.Hash) *RewardsTreeVerificationReport {
+	canonicalHeader := canonical.GetHeader()
+	generatedHeader := generated.GetHeader()
+	generatedRoot := common.BytesToHash(generatedHeader.MerkleTree.Root())
+
+	report := &RewardsTreeVerificationReport{
+		Index:               generatedHeader.Index,
+		Network:             generatedHeader.Network,
+		VerifiedAt:          time.Now().UTC(),
+		OnChainMerkleRoot:   onChainRoot.Hex(),
+		CanonicalMerkleRoot: canonicalHeader.MerkleRoot,
+		GeneratedMerkleRoot: generatedRoot.Hex(),
+		MinipoolsCompared:   canonicalPerformance != nil,
+		NodeDifferences:     diffNodeRewards(canonical, generated),
+		MinipoolDifferences: []RewardsDiffEntry{},
+	}
+	if canonicalPerformance != nil {
+		report.MinipoolDifferences = diffMinipoolPerformance(canonicalPerformance, generated.GetMinipoolPerformanceFile())
+	}
+	report.Matches = generatedRoot == onChainRoot &&
+		len(report.NodeDifferences) == 0 &&
+		len(report.MinipoolDifferences) == 0
+	return report
+}
+
+// Saves a verification report to disk as JSON
+func (r *RewardsTreeVerificationReport) Write(path string) error {
+	bytes, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing verification report: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing verification report to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Reads a verification report from disk
+func ReadRewardsTreeVerificationReport(path string) (*RewardsTreeVerificationReport, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading verification report from %s: %w", path, err)
+	}
+	var report RewardsTreeVerificationReport
+	err = json.Unmarshal(bytes, &report)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing verification report from %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// Compare the rewards of every node in either file
+func diffNodeRewards(canonical IRewardsFile, generated IRewardsFile) []RewardsDiffEntry {
+	diffs := []RewardsDiffEntry{}
+	for _, address := range mergeAddresses(canonical.GetNodeAddresses(), generated.GetNodeAddresses()) {
+		canonicalInfo, canonicalExists := canonical.GetNodeRewardsInfo(address)
+		generatedInfo, generatedExists := generated.GetNodeRewardsInfo(address)
+		if !canonicalExists || !generatedExists {
+			diffs = append(diffs, newExistenceDiff(address, canonicalExists, generatedExists))
+			continue
+		}
+
+		diffs = appendIfDifferent(diffs, address, DiffField_RewardNetwork, fmt.Sprint(canonicalInfo.GetRewardNetwork()), fmt.Sprint(generatedInfo.GetRewardNetwork()))
+		diffs = appendIfDifferent(diffs, address, DiffField_CollateralRpl, quotedBigIntString(canonicalInfo.GetCollateralRpl()), quotedBigIntString(generatedInfo.GetCollateralRpl()))
+		diffs = appendIfDifferent(diffs, address, DiffField_OracleDaoRpl, quotedBigIntString(canonicalInfo.GetOracleDaoRpl()), quotedBigIntString(generatedInfo.GetOracleDaoRpl()))
+		diffs = appendIfDifferent(diffs, address, DiffField_SmoothingPoolEth, quotedBigIntString(canonicalInfo.GetSmoothingPoolEth()), quotedBigIntString(generatedInfo.GetSmoothingPoolEth()))
+	}
+	return diffs
+}
+
+// Compare the performance of every minipool in either file
+func diffMinipoolPerformance(canonical IMinipoolPerformanceFile, generated IMinipoolPerformanceFile) []RewardsDiffEntry {
+	diffs := []RewardsDiffEntry{}
+	for _, address := range mergeAddresses(canonical.GetMinipoolAddresses(), generated.GetMinipoolAddresses()) {
+		canonicalPerf, canonicalExists := canonical.GetSmoothingPoolPerformance(address)
+		generatedPerf, generatedExists := generated.GetSmoothingPoolPerformance(address)
+		if !canonicalExists || !generatedExists {
+			diffs = append(diffs, newExistenceDiff(address, canonicalExists, generatedExists))
+			continue
+		}
+
+		diffs = appendIfDifferent(diffs, address, DiffField_SuccessfulAttestations, fmt.Sprint(canonicalPerf.GetSuccessfulAttestationCount()), fmt.Sprint(generatedPerf.GetSuccessfulAttestationCount()))
+		diffs = appendIfDifferent(diffs, address, DiffField_MissedAttestations, fmt.Sprint(canonicalPerf.GetMissedAttestationCount()), fmt.Sprint(generatedPerf.GetMissedAttestationCount()))
+		diffs = appendIfDifferent(diffs, address, DiffField_EthEarned, canonicalPerf.GetEthEarned().String(), generatedPerf.GetEthEarned().String())
+	}
+	return diffs
+}
+
+// Get the sorted union of two address lists
+func mergeAddresses(first []common.Address, second []common.Address) []common.Address {
+	addressMap := map[common.Address]bool{}
+	for _, address := range first {
+		addressMap[address] = true
+	}
+	for _, address := range second {
+		addressMap[address] = true
+	}
+	addresses := make([]common.Address, 0, len(addressMap))
+	for address := range addressMap {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	return addresses
+}
+
+// Create a diff entry for an address that is only present in one of the files
+func newExistenceDiff(address common.Address, canonicalExists bool, generatedExists bool) RewardsDiffEntry {
+	return RewardsDiffEntry{
+		Address:   address,
+		Field:     DiffField_Exists,
+		Canonical: fmt.Sprint(canonicalExists),
+		Generated: fmt.Sprint(generatedExists),
+	}
+}
+
+// Add a diff entry if the two values are different
+func appendIfDifferent(diffs []RewardsDiffEntry, address common.Address, field string, canonical string, generated string) []RewardsDiffEntry {
+	if canonical == generated {
+		return diffs
+	}
+	return append(diffs, RewardsDiffEntry{
+		Address:   address,
+		Field:     field,
+		Canonical: canonical,
+		Generated: generated,
+	})
+}
+
+// Get the string form of a QuotedBigInt, treating nil as 0
+func quotedBigIntString(value *QuotedBigInt) string {
+	if value == nil {
+		return "0"
+	}
+	return value.Int.String()
+}
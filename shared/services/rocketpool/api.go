@@ -10,6 +10,10 @@ import (
 
 // Wait for a transaction
 func (c *Client) WaitForTransaction(txHash common.Hash) (api.APIResponse, error) {
+	// Intercepted transactions were never submitted
+	if c.InterceptsTransactions() {
+		return api.APIResponse{Status: "success"}, nil
+	}
 	responseBytes, err := c.callAPI(fmt.Sprintf("wait %s", txHash.String()))
 	if err != nil {
 		return api.APIResponse{}, fmt.Errorf("Error waiting for tx: %w", err)
@@ -3,11 +3,13 @@ package rocketpool
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math"
 	"math/big"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
@@ -29,6 +31,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool/template"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliOutput "github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -45,6 +48,9 @@ const (
 	APIContainerSuffix string = "_api"
 	APIBinPath         string = "/go/bin/rocketpool"
 
+	apiServerStartTimeout time.Duration = 10 * time.Second
+	apiServerPollInterval time.Duration = 250 * time.Millisecond
+
 	templatesDir                  string = "templates"
 	overrideDir                   string = "override"
 	runtimeDir                    string = "runtime"
@@ -81,6 +87,12 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	dryRun             bool
+	exportUnsignedPath string
+	exportUnsignedNode string
+	exportUnsignedFrom string
+	recordResponses    bool
+	apiServerStarted   bool
 }
 
 func getClientStatusString(clientStatus api.ClientStatus) string {
@@ -157,6 +169,11 @@ func NewClientFromCtx(c *cli.Context) *Client {
 		debugPrint:         c.GlobalBool("debug"),
 		forceFallbacks:     false,
 		ignoreSyncCheck:    false,
+		dryRun:             c.GlobalBool("dry-run"),
+		exportUnsignedPath: c.GlobalString("export-unsigned"),
+		exportUnsignedNode: c.GlobalString("export-unsigned-node"),
+		exportUnsignedFrom: c.GlobalString("export-unsigned-from"),
+		recordResponses:    true,
 	}
 
 	if nonce, ok := c.App.Metadata["nonce"]; ok {
@@ -214,6 +231,10 @@ func (c *Client) ConfigPath() string {
 // Load the config
 // Returns the RocketPoolConfig and whether or not it was newly generated
 func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
+	if c.client != nil {
+		return c.loadRemoteConfig()
+	}
+
 	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
 	expandedPath, err := homedir.Expand(settingsFilePath)
 	if err != nil {
@@ -245,13 +266,62 @@ func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
 	return rp.LoadConfigFromFile(expandedPath)
 }
 
-// Save the config
+// Save the config, recording it in the config history
 func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 	settingsFileDirectoryPath, err := homedir.Expand(c.configPath)
 	if err != nil {
 		return err
 	}
-	return rp.SaveConfig(cfg, settingsFileDirectoryPath, SettingsFile)
+
+	// Get the config being replaced so the history can describe what changed; a broken one is treated as missing
+	oldCfg, err := rp.LoadConfigFromFile(filepath.Join(settingsFileDirectoryPath, SettingsFile))
+	if err != nil {
+		oldCfg = nil
+	}
+
+	err = rp.SaveConfig(cfg, settingsFileDirectoryPath, SettingsFile)
+	if err != nil {
+		return err
+	}
+	err = rp.AddConfigSnapshot(settingsFileDirectoryPath, oldCfg, cfg)
+	if err != nil {
+		return fmt.Errorf("the settings were saved, but couldn't be recorded in the config history: %w", err)
+	}
+
+	// Don't leave the password on disk once the Smartnode only holds it in memory
+	if !cfg.Smartnode.SaveWalletPassword.Value.(bool) && (oldCfg == nil || oldCfg.Smartnode.SaveWalletPassword.Value.(bool)) {
+		err = c.deleteSavedPassword(cfg)
+		if err != nil {
+			return fmt.Errorf("the settings were saved, but the node wallet password file couldn't be deleted: %w", err)
+		}
+	}
+	return nil
+}
+
+// Delete the node wallet password file, if there is one
+func (c *Client) deleteSavedPassword(cfg *config.RocketPoolConfig) error {
+	passwordPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetPasswordPathInCLI()))
+	if err != nil {
+		return fmt.Errorf("error loading password path: %w", err)
+	}
+	_, err = os.Stat(passwordPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	// The daemon writes the file, so it may need root privileges to delete
+	fmt.Printf("Deleting the saved node wallet password at %s, since it will only be held in memory from now on...\n", passwordPath)
+	err = os.Remove(passwordPath)
+	if err == nil || !os.IsPermission(err) {
+		return err
+	}
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	cmd := fmt.Sprintf("%s rm -f %s", rootCmd, shellescape.Quote(passwordPath))
+	_, err = c.readOutput(cmd)
+	return err
 }
 
 // Remove the upgrade flag file
@@ -482,7 +552,63 @@ func (c *Client) StartService(composeFiles []string) error {
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	err = c.printOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Start the API server in the API container, which may have just been recreated
+	err = c.StartAPIServer()
+	if err != nil {
+		fmt.Printf("%sWARNING: %s\nAPI calls will each run in a new process instead.%s\n", colorYellow, err.Error(), colorReset)
+	}
+	return nil
+}
+
+// Start the API server in the API container if it isn't running already.
+// The API container only idles until commands are run in it, so the server has to be started in it after each time it starts.
+// In Native Mode, the server is run as its own service with `rocketpool api-server` instead.
+func (c *Client) StartAPIServer() error {
+	c.apiServerStarted = true
+	if c.client != nil || c.daemonPath != "" {
+		return nil
+	}
+	socketPath, err := c.getAPISocketPath()
+	if err != nil {
+		return err
+	}
+	if isSocketListening(socketPath) {
+		return nil
+	}
+
+	// Run it in the background of the API container
+	containerName, err := c.getAPIContainerName()
+	if err != nil {
+		return err
+	}
+	cmd := fmt.Sprintf("docker exec -d %s %s api-server", shellescape.Quote(containerName), shellescape.Quote(APIBinPath))
+	_, err = c.readOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("error starting the API server: %w", err)
+	}
+
+	// Wait for it to listen so the calls right after this use it
+	for start := time.Now(); time.Since(start) < apiServerStartTimeout; time.Sleep(apiServerPollInterval) {
+		if isSocketListening(socketPath) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the API server didn't start listening on %s; check the logs of %s for the reason", socketPath, containerName)
+}
+
+// Check if a server is listening on a unix socket
+func isSocketListening(socketPath string) bool {
+	conn, err := net.DialTimeout("unix", socketPath, time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
 }
 
 // Pause the Rocket Pool service
@@ -840,6 +966,31 @@ func (c *Client) GetClientVolumeName(container string, volumeTarget string) (str
 	return strings.TrimSpace(string(output)), nil
 }
 
+// A host address that a container publishes one of its ports on
+type DockerPortBinding struct {
+	HostIp   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+// Gets the host addresses that a container publishes its ports on, keyed by container port (e.g. 8545/tcp)
+func (c *Client) GetContainerPortBindings(container string) (map[string][]DockerPortBinding, error) {
+
+	cmd := fmt.Sprintf("docker container inspect --format='{{json .HostConfig.PortBindings}}' %s", container)
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+	bindings := map[string][]DockerPortBinding{}
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" || trimmed == "null" {
+		return bindings, nil
+	}
+	if err := json.Unmarshal([]byte(trimmed), &bindings); err != nil {
+		return nil, fmt.Errorf("could not decode port bindings of container %s: %w", container, err)
+	}
+	return bindings, nil
+}
+
 // Gets the disk usage of the given volume
 func (c *Client) GetVolumeSize(volumeName string) (string, error) {
 
@@ -1249,6 +1400,28 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 
 // Call the Rocket Pool API
 func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	// Use the API server if it's running
+	output, served, err := c.callAPIServer(args, otherArgs...)
+	if !served && !c.apiServerStarted {
+		// The API container restarted since the server was last started, so start it again
+		startErr := c.StartAPIServer()
+		if startErr == nil {
+			output, served, err = c.callAPIServer(args, otherArgs...)
+		} else if c.debugPrint {
+			fmt.Printf("Couldn't start the API server (%s), falling back to docker exec\n", startErr.Error())
+		}
+	}
+	if served {
+		c.recordResponse(args, output, err)
+		return c.checkInterceptedTransaction(output, err)
+	}
+
+	// A new process can't use the wallet if its password is only held in the running processes' memory
+	err = c.checkWalletPasswordSaved()
+	if err != nil {
+		return []byte{}, err
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1259,20 +1432,110 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getTransactionModeFlags(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getTransactionModeFlags(),
 			args)
 	}
 
 	// Run the command
-	return c.runApiCall(cmd)
+	output, err = c.runApiCall(cmd)
+	c.recordResponse(args, output, err)
+	return c.checkInterceptedTransaction(output, err)
+}
+
+// Call the Rocket Pool API through the API server's socket
+// Returns false if the API server isn't available, in which case the call should be run through docker exec instead
+func (c *Client) callAPIServer(args string, otherArgs ...string) ([]byte, bool, error) {
+	// The API server can only be reached from the local machine
+	if c.client != nil {
+		return nil, false, nil
+	}
+
+	// Check if the socket exists
+	socketPath, err := c.getAPISocketPath()
+	if err != nil {
+		return nil, false, nil
+	}
+	_, err = os.Stat(socketPath)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	// Build the request
+	request := api.APIServerRequest{
+		MaxFee:          c.maxFee,
+		MaxPrioFee:      c.maxPrioFee,
+		GasLimit:        c.gasLimit,
+		IgnoreSyncCheck: c.ignoreSyncCheck,
+		ForceFallbacks:  c.forceFallbacks,
+		DryRun:          c.dryRun,
+		Unsigned:        c.exportUnsignedPath != "",
+		UnsignedNode:    c.exportUnsignedNode,
+		UnsignedFrom:    c.exportUnsignedFrom,
+		Args:            append(strings.Fields(args), otherArgs...),
+	}
+	if c.customNonce != nil {
+		request.Nonce = c.customNonce.String()
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, true, fmt.Errorf("error serializing API server request: %w", err)
+	}
+
+	if c.debugPrint {
+		fmt.Println("To API server:")
+		fmt.Println(string(body))
+	}
+
+	// Send the request over the socket
+	httpClient := http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	response, err := httpClient.Post("http://rocketpool"+api.APIServerCallPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// If nothing is listening on the socket (e.g. the file was left behind by a stopped server), fall back to docker exec
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			if c.debugPrint {
+				fmt.Printf("API server unavailable (%s), falling back to docker exec\n", err.Error())
+			}
+			return nil, false, nil
+		}
+		return nil, true, fmt.Errorf("error calling API server: %w", err)
+	}
+	defer response.Body.Close()
+	output, err := io.ReadAll(response.Body)
+
+	if c.debugPrint {
+		fmt.Println("API Out:")
+		fmt.Println(string(output))
+	}
+
+	// Reset the gas settings after the call
+	c.maxFee = c.originalMaxFee
+	c.maxPrioFee = c.originalMaxPrioFee
+	c.gasLimit = c.originalGasLimit
+
+	if err != nil {
+		return nil, true, fmt.Errorf("error reading API server response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, true, fmt.Errorf("API server returned %s: %s", response.Status, strings.TrimSpace(string(output)))
+	}
+	return output, true, nil
 }
 
 // Call the Rocket Pool API with some custom environment variables
@@ -1292,13 +1555,13 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getTransactionModeFlags(), args)
 	} else {
 		envArgs := ""
 		for key, value := range envVars {
 			envArgs += fmt.Sprintf("%s=%s ", key, shellescape.Quote(value))
 		}
-		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s %s api %s",
 			envArgs,
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
@@ -1306,11 +1569,14 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getTransactionModeFlags(),
 			args)
 	}
 
 	// Run the command
-	return c.runApiCall(cmd)
+	output, err := c.runApiCall(cmd)
+	c.recordResponse(args, output, err)
+	return c.checkInterceptedTransaction(output, err)
 }
 
 func (c *Client) getApiCallArgs(args string, otherArgs ...string) (string, string, string) {
@@ -1379,6 +1645,32 @@ func (c *Client) getAPIContainerName() (string, error) {
 	return cfg.Smartnode.ProjectName.Value.(string) + APIContainerSuffix, nil
 }
 
+// Get the path of the API server's socket
+func (c *Client) getAPISocketPath() (string, error) {
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if isNew {
+		return "", fmt.Errorf("Settings file not found.")
+	}
+	return homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetApiSocketPathInCLI()))
+}
+
+// Make sure the API can be run in a new process instead of through the API server.
+// If the node wallet password isn't saved to disk, a new process would start with the wallet locked and nothing could unlock it.
+func (c *Client) checkWalletPasswordSaved() error {
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil || isNew {
+		// Let the call report any config problems itself
+		return nil
+	}
+	if cfg.Smartnode.SaveWalletPassword.Value.(bool) {
+		return nil
+	}
+	return errors.New("The Smartnode's API server isn't running, and your node wallet password isn't saved to disk, so the API can't be run in a new process that would start with the wallet locked. Please start the Smartnode with `rocketpool service start`, unlock it with `rocketpool wallet unlock`, and try again.")
+}
+
 // Get gas price & limit flags
 func (c *Client) getGasOpts() string {
 	var opts string
@@ -1397,6 +1689,65 @@ func (c *Client) getCustomNonce() string {
 	return nonce
 }
 
+// Get the flags that tell the API to simulate or export transactions instead of submitting them
+func (c *Client) getTransactionModeFlags() string {
+	flags := []string{}
+	if c.dryRun {
+		flags = append(flags, "--dry-run")
+	}
+	if c.exportUnsignedPath != "" {
+		flags = append(flags, "--unsigned")
+		if c.exportUnsignedNode != "" {
+			flags = append(flags, "--unsigned-node", shellescape.Quote(c.exportUnsignedNode))
+		}
+		if c.exportUnsignedFrom != "" {
+			flags = append(flags, "--unsigned-from", shellescape.Quote(c.exportUnsignedFrom))
+		}
+	}
+	return strings.Join(flags, " ")
+}
+
+// Record an API response for the CLI's machine-readable output.
+// Only the API command is recorded since the other args can be secrets, like a mnemonic or password.
+func (c *Client) recordResponse(args string, response []byte, err error) {
+	if !c.recordResponses || err != nil {
+		return
+	}
+	fields := strings.Fields(args)
+	if len(fields) > 2 {
+		fields = fields[:2]
+	}
+	cliOutput.RecordResponse(strings.Join(fields, " "), response)
+}
+
+// Check an API response for a transaction that was simulated or exported instead of submitted
+func (c *Client) checkInterceptedTransaction(output []byte, err error) ([]byte, error) {
+	output, err = c.checkDryRun(output, err)
+	return c.checkUnsignedTransaction(output, err)
+}
+
+// Check if the transactions this client's commands send are simulated or exported instead of submitted.
+// Their API responses have an empty transaction hash, so there's nothing to wait for.
+func (c *Client) InterceptsTransactions() bool {
+	return c.dryRun || c.exportUnsignedPath != ""
+}
+
+// The response that replaces one for an intercepted transaction, so the command can continue as though it was submitted.
+// Every transaction response type can be decoded from it, with an empty hash.
+func getInterceptedTransactionResponse() ([]byte, error) {
+	return json.Marshal(api.APIResponse{Status: "success"})
+}
+
+// Print the transactions that were simulated or exported instead of submitted during the command that just finished.
+// Returns an error if a simulated transaction would revert.
+func FinishInterceptedTransactions(commandErr error) error {
+	err := printSimulations(commandErr)
+	if !cliOutput.IsStructured() {
+		printExportedTransactions(commandErr)
+	}
+	return err
+}
+
 // Run a command and print its output
 func (c *Client) printOutput(cmdText string) error {
 
@@ -0,0 +1,153 @@
+package rocketpool
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliOutput "github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+const colorGreen string = "\033[32m"
+
+// The transactions simulated during this command, which are printed when it finishes
+var simulations []api.TransactionSimulation
+
+// Check an API response for a simulated transaction.
+// If there is one, it's recorded and replaced with an empty successful response so the command can carry on to its next transaction.
+func (c *Client) checkDryRun(output []byte, err error) ([]byte, error) {
+	if err != nil || !c.dryRun {
+		return output, err
+	}
+
+	var response api.DryRunResponse
+	if json.Unmarshal(output, &response) != nil || response.Status != api.DryRunStatus {
+		return output, err
+	}
+
+	// The response has already been recorded for machine-readable output
+	simulations = append(simulations, response.Simulation)
+	fmt.Printf("Simulated transaction %d; the results will be shown when the command finishes.\n", len(simulations))
+	return getInterceptedTransactionResponse()
+}
+
+// Print the transactions that were simulated during this command, returning an error if any of them would revert
+func printSimulations(commandErr error) error {
+	if len(simulations) == 0 {
+		return nil
+	}
+
+	reverted := 0
+	for i, sim := range simulations {
+		if !cliOutput.IsStructured() {
+			printTransactionSimulation(sim, i+1, len(simulations))
+		}
+		if sim.Reverted {
+			reverted++
+		}
+	}
+	if !cliOutput.IsStructured() && len(simulations) > 1 {
+		fmt.Printf("%sEach transaction was simulated without the effects of the ones before it, so one that depends on them (e.g. on an approval) may show a revert that wouldn't happen for real.%s\n", colorYellow, colorReset)
+	} else if !cliOutput.IsStructured() && commandErr != nil {
+		fmt.Printf("%sThe command failed after this simulation. Later transactions are simulated without the effects of earlier ones, so one that depends on them (e.g. on an approval) can't be simulated until they have been submitted.%s\n", colorYellow, colorReset)
+	}
+
+	if reverted > 0 {
+		return fmt.Errorf("%d of the %d simulated transactions would revert", reverted, len(simulations))
+	}
+	return nil
+}
+
+// Print the results of a simulated transaction, which was the nth of the command's transactions
+func printTransactionSimulation(sim api.TransactionSimulation, n int, count int) {
+
+	fmt.Println()
+	if count > 1 {
+		fmt.Printf("=== Dry Run (%d/%d) ===\n", n, count)
+	} else {
+		fmt.Println("=== Dry Run ===")
+	}
+	fmt.Println("The transaction was simulated against the pending block and was NOT submitted.")
+	fmt.Println()
+
+	// Transaction details
+	fmt.Printf("From:      %s\n", sim.From.Hex())
+	if sim.To != nil {
+		if sim.ToLabel != "" {
+			fmt.Printf("To:        %s (%s)\n", sim.To.Hex(), sim.ToLabel)
+		} else {
+			fmt.Printf("To:        %s\n", sim.To.Hex())
+		}
+	}
+	if sim.Method != "" {
+		fmt.Printf("Method:    %s\n", sim.Method)
+	}
+	if sim.Value != nil && sim.Value.Sign() > 0 {
+		fmt.Printf("Value:     %.6f ETH\n", eth.WeiToEth(sim.Value))
+	}
+	fmt.Printf("Gas limit: %d\n", sim.GasLimit)
+	fmt.Println()
+
+	// Result
+	if sim.Reverted {
+		fmt.Printf("%sResult: the transaction would REVERT: %s%s\n", colorRed, sim.RevertReason, colorReset)
+		return
+	}
+	fmt.Printf("%sResult: the transaction would succeed.%s\n", colorGreen, colorReset)
+	if len(sim.ReturnValues) > 0 {
+		fmt.Println("Return values:")
+		for i, value := range sim.ReturnValues {
+			fmt.Printf("\t[%d] %s\n", i, value)
+		}
+	}
+	fmt.Println()
+
+	// Tracing isn't supported by every client
+	if sim.TraceError != "" {
+		fmt.Printf("%sBalance changes and events are unavailable because your Execution client couldn't trace the transaction: %s%s\n", colorYellow, sim.TraceError, colorReset)
+		return
+	}
+
+	// Balance changes
+	if len(sim.BalanceChanges) == 0 {
+		fmt.Println("Expected ETH balance changes: none")
+	} else {
+		fmt.Println("Expected ETH balance changes:")
+		for _, change := range sim.BalanceChanges {
+			delta := big.NewInt(0).Sub(change.After, change.Before)
+			fmt.Printf("\t%s (%s): %.6f -> %.6f ETH (%+.6f)\n", change.Address.Hex(), change.Label, eth.WeiToEth(change.Before), eth.WeiToEth(change.After), eth.WeiToEth(delta))
+		}
+	}
+	fmt.Println()
+
+	// Events
+	if len(sim.Events) == 0 {
+		fmt.Println("Expected events: none")
+		return
+	}
+	fmt.Println("Expected events:")
+	for _, event := range sim.Events {
+		source := event.Address.Hex()
+		if event.Contract != "" {
+			source = fmt.Sprintf("%s (%s)", source, event.Contract)
+		}
+		if event.Name == "" {
+			fmt.Printf("\tUnknown event from %s\n", source)
+			continue
+		}
+		fmt.Printf("\t%s from %s\n", event.Name, source)
+		names := make([]string, 0, len(event.Arguments))
+		for name := range event.Arguments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("\t\t%s: %s\n", name, event.Arguments[name])
+		}
+	}
+
+}
@@ -3,6 +3,7 @@ package rocketpool
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -216,6 +217,42 @@ func (c *Client) ExitMinipool(address common.Address) (api.ExitMinipoolResponse,
 	return response, nil
 }
 
+// Get signed voluntary exits for minipools without broadcasting them
+func (c *Client) GetSignedExits(addresses []common.Address) (api.GetSignedExitsResponse, error) {
+	addressStrings := make([]string, len(addresses))
+	for i, address := range addresses {
+		addressStrings[i] = address.Hex()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool get-signed-exits %s", strings.Join(addressStrings, ",")))
+	if err != nil {
+		return api.GetSignedExitsResponse{}, fmt.Errorf("Could not get signed exits: %w", err)
+	}
+	var response api.GetSignedExitsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetSignedExitsResponse{}, fmt.Errorf("Could not decode signed exits response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetSignedExitsResponse{}, fmt.Errorf("Could not get signed exits: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast a signed voluntary exit
+func (c *Client) BroadcastExit(validatorIndex string, epoch string, signature string) (api.BroadcastExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool broadcast-exit %s %s %s", validatorIndex, epoch, signature))
+	if err != nil {
+		return api.BroadcastExitResponse{}, fmt.Errorf("Could not broadcast exit: %w", err)
+	}
+	var response api.BroadcastExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastExitResponse{}, fmt.Errorf("Could not decode broadcast exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastExitResponse{}, fmt.Errorf("Could not broadcast exit: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check all of the node's minipools for closure eligibility, and return the details of the closeable ones
 func (c *Client) GetMinipoolCloseDetailsForNode() (api.GetMinipoolCloseDetailsForNodeResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-minipool-close-details-for-node")
@@ -457,17 +494,17 @@ func (c *Client) DistributeBalance(address common.Address) (api.DistributeBalanc
 }
 
 // Import a validator private key for a vacant minipool
-func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ChangeWithdrawalCredentialsResponse, error) {
+func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ImportKeyResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool import-key %s", address.Hex()), mnemonic)
 	if err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %w", err)
 	}
-	var response api.ChangeWithdrawalCredentialsResponse
+	var response api.ImportKeyResponse
 	if err := json.Unmarshal(responseBytes, &response); err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
 	}
 	if response.Error != "" {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
 	}
 	return response, nil
 }
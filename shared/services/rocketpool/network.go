@@ -107,6 +107,70 @@ func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTr
 	return response, nil
 }
 
+// Set a request marker for the watchtower to generate the rewards tree for the given interval and record the client responses it uses into a fixture
+func (c *Client) RecordRewardsFixture(index uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network record-rewards-fixture %d", index))
+	if err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards fixture recording: %w", err)
+	}
+	var response api.NetworkGenerateRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards fixture recording response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards fixture recording: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check if the rewards tree for the provided interval can be verified
+func (c *Client) CanVerifyRewardsTree(index uint64) (api.CanNetworkVerifyRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network can-verify-rewards-tree %d", index))
+	if err != nil {
+		return api.CanNetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not check rewards tree verification status: %w", err)
+	}
+	var response api.CanNetworkVerifyRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree verification status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not check rewards tree verification status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set a request marker for the watchtower to regenerate and verify the rewards tree for the given interval
+func (c *Client) VerifyRewardsTree(index uint64) (api.NetworkVerifyRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network verify-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree verification: %w", err)
+	}
+	var response api.NetworkVerifyRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree verification response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree verification: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the verification report for the rewards tree of the given interval
+func (c *Client) GetRewardsTreeVerification(index uint64) (api.NetworkRewardsTreeVerificationResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network rewards-tree-verification %d", index))
+	if err != nil {
+		return api.NetworkRewardsTreeVerificationResponse{}, fmt.Errorf("Could not get rewards tree verification report: %w", err)
+	}
+	var response api.NetworkRewardsTreeVerificationResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkRewardsTreeVerificationResponse{}, fmt.Errorf("Could not decode rewards tree verification report response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkRewardsTreeVerificationResponse{}, fmt.Errorf("Could not get rewards tree verification report: %s", response.Error)
+	}
+	return response, nil
+}
+
 // GetActiveDAOProposals fetches information about active DAO proposals
 func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("network dao-proposals")
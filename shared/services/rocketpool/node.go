@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -1111,3 +1112,145 @@ func (c *Client) SendMessage(address common.Address, message []byte) (api.NodeSe
 	}
 	return response, nil
 }
+
+// Get the node's pending transactions
+func (c *Client) GetPendingTransactions() (api.PendingTransactionsResponse, error) {
+	responseBytes, err := c.callAPI("node pending-txs")
+	if err != nil {
+		return api.PendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %w", err)
+	}
+	var response api.PendingTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PendingTransactionsResponse{}, fmt.Errorf("Could not decode pending transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a pending transaction
+func (c *Client) CancelTransaction(nonce uint64) (api.CancelTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-tx %d", nonce))
+	if err != nil {
+		return api.CancelTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %w", err)
+	}
+	var response api.CancelTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CancelTransactionResponse{}, fmt.Errorf("Could not decode cancel transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CancelTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast a transaction that was signed offline
+func (c *Client) BroadcastTransaction(payload []byte) (api.BroadcastTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node broadcast-tx %s", hex.EncodeToString(payload)))
+	if err != nil {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %w", err)
+	}
+	var response api.BroadcastTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not decode broadcast transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get every ETH and RPL inflow to the node between two times; zero times mean no limit
+func (c *Client) ExportIncome(from time.Time, to time.Time) (api.ExportIncomeResponse, error) {
+	var fromUnix, toUnix int64
+	if !from.IsZero() {
+		fromUnix = from.Unix()
+	}
+	if !to.IsZero() {
+		toUnix = to.Unix()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("node export-income %d %d", fromUnix, toUnix))
+	if err != nil {
+		return api.ExportIncomeResponse{}, fmt.Errorf("Could not export node income: %w", err)
+	}
+	var response api.ExportIncomeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportIncomeResponse{}, fmt.Errorf("Could not decode export node income response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportIncomeResponse{}, fmt.Errorf("Could not export node income: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's recorded history between two times, downsampled to one sample per step
+func (c *Client) NodeHistory(from time.Time, to time.Time, step time.Duration) (api.NodeHistoryResponse, error) {
+	var fromUnix, toUnix int64
+	if !from.IsZero() {
+		fromUnix = from.Unix()
+	}
+	if !to.IsZero() {
+		toUnix = to.Unix()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("node history %d %d %d", fromUnix, toUnix, int64(step.Seconds())))
+	if err != nil {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not get node history: %w", err)
+	}
+	var response api.NodeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not decode node history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not get node history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the address and token of the node daemon's web dashboard
+func (c *Client) NodeDashboard() (api.NodeDashboardResponse, error) {
+	responseBytes, err := c.callAPI("node dashboard")
+	if err != nil {
+		return api.NodeDashboardResponse{}, fmt.Errorf("Could not get web dashboard info: %w", err)
+	}
+	var response api.NodeDashboardResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDashboardResponse{}, fmt.Errorf("Could not decode web dashboard info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDashboardResponse{}, fmt.Errorf("Could not get web dashboard info: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Replace the web dashboard's token, which logs out every browser that used the old one
+func (c *Client) ResetDashboardToken() (api.ResetDashboardTokenResponse, error) {
+	responseBytes, err := c.callAPI("node reset-dashboard-token")
+	if err != nil {
+		return api.ResetDashboardTokenResponse{}, fmt.Errorf("Could not reset web dashboard token: %w", err)
+	}
+	var response api.ResetDashboardTokenResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ResetDashboardTokenResponse{}, fmt.Errorf("Could not decode reset web dashboard token response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ResetDashboardTokenResponse{}, fmt.Errorf("Could not reset web dashboard token: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the validator pubkeys of a node's minipools
+func (c *Client) NodeMinipoolPubkeys(nodeAddress common.Address) (api.NodeMinipoolPubkeysResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node minipool-pubkeys %s", nodeAddress.Hex()))
+	if err != nil {
+		return api.NodeMinipoolPubkeysResponse{}, fmt.Errorf("Could not get node minipool pubkeys: %w", err)
+	}
+	var response api.NodeMinipoolPubkeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeMinipoolPubkeysResponse{}, fmt.Errorf("Could not decode node minipool pubkeys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeMinipoolPubkeysResponse{}, fmt.Errorf("Could not get node minipool pubkeys: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,213 @@
+package rocketpool
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliOutput "github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// A transaction that was exported unsigned during this command
+type exportedTransaction struct {
+	path string
+	tx   api.UnsignedTransaction
+}
+
+// The transactions exported during this command, which are listed when it finishes
+var exportedTransactions []exportedTransaction
+
+// Check an API response for a transaction that was exported unsigned.
+// If there is one, it's saved and replaced with an empty successful response so the command can carry on to its next transaction.
+func (c *Client) checkUnsignedTransaction(output []byte, err error) ([]byte, error) {
+	if err != nil || c.exportUnsignedPath == "" {
+		return output, err
+	}
+
+	var response api.UnsignedTransactionResponse
+	if json.Unmarshal(output, &response) != nil || response.Status != api.UnsignedTransactionStatus {
+		return output, err
+	}
+
+	// Save the transaction; the first goes to the requested path and the rest are numbered after it
+	tx := response.Transaction
+	path := getExportPath(c.exportUnsignedPath, len(exportedTransactions)+1)
+	bytes, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing unsigned transaction: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Error saving unsigned transaction to %s: %w", path, err)
+	}
+	exportedTransactions = append(exportedTransactions, exportedTransaction{
+		path: path,
+		tx:   tx,
+	})
+	fmt.Printf("Exported unsigned transaction %d to %s.\n", len(exportedTransactions), path)
+
+	// None of the exported transactions have been submitted, so the next one needs the nonce after this one
+	c.customNonce = big.NewInt(0).SetUint64(tx.Nonce + 1)
+
+	paths := make([]string, len(exportedTransactions))
+	for i, exported := range exportedTransactions {
+		paths[i] = exported.path
+	}
+	cliOutput.Set("unsignedTransactionFiles", paths)
+	return getInterceptedTransactionResponse()
+}
+
+// Print the transactions that were exported during this command, and why it may have stopped early if it failed
+func printExportedTransactions(commandErr error) {
+	if len(exportedTransactions) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("%sNone of this command's transactions were submitted. %d were saved unsigned:%s\n", colorYellow, len(exportedTransactions), colorReset)
+	for i, exported := range exportedTransactions {
+		fmt.Printf("%d. %s\n", i+1, exported.path)
+		fmt.Printf("\tFrom:  %s\n", exported.tx.From.Hex())
+		if exported.tx.To != nil {
+			fmt.Printf("\tTo:    %s\n", exported.tx.To.Hex())
+		}
+		fmt.Printf("\tNonce: %d\n", exported.tx.Nonce)
+	}
+	fmt.Println()
+	fmt.Println("Sign them on your offline machine with `rocketpool wallet sign-tx`, then submit them in order with `rocketpool node broadcast-tx`.")
+	if commandErr != nil {
+		fmt.Printf("%sThe command failed after exporting these. Each transaction is built without the effects of the ones before it, so if a later one depends on them (e.g. on an approval), run the command again once they have been included in a block.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+	fmt.Println("To move a transaction with a QR code instead, encode its payload (e.g. with `qrencode -t ansiutf8`):")
+	for i, exported := range exportedTransactions {
+		fmt.Printf("%d. %s\n", i+1, exported.tx.Payload.String())
+	}
+}
+
+// Get the path to save the nth exported transaction to, numbering the ones after the first before the extension
+func getExportPath(path string, n int) string {
+	if n == 1 {
+		return path
+	}
+	extension := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, extension), n, extension)
+}
+
+// Load an unsigned transaction from a file exported with --export-unsigned, or from its raw payload.
+// The sender is only known when loading from a file.
+func LoadUnsignedTransaction(source string) (*types.Transaction, *common.Address, error) {
+
+	var from *common.Address
+	var payload []byte
+	if strings.HasPrefix(source, "0x") && !fileExists(source) {
+		bytes, err := hexutil.Decode(source)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding unsigned transaction payload: %w", err)
+		}
+		payload = bytes
+	} else {
+		bytes, err := os.ReadFile(source)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading unsigned transaction file %s: %w", source, err)
+		}
+		var unsignedTx api.UnsignedTransaction
+		if err := json.Unmarshal(bytes, &unsignedTx); err != nil {
+			return nil, nil, fmt.Errorf("error deserializing unsigned transaction file %s: %w", source, err)
+		}
+		if unsignedTx.Version != api.OfflineTransactionVersion {
+			return nil, nil, fmt.Errorf("unsigned transaction file %s has unsupported version %d", source, unsignedTx.Version)
+		}
+		from = &unsignedTx.From
+		payload = unsignedTx.Payload
+	}
+
+	// The payload is the source of truth; the other fields in the file are only there for review
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(payload); err != nil {
+		return nil, nil, fmt.Errorf("error decoding unsigned transaction: %w", err)
+	}
+	if tx.Type() != types.DynamicFeeTxType {
+		return nil, nil, fmt.Errorf("unsigned transaction has type %d, but only EIP-1559 transactions are supported", tx.Type())
+	}
+	return tx, from, nil
+
+}
+
+// Save a transaction that was signed offline so it can be moved back to the node and broadcast
+func SaveSignedTransaction(path string, tx *types.Transaction, from common.Address) error {
+
+	payload, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error serializing signed transaction: %w", err)
+	}
+	bytes, err := json.MarshalIndent(api.SignedTransaction{
+		Version: api.OfflineTransactionVersion,
+		ChainID: tx.ChainId(),
+		From:    from,
+		Nonce:   tx.Nonce(),
+		Hash:    tx.Hash(),
+		Payload: payload,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing signed transaction: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error saving signed transaction to %s: %w", path, err)
+	}
+	return nil
+
+}
+
+// Load a signed transaction from a file saved by `wallet sign-tx`, or from its raw payload
+func LoadSignedTransaction(source string) (*types.Transaction, common.Address, error) {
+
+	var payload []byte
+	if strings.HasPrefix(source, "0x") && !fileExists(source) {
+		bytes, err := hexutil.Decode(source)
+		if err != nil {
+			return nil, common.Address{}, fmt.Errorf("error decoding signed transaction payload: %w", err)
+		}
+		payload = bytes
+	} else {
+		bytes, err := os.ReadFile(source)
+		if err != nil {
+			return nil, common.Address{}, fmt.Errorf("error reading signed transaction file %s: %w", source, err)
+		}
+		var signedTx api.SignedTransaction
+		if err := json.Unmarshal(bytes, &signedTx); err != nil {
+			return nil, common.Address{}, fmt.Errorf("error deserializing signed transaction file %s: %w", source, err)
+		}
+		if signedTx.Version != api.OfflineTransactionVersion {
+			return nil, common.Address{}, fmt.Errorf("signed transaction file %s has unsupported version %d", source, signedTx.Version)
+		}
+		payload = signedTx.Payload
+	}
+
+	// Make sure the transaction is actually signed
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(payload); err != nil {
+		return nil, common.Address{}, fmt.Errorf("error decoding signed transaction: %w", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("the transaction does not have a valid signature: %w", err)
+	}
+	return tx, from, nil
+
+}
+
+// Check if a file exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
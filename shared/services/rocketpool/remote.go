@@ -0,0 +1,61 @@
+package rocketpool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Create a Rocket Pool client for a node other than the one in the global flags.
+// If sshClient is set, the node is on a remote host and every command (including API calls) runs over that connection;
+// configPath is then the node's config path on the remote host.
+// Its API responses aren't added to the CLI's machine-readable output, since they don't belong to the local node.
+func NewClientForNode(c *cli.Context, configPath string, sshClient *ssh.Client) *Client {
+	client := NewClientFromCtx(c)
+	client.configPath = configPath
+	client.daemonPath = ""
+	client.client = sshClient
+	client.recordResponses = false
+	return client
+}
+
+// Check if this client runs its commands on a remote host
+func (c *Client) IsRemote() bool {
+	return c.client != nil
+}
+
+// Load the config of a remote node over SSH
+func (c *Client) loadRemoteConfig() (*config.RocketPoolConfig, bool, error) {
+	settingsFilePath := RemoteShellPath(fmt.Sprintf("%s/%s", strings.TrimSuffix(c.configPath, "/"), SettingsFile))
+	configBytes, err := c.readOutput(fmt.Sprintf("cat %s", settingsFilePath))
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read the remote Rocket Pool settings file at %s: %w", settingsFilePath, err)
+	}
+
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
+		return nil, false, fmt.Errorf("could not parse remote settings file: %w", err)
+	}
+	cfg := config.NewRocketPoolConfig(c.configPath, false)
+	if err := cfg.Deserialize(settings); err != nil {
+		return nil, false, fmt.Errorf("could not deserialize remote settings file: %w", err)
+	}
+	return cfg, false, nil
+}
+
+// Quote a path for a remote shell, leaving a leading ~ unquoted so it still expands to the remote user's home directory
+func RemoteShellPath(path string) string {
+	if path == "~" {
+		return "$HOME"
+	}
+	if strings.HasPrefix(path, "~/") {
+		return "\"$HOME\"/" + shellescape.Quote(path[2:])
+	}
+	return shellescape.Quote(path)
+}
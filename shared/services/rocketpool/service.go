@@ -41,6 +41,22 @@ func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	return response, nil
 }
 
+// Gets the node daemon's details for a health and misconfiguration diagnosis
+func (c *Client) GetDoctorStatus() (api.ServiceDoctorResponse, error) {
+	responseBytes, err := c.callAPI("service doctor")
+	if err != nil {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not get doctor status: %w", err)
+	}
+	var response api.ServiceDoctorResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not decode doctor response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not get doctor status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Restarts the Validator client
 func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	responseBytes, err := c.callAPI("service restart-vc")
@@ -56,3 +72,19 @@ func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	}
 	return response, nil
 }
+
+// Sets the graffiti in the settings on every validator in the Validator client
+func (c *Client) SetGraffiti() (api.SetGraffitiResponse, error) {
+	responseBytes, err := c.callAPI("service set-graffiti")
+	if err != nil {
+		return api.SetGraffitiResponse{}, fmt.Errorf("Could not set graffiti: %w", err)
+	}
+	var response api.SetGraffitiResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetGraffitiResponse{}, fmt.Errorf("Could not decode set-graffiti response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetGraffitiResponse{}, fmt.Errorf("Could not set graffiti: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,237 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Slashing protection is moved in and out of a VC by running its own image against the validator data folder,
+// with the interchange file in a temporary folder mounted next to it
+const (
+	slashingProtectionValidatorsMount  string = "/validators"
+	slashingProtectionInterchangeMount string = "/interchange"
+	SlashingProtectionFilename         string = "slashing-protection.json"
+)
+
+// An EIP-3076 slashing protection interchange file
+type SlashingProtectionInterchange struct {
+	Metadata struct {
+		InterchangeFormatVersion string `json:"interchange_format_version"`
+		GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+	} `json:"metadata"`
+	Data []struct {
+		Pubkey             string            `json:"pubkey"`
+		SignedBlocks       []json.RawMessage `json:"signed_blocks"`
+		SignedAttestations []json.RawMessage `json:"signed_attestations"`
+	} `json:"data"`
+}
+
+// Load an EIP-3076 slashing protection interchange file
+func LoadSlashingProtectionInterchange(path string) (*SlashingProtectionInterchange, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading slashing protection file %s: %w", path, err)
+	}
+	var interchange SlashingProtectionInterchange
+	if err := json.Unmarshal(bytes, &interchange); err != nil {
+		return nil, fmt.Errorf("error deserializing slashing protection file %s: %w", path, err)
+	}
+	if interchange.Metadata.InterchangeFormatVersion != "5" {
+		return nil, fmt.Errorf("slashing protection file %s has unsupported interchange format version [%s]", path, interchange.Metadata.InterchangeFormatVersion)
+	}
+	return &interchange, nil
+}
+
+// Get the validators the interchange has slashing protection data for
+func (i *SlashingProtectionInterchange) Pubkeys() map[types.ValidatorPubkey]bool {
+	pubkeys := map[types.ValidatorPubkey]bool{}
+	for _, validator := range i.Data {
+		pubkey, err := types.HexToValidatorPubkey(strings.TrimPrefix(validator.Pubkey, "0x"))
+		if err == nil {
+			pubkeys[pubkey] = true
+		}
+	}
+	return pubkeys
+}
+
+// Export the EIP-3076 slashing protection data of a VC to a file. The VC should be stopped so the export is complete.
+// image is the VC image that produced the data, which may differ from the one in the settings if the client was just changed.
+func (c *Client) ExportSlashingProtection(cfg *config.RocketPoolConfig, client cfgtypes.ConsensusClient, image string, targetFile string) error {
+	return c.runSlashingProtectionCommand(cfg, client, image, targetFile, true)
+}
+
+// Import EIP-3076 slashing protection data into a VC. The VC must be stopped.
+func (c *Client) ImportSlashingProtection(cfg *config.RocketPoolConfig, client cfgtypes.ConsensusClient, image string, sourceFile string) error {
+	return c.runSlashingProtectionCommand(cfg, client, image, sourceFile, false)
+}
+
+// Run a VC's slashing protection export or import command in a temporary container
+func (c *Client) runSlashingProtectionCommand(cfg *config.RocketPoolConfig, client cfgtypes.ConsensusClient, image string, file string, export bool) error {
+
+	if cfg.IsNativeMode {
+		return fmt.Errorf("slashing protection can only be managed automatically in Docker mode; please use your validator client's own tools")
+	}
+	if c.IsRemote() {
+		return fmt.Errorf("slashing protection can't be managed over SSH; please run this command on the node itself")
+	}
+
+	// Get the VC's data folder
+	validatorsPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPathInCLI()))
+	if err != nil {
+		return fmt.Errorf("error expanding validator folder path: %w", err)
+	}
+
+	// Set up the folder for the interchange file
+	interchangeFolder, err := os.MkdirTemp("", "rocketpool-slashing-protection-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary folder for slashing protection: %w", err)
+	}
+	defer os.RemoveAll(interchangeFolder)
+	err = os.Chmod(interchangeFolder, 0777)
+	if err != nil {
+		return fmt.Errorf("error setting permissions on temporary folder for slashing protection: %w", err)
+	}
+	interchangeFile := filepath.Join(interchangeFolder, SlashingProtectionFilename)
+	if !export {
+		bytes, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading slashing protection file %s: %w", file, err)
+		}
+		err = os.WriteFile(interchangeFile, bytes, 0644)
+		if err != nil {
+			return fmt.Errorf("error staging slashing protection file: %w", err)
+		}
+	}
+
+	// Get the client's command
+	entrypoint, args, needsBeaconNode, err := getSlashingProtectionCommand(cfg, client, export)
+	if err != nil {
+		return err
+	}
+	networkArg := ""
+	if needsBeaconNode {
+		beaconNodeUrl, err := cfg.ConsensusClientApiUrl()
+		if err != nil {
+			return fmt.Errorf("error getting Beacon Node URL: %w", err)
+		}
+		args = fmt.Sprintf("%s --beaconNodes %s", args, shellescape.Quote(beaconNodeUrl))
+		if cfg.ConsensusClientLocal() {
+			networkArg = fmt.Sprintf("--network %s ", shellescape.Quote(cfg.Smartnode.ProjectName.Value.(string)+"_net"))
+		}
+	}
+
+	// Run it
+	cmd := fmt.Sprintf("docker run --rm %s-v %s:%s -v %s:%s --entrypoint %s %s %s",
+		networkArg,
+		shellescape.Quote(validatorsPath), slashingProtectionValidatorsMount,
+		shellescape.Quote(interchangeFolder), slashingProtectionInterchangeMount,
+		shellescape.Quote(entrypoint), shellescape.Quote(image), args,
+	)
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("error running the %s slashing protection tool: %w\n%s", client, err, strings.TrimSpace(string(output)))
+	}
+
+	if !export {
+		return nil
+	}
+
+	// Prysm names the file itself
+	if client == cfgtypes.ConsensusClient_Prysm {
+		interchangeFile = filepath.Join(interchangeFolder, "slashing_protection.json")
+	}
+	bytes, err := os.ReadFile(interchangeFile)
+	if err != nil {
+		return fmt.Errorf("the %s slashing protection tool didn't export anything: %w\n%s", client, err, strings.TrimSpace(string(output)))
+	}
+	err = os.WriteFile(file, bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error saving slashing protection file %s: %w", file, err)
+	}
+	return nil
+
+}
+
+// Get the entrypoint and arguments of a VC's slashing protection tool, and whether it needs to reach the Beacon Node
+func getSlashingProtectionCommand(cfg *config.RocketPoolConfig, client cfgtypes.ConsensusClient, export bool) (string, string, bool, error) {
+
+	interchangeFile := slashingProtectionInterchangeMount + "/" + SlashingProtectionFilename
+	operation := "import"
+	if export {
+		operation = "export"
+	}
+
+	// The devnet runs on Holesky
+	network := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	if cfg.Smartnode.Network.Value.(cfgtypes.Network) == cfgtypes.Network_Devnet {
+		network = string(cfgtypes.Network_Holesky)
+	}
+
+	switch client {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		return "lighthouse", fmt.Sprintf("account validator slashing-protection %s %s --datadir %s/lighthouse --network %s", operation, interchangeFile, slashingProtectionValidatorsMount, network), false, nil
+
+	case cfgtypes.ConsensusClient_Lodestar:
+		return "node", fmt.Sprintf("./packages/cli/bin/lodestar validator slashing-protection %s --file %s --dataDir %s/lodestar --network %s", operation, interchangeFile, slashingProtectionValidatorsMount, network), true, nil
+
+	case cfgtypes.ConsensusClient_Nimbus:
+		return "/home/user/nimbus-eth2/build/nimbus_beacon_node", fmt.Sprintf("slashingdb %s %s --data-dir=%s/nimbus", operation, interchangeFile, slashingProtectionValidatorsMount), false, nil
+
+	case cfgtypes.ConsensusClient_Prysm:
+		if export {
+			return "/app/cmd/validator/validator", fmt.Sprintf("slashing-protection-history export --datadir=%s/prysm-non-hd/direct --slashing-protection-export-dir=%s --accept-terms-of-use", slashingProtectionValidatorsMount, slashingProtectionInterchangeMount), false, nil
+		}
+		return "/app/cmd/validator/validator", fmt.Sprintf("slashing-protection-history import --datadir=%s/prysm-non-hd/direct --slashing-protection-json-file=%s --accept-terms-of-use", slashingProtectionValidatorsMount, interchangeFile), false, nil
+
+	case cfgtypes.ConsensusClient_Teku:
+		if export {
+			return "/opt/teku/bin/teku", fmt.Sprintf("slashing-protection export --data-path=%s/teku --to=%s", slashingProtectionValidatorsMount, interchangeFile), false, nil
+		}
+		return "/opt/teku/bin/teku", fmt.Sprintf("slashing-protection import --data-path=%s/teku --from=%s", slashingProtectionValidatorsMount, interchangeFile), false, nil
+
+	default:
+		return "", "", false, fmt.Errorf("unknown validator client [%s]", client)
+	}
+
+}
+
+// Get the image that runs a client's slashing protection tool. Nimbus's tool only ships with its Beacon Node image.
+func GetSlashingProtectionImage(cfg *config.RocketPoolConfig, client cfgtypes.ConsensusClient, validatorImage string) string {
+	if client == cfgtypes.ConsensusClient_Nimbus {
+		return cfg.Nimbus.BnContainerTag.Value.(string)
+	}
+	return validatorImage
+}
+
+// Get the client that a VC image belongs to
+func GetConsensusClientFromImage(image string) (cfgtypes.ConsensusClient, error) {
+	name := image
+	if index := strings.LastIndex(name, ":"); index > strings.LastIndex(name, "/") {
+		name = name[:index]
+	}
+	name = name[strings.LastIndex(name, "/")+1:]
+	switch {
+	case strings.HasPrefix(name, "lighthouse"):
+		return cfgtypes.ConsensusClient_Lighthouse, nil
+	case strings.HasPrefix(name, "lodestar"):
+		return cfgtypes.ConsensusClient_Lodestar, nil
+	case strings.HasPrefix(name, "nimbus"):
+		return cfgtypes.ConsensusClient_Nimbus, nil
+	case strings.HasPrefix(name, "prysm"), strings.HasPrefix(name, "validator"):
+		return cfgtypes.ConsensusClient_Prysm, nil
+	case strings.HasPrefix(name, "teku"):
+		return cfgtypes.ConsensusClient_Teku, nil
+	default:
+		return cfgtypes.ConsensusClient_Unknown, fmt.Errorf("couldn't determine the validator client of image [%s]", image)
+	}
+}
@@ -1,10 +1,19 @@
 package rocketpool
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
+	"github.com/mitchellh/go-homedir"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -41,6 +50,68 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 	return response, nil
 }
 
+// Unlock the node wallet of a long-running process (see config.WalletUnlockProcesses) by sending it the wallet password over its local socket.
+// Returns false if the process isn't running or isn't waiting for the password.
+func (c *Client) UnlockWallet(process string, password string) (bool, error) {
+
+	// The socket can only be reached from the local machine
+	if c.client != nil {
+		return false, errors.New("The node wallet can only be unlocked from the node machine itself.")
+	}
+
+	// Check if the socket exists
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil {
+		return false, err
+	}
+	if isNew {
+		return false, errors.New("Settings file not found.")
+	}
+	socketPath, err := homedir.Expand(os.ExpandEnv(cfg.Smartnode.GetWalletUnlockSocketPathInCLI(process)))
+	if err != nil {
+		return false, fmt.Errorf("Could not get the wallet unlock socket path: %w", err)
+	}
+	if _, err := os.Stat(socketPath); err != nil {
+		return false, nil
+	}
+
+	// Send the password over the socket
+	body, err := json.Marshal(api.UnlockWalletRequest{Password: password})
+	if err != nil {
+		return false, fmt.Errorf("Could not serialize wallet unlock request: %w", err)
+	}
+	httpClient := http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	response, err := httpClient.Post("http://rocketpool"+api.WalletUnlockPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// The socket was left behind by a process that isn't running anymore
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Could not unlock the %s wallet (you may need to run this command with sudo): %w", process, err)
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, fmt.Errorf("Could not read the %s wallet unlock response: %w", process, err)
+	}
+
+	var unlockResponse api.UnlockWalletResponse
+	if err := json.Unmarshal(responseBytes, &unlockResponse); err != nil {
+		return false, fmt.Errorf("Could not decode the %s wallet unlock response: %w", process, err)
+	}
+	if unlockResponse.Error != "" {
+		return true, fmt.Errorf("Could not unlock the %s wallet: %s", process, unlockResponse.Error)
+	}
+	return true, nil
+}
+
 // Initialize wallet
 func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet init --derivation-path", derivationPath)
@@ -165,6 +236,22 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Delete every validator key from the validator client through its keymanager API
+func (c *Client) DeleteValidatorKeys() (api.DeleteValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet delete-validator-keys")
+	if err != nil {
+		return api.DeleteValidatorKeysResponse{}, fmt.Errorf("Could not delete validator keys: %w", err)
+	}
+	var response api.DeleteValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DeleteValidatorKeysResponse{}, fmt.Errorf("Could not decode delete validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DeleteValidatorKeysResponse{}, fmt.Errorf("Could not delete validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
@@ -5,25 +5,31 @@ import (
 	"math/big"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
 // Config
@@ -42,8 +48,11 @@ var (
 	rocketSignerRegistry *contracts.RocketSignerRegistry
 	beaconClient         beacon.Client
 	docker               *client.Client
+	txManager            *txmanager.Manager
 
-	initCfg                  sync.Once
+	cfgLock                  sync.Mutex
+	cfgModTime               time.Time
+	reloadCfg                bool
 	initPasswordManager      sync.Once
 	initNodeWallet           sync.Once
 	initECManager            sync.Once
@@ -53,6 +62,7 @@ var (
 	initRocketSignerRegistry sync.Once
 	initBeaconClient         sync.Once
 	initDocker               sync.Once
+	initTxManager            sync.Once
 )
 
 //
@@ -80,6 +90,49 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	return getWallet(c, cfg, pm)
 }
 
+// Get a client for the VC's keymanager API, or nil if the Smartnode should restart the VC instead of using it
+func GetKeymanager(c *cli.Context) (*keymanager.Client, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	url := cfg.GetVcKeymanagerUrl()
+	if url == "" {
+		return nil, nil
+	}
+	return keymanager.NewClientWithTokenFile(url, os.ExpandEnv(cfg.Smartnode.GetKeymanagerTokenPath())), nil
+}
+
+// Get a signer for the validator with the given pubkey, using the remote signer if it's enabled or the wallet's key for it otherwise
+func GetValidatorSigner(c *cli.Context, pubkey types.ValidatorPubkey) (validator.Signer, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.EnableRemoteSigner.Value.(bool) {
+		bc, err := GetBeaconClient(c)
+		if err != nil {
+			return nil, err
+		}
+		eth2Config, err := bc.GetEth2Config()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Beacon config: %w", err)
+		}
+		ks := web3signer.NewKeystore(cfg.RemoteSigner.Url.Value.(string), cfg.RemoteSigner.KeymanagerToken.Value.(string))
+		return ks.GetSigner(pubkey, eth2Config), nil
+	}
+
+	w, err := getWallet(c, cfg, getPasswordManager(cfg))
+	if err != nil {
+		return nil, err
+	}
+	key, err := w.GetValidatorKeyByPubkey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return validator.NewLocalSigner(key), nil
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -111,6 +164,22 @@ func GetRocketPool(c *cli.Context) (*rocketpool.RocketPool, error) {
 	return getRocketPool(cfg, ec)
 }
 
+func GetTxManager(c *cli.Context) (*txmanager.Manager, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	return getTxManager(cfg, rp.Client, w), nil
+}
+
 func GetRocketSignerRegistry(c *cli.Context) (*contracts.RocketSignerRegistry, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -139,25 +208,109 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 	return docker, err
 }
 
+// Apply the global flags of the current call to the service instances that have already been created.
+// The API server handles many calls from a single process, so the gas and client sync settings that were
+// captured when the services were first created need to be refreshed for each call, using the current settings.
+func ApplyCallFlags(c *cli.Context) error {
+	if cfg == nil {
+		return nil
+	}
+	currentCfg, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+	if nodeWallet != nil {
+		maxFee, maxPriorityFee := getGasSettings(c, currentCfg)
+		nodeWallet.SetGasSettings(maxFee, maxPriorityFee, 0)
+		if err := applyUnsignedMode(c, nodeWallet); err != nil {
+			return err
+		}
+	}
+	if ecManager != nil {
+		ecManager.applyCallFlags(c.GlobalBool("ignore-sync-check"), c.GlobalBool("force-fallbacks"), c.GlobalBool("dry-run"), c.GlobalBool("unsigned"))
+	}
+	if bcManager != nil {
+		bcManager.applyCallFlags(c.GlobalBool("ignore-sync-check"), c.GlobalBool("force-fallbacks"))
+	}
+	return nil
+}
+
+// Set the wallet's unsigned mode and the addresses it should use from the global flags
+func applyUnsignedMode(c *cli.Context, w *wallet.Wallet) error {
+	if !c.GlobalBool("unsigned") {
+		w.SetUnsignedMode(false, nil, nil)
+		return nil
+	}
+	nodeAddress, err := getOptionalAddressFlag(c, "unsigned-node")
+	if err != nil {
+		return err
+	}
+	sender, err := getOptionalAddressFlag(c, "unsigned-from")
+	if err != nil {
+		return err
+	}
+	w.SetUnsignedMode(true, nodeAddress, sender)
+	return nil
+}
+
+// Get the value of a global address flag, or nil if it isn't set
+func getOptionalAddressFlag(c *cli.Context, name string) (*common.Address, error) {
+	value := c.GlobalString(name)
+	if value == "" {
+		return nil, nil
+	}
+	if !common.IsHexAddress(value) {
+		return nil, fmt.Errorf("Invalid %s address: %s", name, value)
+	}
+	address := common.HexToAddress(value)
+	return &address, nil
+}
+
 //
 // Service instance getters
 //
 
 func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
-	var err error
-	initCfg.Do(func() {
-		settingsFile := os.ExpandEnv(c.GlobalString("settings"))
-		cfg, err = rp.LoadConfigFromFile(settingsFile)
-		if cfg == nil && err == nil {
-			err = fmt.Errorf("Settings file [%s] not found.", settingsFile)
+	cfgLock.Lock()
+	defer cfgLock.Unlock()
+
+	// Keep the loaded settings unless they should be reloaded and the file has changed since
+	settingsFile := os.ExpandEnv(c.GlobalString("settings"))
+	info, err := os.Stat(settingsFile)
+	if cfg != nil && (!reloadCfg || err != nil || info.ModTime().Equal(cfgModTime)) {
+		return cfg, nil
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("Settings file [%s] not found.", settingsFile)
 		}
-	})
-	return cfg, err
+		return nil, fmt.Errorf("error checking settings file [%s]: %w", settingsFile, err)
+	}
+
+	loadedCfg, err := rp.LoadConfigFromFile(settingsFile)
+	if err != nil {
+		return nil, err
+	}
+	if loadedCfg == nil {
+		return nil, fmt.Errorf("Settings file [%s] not found.", settingsFile)
+	}
+	cfg = loadedCfg
+	cfgModTime = info.ModTime()
+	return cfg, nil
+}
+
+// Reload the settings whenever the settings file changes instead of keeping the first copy that was loaded.
+// The API server uses this because it's a long-running process, and some of the settings its calls read (such as the gas
+// settings) can be changed without restarting it. Services that were already created from the old settings are kept.
+func EnableConfigReload() {
+	cfgLock.Lock()
+	defer cfgLock.Unlock()
+	reloadCfg = true
 }
 
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()), cfg.Smartnode.SaveWalletPassword.Value.(bool))
 	})
 	return passwordManager
 }
@@ -165,28 +318,23 @@ func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
 	var err error
 	initNodeWallet.Do(func() {
-		var maxFee *big.Int
-		maxFeeFloat := c.GlobalFloat64("maxFee")
-		if maxFeeFloat == 0 {
-			maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
-		}
-		if maxFeeFloat != 0 {
-			maxFee = eth.GweiToWei(maxFeeFloat)
-		}
+		maxFee, maxPriorityFee := getGasSettings(c, cfg)
+		chainId := cfg.Smartnode.GetChainID()
 
-		var maxPriorityFee *big.Int
-		maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
-		if maxPriorityFeeFloat == 0 {
-			maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
+		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
+		if err != nil {
+			return
 		}
-		if maxPriorityFeeFloat != 0 {
-			maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
+		if err = applyUnsignedMode(c, nodeWallet); err != nil {
+			return
 		}
 
-		chainId := cfg.Smartnode.GetChainID()
+		// Import validator keys into the remote signer instead of saving them if it's enabled
+		if cfg.EnableRemoteSigner.Value.(bool) {
+			nodeWallet.AddKeystore("web3signer", web3signer.NewKeystore(cfg.RemoteSigner.Url.Value.(string), cfg.RemoteSigner.KeymanagerToken.Value.(string)))
 
-		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
-		if err != nil {
+			// Lighthouse finds the keys in the signer through its validator definitions file
+			nodeWallet.AddKeystore("lighthouse", lhkeystore.NewRemoteKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), cfg.RemoteSigner.Url.Value.(string)))
 			return
 		}
 
@@ -205,6 +353,28 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 	return nodeWallet, err
 }
 
+func getGasSettings(c *cli.Context, cfg *config.RocketPoolConfig) (*big.Int, *big.Int) {
+	var maxFee *big.Int
+	maxFeeFloat := c.GlobalFloat64("maxFee")
+	if maxFeeFloat == 0 {
+		maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
+	}
+	if maxFeeFloat != 0 {
+		maxFee = eth.GweiToWei(maxFeeFloat)
+	}
+
+	var maxPriorityFee *big.Int
+	maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
+	if maxPriorityFeeFloat == 0 {
+		maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
+	}
+	if maxPriorityFeeFloat != 0 {
+		maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
+	}
+
+	return maxFee, maxPriorityFee
+}
+
 func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 	var err error
 	initECManager.Do(func() {
@@ -218,6 +388,9 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 			if c.GlobalBool("force-fallbacks") {
 				ecManager.primaryReady = false
 			}
+			// Simulate or export transactions instead of submitting them if requested
+			ecManager.dryRun = c.GlobalBool("dry-run")
+			ecManager.unsigned = c.GlobalBool("unsigned")
 		}
 	})
 	return ecManager, err
@@ -231,6 +404,13 @@ func getRocketPool(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClie
 	return rocketPool, err
 }
 
+func getTxManager(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient, w *wallet.Wallet) *txmanager.Manager {
+	initTxManager.Do(func() {
+		txManager = txmanager.NewManager(cfg, client, w)
+	})
+	return txManager
+}
+
 func getRocketSignerRegistry(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*contracts.RocketSignerRegistry, error) {
 	var err error
 	initRocketSignerRegistry.Do(func() {
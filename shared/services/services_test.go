@@ -0,0 +1,68 @@
+package services
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Load the settings and get their manual max fee
+func loadMaxFee(t *testing.T, c *cli.Context) float64 {
+	t.Helper()
+	loadedCfg, err := getConfig(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loadedCfg.Smartnode.ManualMaxFee.Value.(float64)
+}
+
+// Save a settings file with the given manual max fee, making sure its modification time changes
+func saveSettings(t *testing.T, folder string, maxFee float64) {
+	t.Helper()
+	settings := config.NewRocketPoolConfig(folder, false)
+	settings.Smartnode.ManualMaxFee.Value = maxFee
+	if err := rp.SaveConfig(settings, folder, "user-settings.yml"); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(time.Duration(maxFee) * time.Second)
+	if err := os.Chtimes(filepath.Join(folder, "user-settings.yml"), modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigReload(t *testing.T) {
+	folder := t.TempDir()
+	saveSettings(t, folder, 1)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String("settings", filepath.Join(folder, "user-settings.yml"), "")
+	c := cli.NewContext(cli.NewApp(), flags, nil)
+	defer func() {
+		cfg = nil
+		reloadCfg = false
+	}()
+
+	// Without reloading, the first settings are kept
+	loadMaxFee(t, c)
+	saveSettings(t, folder, 2)
+	if maxFee := loadMaxFee(t, c); maxFee != 1 {
+		t.Fatalf("expected the first settings to be kept, got a max fee of %f", maxFee)
+	}
+
+	// With reloading, a changed file is loaded again
+	EnableConfigReload()
+	if maxFee := loadMaxFee(t, c); maxFee != 2 {
+		t.Fatalf("expected the changed settings to be loaded, got a max fee of %f", maxFee)
+	}
+	loadedCfg := cfg
+	loadMaxFee(t, c)
+	if cfg != loadedCfg {
+		t.Fatal("expected the settings to be kept while the file is unchanged")
+	}
+}
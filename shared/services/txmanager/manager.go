@@ -0,0 +1,544 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often to check whether a pending transaction has been included
+	PollInterval time.Duration = 4 * time.Second
+
+	// How long to keep looking for a new transaction before giving up on it
+	FindTimeout time.Duration = 30 * time.Second
+
+	// The percentage to raise the fees of a stuck transaction by; execution clients need at least 10% to accept a replacement
+	FeeBumpPercent int64 = 20
+
+	// The minimum fee increase execution clients accept for a replacement
+	minReplacementPercent int64 = 10
+
+	// The gas limit of a plain ETH transfer, used for cancellations
+	transferGasLimit uint64 = 21000
+)
+
+// Tracks the node's pending transactions, persisting them to disk and replacing them with higher fees if they get stuck
+type Manager struct {
+	cfg   *config.RocketPoolConfig
+	ec    rocketpool.ExecutionClient
+	w     *wallet.Wallet
+	path  string
+	owner string
+	lock  sync.Mutex
+}
+
+// Create a new transaction manager
+func NewManager(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, w *wallet.Wallet) *Manager {
+	return &Manager{
+		cfg:   cfg,
+		ec:    ec,
+		w:     w,
+		path:  os.ExpandEnv(cfg.Smartnode.GetPendingTxPath()),
+		owner: "api",
+	}
+}
+
+// Take ownership of the transactions this process sent before it restarted, and resume waiting on them in the background
+func (m *Manager) Start(owner string, logger *log.ColorLogger) error {
+
+	m.owner = owner
+	txs, err := m.loadAll()
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		if tx.Owner != owner {
+			continue
+		}
+		logger.Printlnf("Resuming pending transaction %s (nonce %d) from before the restart.", tx.Hash.Hex(), tx.Nonce)
+		go func(tx *api.PendingTransaction) {
+			_, err := m.wait(tx, logger)
+			if err != nil {
+				logger.Printlnf("Error waiting for transaction %s: %s", tx.Hash.Hex(), err.Error())
+			} else {
+				logger.Printlnf("Pending transaction with nonce %d has been included.", tx.Nonce)
+			}
+		}(tx)
+	}
+	return nil
+
+}
+
+// Start tracking a transaction that was just submitted, then wait for it to be included in a block.
+// If it gets stuck, it will be replaced with a copy that has higher fees.
+func (m *Manager) WaitForTransaction(hash common.Hash, logger *log.ColorLogger) (*types.Receipt, error) {
+
+	tx, err := m.track(hash)
+	if err != nil {
+		return nil, err
+	}
+	return m.wait(tx, logger)
+
+}
+
+// Get the node's pending transactions, removing any that have been included since they were last checked
+func (m *Manager) GetPendingTransactions() ([]api.PendingTransaction, uint64, uint64, error) {
+
+	nodeAccount, err := m.w.GetNodeAccount()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	latestNonce, err := m.ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("error getting latest nonce: %w", err)
+	}
+	pendingNonce, err := m.ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("error getting pending nonce: %w", err)
+	}
+
+	txs, err := m.loadAll()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	pending := []api.PendingTransaction{}
+	for _, tx := range txs {
+		if tx.Nonce < latestNonce {
+			err = m.remove(tx.Nonce)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			continue
+		}
+		pending = append(pending, *tx)
+	}
+	return pending, latestNonce, pendingNonce, nil
+
+}
+
+// Cancel the pending transaction with the given nonce by replacing it with an empty transfer to the node itself.
+// The replacement uses the given fees if they're set or the network's current fees if not, raised to whatever is needed to replace a tracked transaction.
+func (m *Manager) CancelTransaction(nonce uint64, maxFee *big.Int, maxPriorityFee *big.Int) (common.Hash, error) {
+
+	nodeAccount, err := m.w.GetNodeAccount()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// Make sure the nonce belongs to a pending transaction
+	latestNonce, err := m.ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting latest nonce: %w", err)
+	}
+	if nonce < latestNonce {
+		return common.Hash{}, fmt.Errorf("the transaction with nonce %d has already been included in a block", nonce)
+	}
+	pendingNonce, err := m.ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting pending nonce: %w", err)
+	}
+	if nonce >= pendingNonce {
+		return common.Hash{}, fmt.Errorf("there is no pending transaction with nonce %d (the next nonce is %d)", nonce, pendingNonce)
+	}
+
+	// Get the fees
+	tx, err := m.load(nonce)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if maxFee == nil || maxFee.Sign() == 0 || maxPriorityFee == nil || maxPriorityFee.Sign() == 0 {
+		maxFee, maxPriorityFee, err = m.getNetworkFees()
+		if err != nil {
+			return common.Hash{}, err
+		}
+	}
+	if tx != nil {
+		minFee := raiseByPercent(tx.MaxFee, minReplacementPercent+1)
+		minPriorityFee := raiseByPercent(tx.MaxPriorityFee, minReplacementPercent+1)
+		if maxFee.Cmp(minFee) < 0 {
+			maxFee = minFee
+		}
+		if maxPriorityFee.Cmp(minPriorityFee) < 0 {
+			maxPriorityFee = minPriorityFee
+		}
+	}
+	if maxPriorityFee.Cmp(maxFee) > 0 {
+		maxPriorityFee = maxFee
+	}
+
+	// Replace it with an empty transfer
+	to := nodeAccount.Address
+	cancellation := &api.PendingTransaction{
+		Owner:          m.owner,
+		Nonce:          nonce,
+		From:           nodeAccount.Address,
+		To:             &to,
+		Value:          big.NewInt(0),
+		GasLimit:       transferGasLimit,
+		MaxFee:         maxFee,
+		MaxPriorityFee: maxPriorityFee,
+		FirstSubmitted: time.Now(),
+	}
+	if tx != nil {
+		cancellation.ReplacedHashes = append(tx.ReplacedHashes, tx.Hash)
+	}
+	err = m.send(cancellation)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return cancellation.Hash, nil
+
+}
+
+// Start tracking a transaction that was just submitted
+func (m *Manager) track(hash common.Hash) (*api.PendingTransaction, error) {
+
+	// Get the transaction, retrying if the client hasn't seen it yet
+	var tx *types.Transaction
+	var err error
+	start := time.Now()
+	for {
+		tx, _, err = m.ec.TransactionByHash(context.Background(), hash)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ethereum.NotFound) && err.Error() != "not found" {
+			return nil, fmt.Errorf("error getting transaction %s: %w", hash.Hex(), err)
+		}
+		if time.Since(start) > FindTimeout {
+			return nil, fmt.Errorf("Transaction not found after %s.", FindTimeout)
+		}
+		time.Sleep(time.Second)
+	}
+
+	// Get the sender
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sender of transaction %s: %w", hash.Hex(), err)
+	}
+
+	// Save it
+	now := time.Now()
+	pendingTx := &api.PendingTransaction{
+		Hash:           hash,
+		Owner:          m.owner,
+		Nonce:          tx.Nonce(),
+		From:           from,
+		To:             tx.To(),
+		Value:          tx.Value(),
+		Data:           tx.Data(),
+		GasLimit:       tx.Gas(),
+		MaxFee:         tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+		FirstSubmitted: now,
+		LastSubmitted:  now,
+	}
+	err = m.save(pendingTx)
+	if err != nil {
+		return nil, err
+	}
+	return pendingTx, nil
+
+}
+
+// Wait for a pending transaction to be included, replacing it with higher fees if it gets stuck
+func (m *Manager) wait(tx *api.PendingTransaction, logger *log.ColorLogger) (*types.Receipt, error) {
+
+	timeout := time.Duration(m.cfg.Smartnode.StuckTxTimeout.Value.(uint64)) * time.Minute
+	maxFeeCap := eth.GweiToWei(m.cfg.Smartnode.StuckTxMaxFee.Value.(float64))
+	replacedElsewhere := false
+
+	for {
+		// Check if any version of the transaction has been included
+		receipt, err := m.getReceipt(tx)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			err = m.remove(tx.Nonce)
+			if err != nil {
+				logger.Printlnf("WARNING: couldn't remove pending transaction record: %s", err.Error())
+			}
+			if receipt.Status == types.ReceiptStatusFailed {
+				return receipt, errors.New("Transaction failed with status 0")
+			}
+			return receipt, nil
+		}
+
+		// Check if the nonce was used by something else
+		latestNonce, err := m.ec.NonceAt(context.Background(), tx.From, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest nonce: %w", err)
+		}
+		if latestNonce > tx.Nonce {
+			// Catch a receipt that was written after the first check
+			receipt, err := m.getReceipt(tx)
+			if err != nil {
+				return nil, err
+			}
+			if receipt == nil {
+				if err := m.remove(tx.Nonce); err != nil {
+					logger.Printlnf("WARNING: couldn't remove pending transaction record: %s", err.Error())
+				}
+				return nil, fmt.Errorf("Transaction %s was replaced by another transaction with the same nonce (%d).", tx.Hash.Hex(), tx.Nonce)
+			}
+			continue
+		}
+
+		// Stop replacing the transaction if something else already did, such as a cancellation from the CLI
+		if !replacedElsewhere {
+			saved, err := m.load(tx.Nonce)
+			if err != nil {
+				return nil, err
+			}
+			if saved != nil && saved.Hash != tx.Hash {
+				logger.Printlnf("Transaction %s has been replaced by %s, waiting for one of them to be included.", tx.Hash.Hex(), saved.Hash.Hex())
+				replacedElsewhere = true
+			}
+		}
+
+		// Replace the transaction if it's stuck
+		if !replacedElsewhere && timeout > 0 && time.Since(tx.LastSubmitted) > timeout {
+			err = m.bump(tx, maxFeeCap, logger)
+			if err != nil {
+				logger.Printlnf("WARNING: couldn't replace stuck transaction %s: %s", tx.Hash.Hex(), err.Error())
+			}
+		}
+
+		time.Sleep(PollInterval)
+	}
+
+}
+
+// Replace a stuck transaction with a copy that has higher fees
+func (m *Manager) bump(tx *api.PendingTransaction, maxFeeCap *big.Int, logger *log.ColorLogger) error {
+
+	// Get the new fees
+	newMaxFee, newPriorityFee, ok := getBumpedFees(tx.MaxFee, tx.MaxPriorityFee, maxFeeCap)
+	if !ok {
+		// Don't check again until the next timeout
+		tx.LastSubmitted = time.Now()
+		logger.Printlnf("Transaction %s has been pending since %s, but its max fee of %.2f gwei can't be raised any further without going over the stuck TX max fee of %.2f gwei. Waiting for the network's fees to come down.",
+			tx.Hash.Hex(), tx.FirstSubmitted.Format(time.RFC1123), eth.WeiToGwei(tx.MaxFee), eth.WeiToGwei(maxFeeCap))
+		return nil
+	}
+
+	// Send the replacement
+	replacement := *tx
+	replacement.ReplacedHashes = append(append([]common.Hash{}, tx.ReplacedHashes...), tx.Hash)
+	replacement.Owner = m.owner
+	replacement.MaxFee = newMaxFee
+	replacement.MaxPriorityFee = newPriorityFee
+	err := m.send(&replacement)
+	if err != nil {
+		// Don't try again until the next timeout
+		tx.LastSubmitted = time.Now()
+		return err
+	}
+	logger.Printlnf("Transaction %s was stuck for %s, replaced it with %s using a max fee of %.2f gwei and a priority fee of %.2f gwei.",
+		tx.Hash.Hex(), time.Since(tx.LastSubmitted).Round(time.Second), replacement.Hash.Hex(), eth.WeiToGwei(newMaxFee), eth.WeiToGwei(newPriorityFee))
+	*tx = replacement
+	return nil
+
+}
+
+// Get the fees for the replacement of a stuck transaction, which are raised by FeeBumpPercent but kept under the cap.
+// Returns false if the max fee can't be raised enough for execution clients to accept the replacement without going over the cap.
+func getBumpedFees(maxFee *big.Int, maxPriorityFee *big.Int, maxFeeCap *big.Int) (*big.Int, *big.Int, bool) {
+	newMaxFee := raiseByPercent(maxFee, FeeBumpPercent)
+	if newMaxFee.Cmp(maxFeeCap) > 0 {
+		newMaxFee = maxFeeCap
+	}
+	if newMaxFee.Cmp(raiseByPercent(maxFee, minReplacementPercent)) < 0 {
+		return nil, nil, false
+	}
+	newPriorityFee := raiseByPercent(maxPriorityFee, FeeBumpPercent)
+	if newPriorityFee.Cmp(newMaxFee) > 0 {
+		newPriorityFee = newMaxFee
+	}
+	return newMaxFee, newPriorityFee, true
+}
+
+// Sign and submit a transaction with the details in the record, then save it
+func (m *Manager) send(tx *api.PendingTransaction) error {
+
+	opts, err := m.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+	signedTx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   m.w.GetChainID(),
+		Nonce:     tx.Nonce,
+		GasTipCap: tx.MaxPriorityFee,
+		GasFeeCap: tx.MaxFee,
+		Gas:       tx.GasLimit,
+		To:        tx.To,
+		Value:     tx.Value,
+		Data:      tx.Data,
+	}))
+	if err != nil {
+		return fmt.Errorf("error signing transaction: %w", err)
+	}
+	err = m.ec.SendTransaction(context.Background(), signedTx)
+	if err != nil {
+		return fmt.Errorf("error submitting transaction: %w", err)
+	}
+
+	tx.Hash = signedTx.Hash()
+	tx.LastSubmitted = time.Now()
+	return m.save(tx)
+
+}
+
+// Get the receipt for any version of a pending transaction, or nil if none have been included yet
+func (m *Manager) getReceipt(tx *api.PendingTransaction) (*types.Receipt, error) {
+	hashes := append([]common.Hash{tx.Hash}, tx.ReplacedHashes...)
+	for _, hash := range hashes {
+		receipt, err := m.ec.TransactionReceipt(context.Background(), hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) && err.Error() != "not found" {
+			return nil, fmt.Errorf("error getting receipt for transaction %s: %w", hash.Hex(), err)
+		}
+	}
+	return nil, nil
+}
+
+// Get fees that should get a transaction included in the next few blocks
+func (m *Manager) getNetworkFees() (*big.Int, *big.Int, error) {
+	header, err := m.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	priorityFee, err := m.ec.SuggestGasTipCap(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting suggested priority fee: %w", err)
+	}
+	maxFee := big.NewInt(0).Mul(header.BaseFee, big.NewInt(2))
+	maxFee.Add(maxFee, priorityFee)
+	return maxFee, priorityFee, nil
+}
+
+// Get the path of the record for the transaction with the given nonce
+func (m *Manager) getRecordPath(nonce uint64) string {
+	return filepath.Join(m.path, fmt.Sprintf("%d.json", nonce))
+}
+
+// Save a pending transaction record
+func (m *Manager) save(tx *api.PendingTransaction) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	err := os.MkdirAll(m.path, 0700)
+	if err != nil {
+		return fmt.Errorf("error creating pending transaction folder [%s]: %w", m.path, err)
+	}
+	bytes, err := json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("error serializing pending transaction: %w", err)
+	}
+
+	// Write to a temporary file first so the record is never left half-written
+	path := m.getRecordPath(tx.Nonce)
+	err = os.WriteFile(path+".tmp", bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error saving pending transaction to [%s]: %w", path, err)
+	}
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		return fmt.Errorf("error saving pending transaction to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the pending transaction record for the given nonce, or nil if there isn't one
+func (m *Manager) load(nonce uint64) (*api.PendingTransaction, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	bytes, err := os.ReadFile(m.getRecordPath(nonce))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading pending transaction with nonce %d: %w", nonce, err)
+	}
+	var tx api.PendingTransaction
+	err = json.Unmarshal(bytes, &tx)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing pending transaction with nonce %d: %w", nonce, err)
+	}
+	return &tx, nil
+}
+
+// Load all of the pending transaction records, ordered by nonce
+func (m *Manager) loadAll() ([]*api.PendingTransaction, error) {
+	m.lock.Lock()
+	filenames, err := filepath.Glob(filepath.Join(m.path, "*.json"))
+	m.lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("error listing pending transactions: %w", err)
+	}
+
+	txs := []*api.PendingTransaction{}
+	for _, filename := range filenames {
+		var nonce uint64
+		_, err := fmt.Sscanf(strings.TrimSuffix(filepath.Base(filename), ".json"), "%d", &nonce)
+		if err != nil {
+			continue
+		}
+		tx, err := m.load(nonce)
+		if err != nil {
+			return nil, err
+		}
+		if tx != nil {
+			txs = append(txs, tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Nonce < txs[j].Nonce
+	})
+	return txs, nil
+}
+
+// Remove the pending transaction record for the given nonce
+func (m *Manager) remove(nonce uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	err := os.Remove(m.getRecordPath(nonce))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing pending transaction with nonce %d: %w", nonce, err)
+	}
+	return nil
+}
+
+// Raise a fee by the given percentage, rounding up
+func raiseByPercent(fee *big.Int, percent int64) *big.Int {
+	raised := big.NewInt(0).Mul(fee, big.NewInt(100+percent))
+	raised.Add(raised, big.NewInt(99))
+	return raised.Div(raised, big.NewInt(100))
+}
@@ -0,0 +1,275 @@
+package txmanager
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const testMnemonic string = "test test test test test test test test test test test junk"
+
+// An execution client that records the transactions sent to it; calls it doesn't implement panic
+type fakeExecutionClient struct {
+	rocketpool.ExecutionClient
+	latestNonce  uint64
+	pendingNonce uint64
+	baseFee      *big.Int
+	tip          *big.Int
+	sent         []*types.Transaction
+}
+
+func (ec *fakeExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	ec.sent = append(ec.sent, tx)
+	return nil
+}
+
+func (ec *fakeExecutionClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return ec.latestNonce, nil
+}
+
+func (ec *fakeExecutionClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return ec.pendingNonce, nil
+}
+
+func (ec *fakeExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: ec.baseFee}, nil
+}
+
+func (ec *fakeExecutionClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return ec.tip, nil
+}
+
+// Create a manager with a node wallet that keeps its records in a temporary folder
+func newTestManager(t *testing.T, ec *fakeExecutionClient) *Manager {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"), false)
+	if err := pm.SetPassword("test-password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := wallet.NewWallet(filepath.Join(dir, "wallet"), 1, nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Recover(wallet.DefaultNodeKeyPath, 0, testMnemonic); err != nil {
+		t.Fatal(err)
+	}
+	return &Manager{
+		cfg:   config.NewRocketPoolConfig(dir, false),
+		ec:    ec,
+		w:     w,
+		path:  filepath.Join(dir, "pending-txs"),
+		owner: "node",
+	}
+}
+
+// Create a record of a transaction from the manager's node wallet that was sent with the given fees, in gwei
+func newTestTransaction(t *testing.T, m *Manager, nonce uint64, maxFee float64, maxPriorityFee float64) *api.PendingTransaction {
+	nodeAccount, err := m.w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1234")
+	submitted := time.Now().Add(-time.Hour)
+	tx := &api.PendingTransaction{
+		Hash:           common.HexToHash("0x01"),
+		Owner:          "api",
+		Nonce:          nonce,
+		From:           nodeAccount.Address,
+		To:             &to,
+		Value:          big.NewInt(5),
+		Data:           []byte{0xab, 0xcd},
+		GasLimit:       100000,
+		MaxFee:         eth.GweiToWei(maxFee),
+		MaxPriorityFee: eth.GweiToWei(maxPriorityFee),
+		FirstSubmitted: submitted,
+		LastSubmitted:  submitted,
+	}
+	if err := m.save(tx); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestRaiseByPercent(t *testing.T) {
+	tests := []struct {
+		fee      int64
+		percent  int64
+		expected int64
+	}{
+		{100, 20, 120},
+		{101, 20, 122}, // 121.2 rounds up
+		{1, 10, 2},     // 1.1 rounds up
+		{1000000000, 10, 1100000000},
+		{0, 20, 0},
+		{100, 0, 100},
+	}
+	for _, test := range tests {
+		raised := raiseByPercent(big.NewInt(test.fee), test.percent)
+		if raised.Cmp(big.NewInt(test.expected)) != 0 {
+			t.Errorf("raising %d by %d%%: expected %d, got %s", test.fee, test.percent, test.expected, raised.String())
+		}
+	}
+
+	// The fee passed in isn't modified
+	fee := big.NewInt(100)
+	raiseByPercent(fee, 20)
+	if fee.Int64() != 100 {
+		t.Errorf("expected the original fee to be unchanged, got %s", fee.String())
+	}
+}
+
+func TestGetBumpedFees(t *testing.T) {
+	tests := []struct {
+		name                   string
+		maxFee                 float64
+		maxPriorityFee         float64
+		maxFeeCap              float64
+		expectedMaxFee         float64
+		expectedMaxPriorityFee float64
+		expectedOk             bool
+	}{
+		{"under the cap", 10, 2, 100, 12, 2.4, true},
+		{"clamped to the cap", 10, 2, 11.5, 11.5, 2.4, true},
+		{"exactly the minimum raise", 10, 2, 11, 11, 2.4, true},
+		{"cap below the minimum raise", 10, 2, 10.9, 0, 0, false},
+		{"already over the cap", 150, 2, 100, 0, 0, false},
+		{"priority fee clamped to the max fee", 10, 10, 11, 11, 11, true},
+	}
+	for _, test := range tests {
+		maxFee, maxPriorityFee, ok := getBumpedFees(eth.GweiToWei(test.maxFee), eth.GweiToWei(test.maxPriorityFee), eth.GweiToWei(test.maxFeeCap))
+		if ok != test.expectedOk {
+			t.Errorf("%s: expected ok to be %t, got %t", test.name, test.expectedOk, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if maxFee.Cmp(eth.GweiToWei(test.expectedMaxFee)) != 0 {
+			t.Errorf("%s: expected a max fee of %.2f gwei, got %.2f", test.name, test.expectedMaxFee, eth.WeiToGwei(maxFee))
+		}
+		if maxPriorityFee.Cmp(eth.GweiToWei(test.expectedMaxPriorityFee)) != 0 {
+			t.Errorf("%s: expected a priority fee of %.2f gwei, got %.2f", test.name, test.expectedMaxPriorityFee, eth.WeiToGwei(maxPriorityFee))
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	ec := &fakeExecutionClient{}
+	m := newTestManager(t, ec)
+	logger := log.NewColorLogger(color.FgWhite)
+	tx := newTestTransaction(t, m, 7, 10, 2)
+	originalHash := tx.Hash
+
+	// The replacement is a copy of the transaction with higher fees, and replaces its record
+	if err := m.bump(tx, eth.GweiToWei(100), &logger); err != nil {
+		t.Fatal(err)
+	}
+	if len(ec.sent) != 1 {
+		t.Fatalf("expected 1 transaction to be sent, got %d", len(ec.sent))
+	}
+	sent := ec.sent[0]
+	if sent.Nonce() != 7 || sent.Gas() != 100000 || *sent.To() != *tx.To || sent.Value().Int64() != 5 || string(sent.Data()) != string(tx.Data) {
+		t.Errorf("expected the replacement to be a copy of the transaction, got %+v", sent)
+	}
+	if sent.GasFeeCap().Cmp(eth.GweiToWei(12)) != 0 || sent.GasTipCap().Cmp(eth.GweiToWei(2.4)) != 0 {
+		t.Errorf("expected fees of 12 and 2.4 gwei, got %.2f and %.2f", eth.WeiToGwei(sent.GasFeeCap()), eth.WeiToGwei(sent.GasTipCap()))
+	}
+	if tx.Hash != sent.Hash() || tx.Owner != "node" || len(tx.ReplacedHashes) != 1 || tx.ReplacedHashes[0] != originalHash {
+		t.Errorf("expected the record to point to the replacement, got hash %s, owner %s, replaced %v", tx.Hash.Hex(), tx.Owner, tx.ReplacedHashes)
+	}
+	saved, err := m.load(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved == nil || saved.Hash != sent.Hash() {
+		t.Fatalf("expected the replacement to be saved")
+	}
+
+	// Replacing it again keeps every earlier hash so any of them can be found
+	if err := m.bump(tx, eth.GweiToWei(100), &logger); err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.ReplacedHashes) != 2 || tx.ReplacedHashes[0] != originalHash || tx.ReplacedHashes[1] != sent.Hash() {
+		t.Errorf("expected both earlier hashes to be kept, got %v", tx.ReplacedHashes)
+	}
+	if tx.MaxFee.Cmp(eth.GweiToWei(14.4)) != 0 {
+		t.Errorf("expected the second replacement to raise the max fee to 14.4 gwei, got %.2f", eth.WeiToGwei(tx.MaxFee))
+	}
+
+	// A transaction that can't be raised under the cap isn't replaced, but waits for the next timeout
+	stuck := newTestTransaction(t, m, 8, 95, 2)
+	if err := m.bump(stuck, eth.GweiToWei(100), &logger); err != nil {
+		t.Fatal(err)
+	}
+	if len(ec.sent) != 2 {
+		t.Errorf("expected no replacement over the cap, got %d transactions", len(ec.sent))
+	}
+	if time.Since(stuck.LastSubmitted) > time.Minute || stuck.MaxFee.Cmp(eth.GweiToWei(95)) != 0 {
+		t.Errorf("expected only the last submitted time to change, got %s and %.2f gwei", stuck.LastSubmitted, eth.WeiToGwei(stuck.MaxFee))
+	}
+}
+
+func TestCancelTransaction(t *testing.T) {
+	ec := &fakeExecutionClient{
+		latestNonce:  7,
+		pendingNonce: 9,
+		baseFee:      eth.GweiToWei(1),
+		tip:          eth.GweiToWei(1),
+	}
+	m := newTestManager(t, ec)
+	nodeAccount, err := m.w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nonces that aren't pending can't be cancelled
+	for _, nonce := range []uint64{6, 9} {
+		if _, err := m.CancelTransaction(nonce, nil, nil); err == nil {
+			t.Errorf("expected an error cancelling nonce %d", nonce)
+		}
+	}
+
+	// The network's fees are used for transactions that aren't tracked
+	if _, err := m.CancelTransaction(8, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	cancellation := ec.sent[0]
+	if cancellation.Nonce() != 8 || *cancellation.To() != nodeAccount.Address || cancellation.Value().Sign() != 0 || cancellation.Gas() != transferGasLimit {
+		t.Errorf("expected an empty transfer to the node, got %+v", cancellation)
+	}
+	if cancellation.GasFeeCap().Cmp(eth.GweiToWei(3)) != 0 || cancellation.GasTipCap().Cmp(eth.GweiToWei(1)) != 0 {
+		t.Errorf("expected fees of 3 and 1 gwei, got %.2f and %.2f", eth.WeiToGwei(cancellation.GasFeeCap()), eth.WeiToGwei(cancellation.GasTipCap()))
+	}
+
+	// Tracked transactions need fees high enough to replace them, even when lower ones are given
+	tx := newTestTransaction(t, m, 7, 10, 2)
+	hash, err := m.CancelTransaction(7, eth.GweiToWei(5), eth.GweiToWei(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancellation = ec.sent[1]
+	if cancellation.GasFeeCap().Cmp(eth.GweiToWei(11.1)) != 0 || cancellation.GasTipCap().Cmp(eth.GweiToWei(5)) != 0 {
+		t.Errorf("expected fees of 11.1 and 5 gwei, got %.2f and %.2f", eth.WeiToGwei(cancellation.GasFeeCap()), eth.WeiToGwei(cancellation.GasTipCap()))
+	}
+	saved, err := m.load(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved == nil || saved.Hash != hash || len(saved.ReplacedHashes) != 1 || saved.ReplacedHashes[0] != tx.Hash {
+		t.Errorf("expected the cancellation to replace the tracked transaction's record, got %+v", saved)
+	}
+}
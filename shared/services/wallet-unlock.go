@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Serve the wallet unlock socket for a long-running process, so `rocketpool wallet unlock` can give it the node wallet password.
+// Does nothing if the password is saved to disk.
+func ServeWalletUnlock(c *cli.Context, process string, logger log.ColorLogger) error {
+
+	// Get services
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return err
+	}
+	pm, err := GetPasswordManager(c)
+	if err != nil {
+		return err
+	}
+	if pm.IsPasswordSaved() {
+		return nil
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+
+	// Remove the socket left behind by a previous run
+	socketPath := os.ExpandEnv(cfg.Smartnode.GetWalletUnlockSocketPath(process))
+	err = os.Remove(socketPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing old wallet unlock socket [%s]: %w", socketPath, err)
+	}
+
+	// Create the socket
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating wallet unlock socket [%s]: %w", socketPath, err)
+	}
+
+	// Give the socket to the owner of the data folder so the CLI can connect to it, and lock everyone else out
+	info, err := os.Stat(filepath.Dir(socketPath))
+	if err != nil {
+		listener.Close()
+		return fmt.Errorf("error getting info for the data folder: %w", err)
+	}
+	allowedUids := map[uint32]bool{
+		0:                   true,
+		uint32(os.Getuid()): true,
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		err = os.Chown(socketPath, int(stat.Uid), int(stat.Gid))
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("error setting owner of wallet unlock socket [%s]: %w", socketPath, err)
+		}
+		allowedUids[stat.Uid] = true
+	}
+	err = os.Chmod(socketPath, 0600)
+	if err != nil {
+		listener.Close()
+		return fmt.Errorf("error setting permissions of wallet unlock socket [%s]: %w", socketPath, err)
+	}
+
+	// Only accept connections from the users that own the socket
+	authListener := &peerUidListener{
+		Listener:    listener,
+		allowedUids: allowedUids,
+		log:         &logger,
+	}
+
+	// Handle unlock requests
+	mux := http.NewServeMux()
+	mux.HandleFunc(apitypes.WalletUnlockPath, func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(rw, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		response := apitypes.UnlockWalletResponse{
+			Status: "success",
+		}
+		var request apitypes.UnlockWalletRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err == nil {
+			err = pm.Unlock(request.Password, w.VerifyPassword)
+		}
+		if err != nil {
+			logger.Printlnf("Couldn't unlock the node wallet: %s", err.Error())
+			response.Status = "error"
+			response.Error = err.Error()
+		} else {
+			logger.Println("The node wallet has been unlocked.")
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(rw).Encode(response)
+		if err != nil {
+			logger.Printlnf("Error writing wallet unlock response: %s", err.Error())
+		}
+	})
+
+	logger.Printlnf("The node wallet password isn't saved to disk, listening for `rocketpool wallet unlock` on %s.", socketPath)
+	go func() {
+		err := http.Serve(authListener, mux)
+		if err != nil && !errors.Is(err, net.ErrClosed) {
+			logger.Printlnf("Error running wallet unlock server: %s", err.Error())
+		}
+	}()
+
+	return nil
+
+}
+
+// A listener that drops connections from users that aren't allowed to unlock the wallet
+type peerUidListener struct {
+	net.Listener
+	allowedUids map[uint32]bool
+	log         *log.ColorLogger
+}
+
+func (l *peerUidListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		uid, err := getPeerUid(conn)
+		if err != nil {
+			l.log.Printlnf("Rejecting wallet unlock connection: %s", err.Error())
+			conn.Close()
+			continue
+		}
+		if !l.allowedUids[uid] {
+			l.log.Printlnf("Rejecting wallet unlock connection from user %d", uid)
+			conn.Close()
+			continue
+		}
+		return conn, nil
+	}
+}
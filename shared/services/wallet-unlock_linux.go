@@ -0,0 +1,35 @@
+//go:build linux
+// +build linux
+
+package services
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// Get the ID of the user on the other end of a unix socket connection
+func getPeerUid(conn net.Conn) (uint32, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, fmt.Errorf("connection is not a unix socket connection")
+	}
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return 0, fmt.Errorf("error getting raw connection: %w", err)
+	}
+
+	var cred *syscall.Ucred
+	var credErr error
+	err = rawConn.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error accessing connection: %w", err)
+	}
+	if credErr != nil {
+		return 0, fmt.Errorf("error getting peer credentials: %w", credErr)
+	}
+	return cred.Uid, nil
+}
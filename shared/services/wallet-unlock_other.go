@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package services
+
+import (
+	"errors"
+	"net"
+)
+
+// Get the ID of the user on the other end of a unix socket connection
+func getPeerUid(conn net.Conn) (uint32, error) {
+	return 0, errors.New("checking the user of a socket connection is only supported on Linux")
+}
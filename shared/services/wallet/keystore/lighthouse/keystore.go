@@ -180,3 +180,33 @@ func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.B
 	return privateKey, nil
 
 }
+
+// Get the pubkeys of the validator keys in the keystore
+func (ks *Keystore) GetValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+
+	// Each key has its own folder, named after its pubkey
+	validatorsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir)
+	entries, err := os.ReadDir(validatorsPath)
+	if os.IsNotExist(err) {
+		return []types.ValidatorPubkey{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't read the Lighthouse validators folder: %w", err)
+	}
+
+	pubkeys := []types.ValidatorPubkey{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(entry.Name()))
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(validatorsPath, entry.Name(), KeyFileName)); err != nil {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+
+}
@@ -0,0 +1,136 @@
+package lighthouse
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"gopkg.in/yaml.v2"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	DefinitionsFileName  = "validator_definitions.yml"
+	Web3SignerType       = "web3signer"
+	definitionsPubkeyKey = "voting_public_key"
+)
+
+// A keystore for validator keys held by a Web3Signer-compatible remote signer.
+// Lighthouse can't be pointed at a remote signer with command line flags, so this adds a web3signer entry for each key
+// to the validator definitions file it reads at startup instead of saving the key itself.
+type RemoteKeystore struct {
+	keystorePath string
+	signerUrl    string
+}
+
+// Create new lighthouse remote signer keystore
+func NewRemoteKeystore(keystorePath string, signerUrl string) *RemoteKeystore {
+	return &RemoteKeystore{
+		keystorePath: keystorePath,
+		signerUrl:    strings.TrimSuffix(signerUrl, "/"),
+	}
+}
+
+// Get the keystore directory
+func (ks *RemoteKeystore) GetKeystoreDir() string {
+	return filepath.Join(ks.keystorePath, KeystoreDir)
+}
+
+// Store a validator key by adding a remote signer definition for it; the key itself is imported into the signer by its own keystore
+func (ks *RemoteKeystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := hexutil.AddPrefix(types.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex())
+
+	// Load the existing definitions
+	definitionsPath := ks.getDefinitionsPath()
+	definitions, bytes, err := ks.loadDefinitions()
+	if err != nil {
+		return err
+	}
+
+	// Skip keys that are already defined
+	for _, definition := range definitions {
+		if strings.EqualFold(fmt.Sprint(definition[definitionsPubkeyKey]), pubkey) {
+			return nil
+		}
+	}
+
+	// Append a definition that points to the remote signer.
+	// Lighthouse writes its own entries to this file too, so they're left exactly as they are; the values are quoted
+	// because Lighthouse would read an unquoted pubkey as a hex number.
+	if len(definitions) == 0 {
+		bytes = []byte("---\n")
+	} else if len(bytes) > 0 && bytes[len(bytes)-1] != '\n' {
+		bytes = append(bytes, '\n')
+	}
+	bytes = append(bytes, fmt.Sprintf("- enabled: true\n  %s: %q\n  type: %s\n  url: %q\n", definitionsPubkeyKey, pubkey, Web3SignerType, ks.signerUrl)...)
+
+	// Create validators dir
+	if err := os.MkdirAll(filepath.Dir(definitionsPath), DirMode); err != nil {
+		return fmt.Errorf("Could not create validator key folder: %w", err)
+	}
+
+	// Write the definitions to a temporary file first so Lighthouse never reads a partial one
+	tempPath := definitionsPath + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write the Lighthouse validator definitions to disk: %w", err)
+	}
+	if err := os.Rename(tempPath, definitionsPath); err != nil {
+		return fmt.Errorf("Could not write the Lighthouse validator definitions to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the pubkeys of the validator keys in the remote signer that Lighthouse has definitions for
+func (ks *RemoteKeystore) GetValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+	definitions, _, err := ks.loadDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys := []types.ValidatorPubkey{}
+	for _, definition := range definitions {
+		if definition["type"] != Web3SignerType {
+			continue
+		}
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(fmt.Sprint(definition[definitionsPubkeyKey])))
+		if err != nil {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Get the path of the validator definitions file
+func (ks *RemoteKeystore) getDefinitionsPath() string {
+	return filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, DefinitionsFileName)
+}
+
+// Load the validator definitions, along with the raw file so it can be appended to
+func (ks *RemoteKeystore) loadDefinitions() ([]map[string]interface{}, []byte, error) {
+	definitions := []map[string]interface{}{}
+	bytes, err := os.ReadFile(ks.getDefinitionsPath())
+	if os.IsNotExist(err) {
+		return definitions, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("Could not read the Lighthouse validator definitions: %w", err)
+	}
+	if err := yaml.Unmarshal(bytes, &definitions); err != nil {
+		return nil, nil, fmt.Errorf("Could not parse the Lighthouse validator definitions: %w", err)
+	}
+	return definitions, bytes, nil
+}
+
+// Load a private key; the remote signer never releases its keys, so this always comes back empty
+func (ks *RemoteKeystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
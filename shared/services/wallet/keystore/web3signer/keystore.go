@@ -0,0 +1,122 @@
+package web3signer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+)
+
+// Config
+const (
+	SignPathFormat = "/api/v1/eth2/sign/%s"
+	RequestTimeout = 30 * time.Second
+)
+
+// A keystore that imports validator keys into a Web3Signer-compatible remote signer through its keymanager API, instead of saving them to disk
+type Keystore struct {
+	url        string
+	token      string
+	keymanager *keymanager.Client
+	httpClient *http.Client
+}
+
+// Create new remote signer keystore
+func NewKeystore(url string, token string) *Keystore {
+	return &Keystore{
+		url:        strings.TrimSuffix(url, "/"),
+		token:      token,
+		keymanager: keymanager.NewClient(url, token),
+		httpClient: &http.Client{
+			Timeout: RequestTimeout,
+		},
+	}
+}
+
+// Get the keystore directory; keys aren't stored on this machine, so there isn't one
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Store a validator key by importing it into the remote signer
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	err := ks.keymanager.ImportKeys([]*eth2types.BLSPrivateKey{key}, []string{derivationPath})
+	if err != nil {
+		pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+		return fmt.Errorf("Could not import validator key %s into the remote signer: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Load a private key; the remote signer never releases its keys, so this always comes back empty
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
+
+// Get the pubkeys of the validator keys in the remote signer
+func (ks *Keystore) GetValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+	pubkeys, err := ks.keymanager.ListKeys()
+	if err != nil {
+		return nil, fmt.Errorf("Could not list the keys in the remote signer: %w", err)
+	}
+	return pubkeys, nil
+}
+
+// Get a signer for messages from the validator with the given pubkey, which must already be in the remote signer
+func (ks *Keystore) GetSigner(pubkey types.ValidatorPubkey, eth2Config beacon.Eth2Config) *Signer {
+	return &Signer{
+		ks:         ks,
+		pubkey:     pubkey,
+		eth2Config: eth2Config,
+	}
+}
+
+// Send a POST request to the remote signer and decode its JSON response
+func (ks *Keystore) post(path string, request interface{}, response interface{}) error {
+
+	// Encode the request
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("error serializing request: %w", err)
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, ks.url+path, bytes.NewReader(requestBytes))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+	if ks.token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+ks.token)
+	}
+
+	// Send it
+	httpResponse, err := ks.httpClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("error sending request to %s: %w", path, err)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response from %s: %w", path, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s: %s", path, httpResponse.Status, strings.TrimSpace(string(responseBytes)))
+	}
+
+	// Decode the response
+	err = json.Unmarshal(responseBytes, response)
+	if err != nil {
+		return fmt.Errorf("error deserializing response from %s: %w", path, err)
+	}
+	return nil
+
+}
@@ -0,0 +1,98 @@
+package web3signer
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Signs messages with a validator key held by the remote signer
+type Signer struct {
+	ks         *Keystore
+	pubkey     types.ValidatorPubkey
+	eth2Config beacon.Eth2Config
+}
+
+// The fork a message is signed for
+type fork struct {
+	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version"`
+	Epoch           string `json:"epoch"`
+}
+
+// The fork and chain a message is signed for
+type forkInfo struct {
+	Fork                  fork   `json:"fork"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
+
+// A voluntary exit in the signing API's format
+type voluntaryExit struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+// A request to sign a message
+type signRequest struct {
+	Type          string         `json:"type"`
+	ForkInfo      forkInfo       `json:"fork_info"`
+	SigningRoot   string         `json:"signingRoot"`
+	VoluntaryExit *voluntaryExit `json:"voluntary_exit,omitempty"`
+}
+
+// The response to a signing request
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+func (s *Signer) GetPubkey() types.ValidatorPubkey {
+	return s.pubkey
+}
+
+// Sign a voluntary exit with the remote signer
+func (s *Signer) SignVoluntaryExit(exit eth2.VoluntaryExit, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	// Per EIP-7044, exits are always signed with the Capella fork version so the signer has to derive the same domain
+	capellaVersion := hexutil.AddPrefix(hex.EncodeToString(s.eth2Config.CapellaForkVersion))
+	return s.sign(signRequest{
+		Type: "VOLUNTARY_EXIT",
+		ForkInfo: forkInfo{
+			Fork: fork{
+				PreviousVersion: capellaVersion,
+				CurrentVersion:  capellaVersion,
+				Epoch:           "0",
+			},
+			GenesisValidatorsRoot: hexutil.AddPrefix(hex.EncodeToString(s.eth2Config.GenesisValidatorsRoot)),
+		},
+		SigningRoot: hexutil.AddPrefix(hex.EncodeToString(signingRoot[:])),
+		VoluntaryExit: &voluntaryExit{
+			Epoch:          strconv.FormatUint(exit.Epoch, 10),
+			ValidatorIndex: strconv.FormatUint(exit.ValidatorIndex, 10),
+		},
+	})
+}
+
+// Withdrawal credentials changes are signed with the withdrawal key, which never goes into the remote signer
+func (s *Signer) SignWithdrawalCredsChange(change eth2.WithdrawalCredentialsChange, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	return types.ValidatorSignature{}, errors.New("the remote signer only holds validator keys, so it can't sign withdrawal credentials changes; they must be signed with the withdrawal key derived from your mnemonic")
+}
+
+// Send a signing request for the signer's pubkey
+func (s *Signer) sign(request signRequest) (types.ValidatorSignature, error) {
+	var response signResponse
+	err := s.ks.post(fmt.Sprintf(SignPathFormat, hexutil.AddPrefix(s.pubkey.Hex())), request, &response)
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("error signing %s for validator %s: %w", request.Type, s.pubkey.Hex(), err)
+	}
+	signature, err := types.HexToValidatorSignature(hexutil.RemovePrefix(response.Signature))
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("error decoding %s signature for validator %s: %w", request.Type, s.pubkey.Hex(), err)
+	}
+	return signature, nil
+}
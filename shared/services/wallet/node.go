@@ -9,12 +9,22 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// The context key for the sender of transactions built in unsigned mode
+type unsignedSenderKey struct{}
+
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+	// Use the provided node address in unsigned mode
+	if w.IsAddressOnly() {
+		return accounts.Account{Address: *w.unsignedNodeAddress}, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
@@ -47,6 +57,11 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
+	// Build unsigned transactions without the node key in unsigned mode
+	if w.unsigned {
+		return w.getUnsignedTransactor()
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
@@ -68,6 +83,45 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
 }
 
+// Get a transactor that leaves transactions unsigned, so they can be exported and signed offline.
+// Its context carries the sender, since it can't be recovered from an unsigned transaction.
+func (w *Wallet) getUnsignedTransactor() (*bind.TransactOpts, error) {
+
+	// Get the sender
+	var sender common.Address
+	if w.unsignedSender != nil {
+		sender = *w.unsignedSender
+	} else {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		sender = nodeAccount.Address
+	}
+
+	// Create & return transactor
+	return &bind.TransactOpts{
+		From: sender,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != sender {
+				return nil, bind.ErrNotAuthorized
+			}
+			return tx, nil
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.WithValue(context.Background(), unsignedSenderKey{}, sender),
+	}, nil
+
+}
+
+// Get the sender of an unsigned transaction from the context of the transactor that built it
+func GetUnsignedSender(ctx context.Context) (common.Address, bool) {
+	sender, ok := ctx.Value(unsignedSenderKey{}).(common.Address)
+	return sender, ok
+}
+
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
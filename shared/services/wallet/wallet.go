@@ -10,6 +10,7 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/goccy/go-json"
@@ -61,6 +62,11 @@ type Wallet struct {
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Unsigned mode, where transactions are built for offline signing without the node key
+	unsigned            bool
+	unsignedNodeAddress *common.Address
+	unsignedSender      *common.Address
 }
 
 // Encrypted wallet store
@@ -106,11 +112,37 @@ func (w *Wallet) GetChainID() *big.Int {
 	return copy
 }
 
+// Set the desired gas price & limit used by the node account transactor
+func (w *Wallet) SetGasSettings(maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64) {
+	w.maxFee = maxFee
+	w.maxPriorityFee = maxPriorityFee
+	w.gasLimit = gasLimit
+}
+
+// Build transactions for offline signing instead of signing them with the node key.
+// The node address comes from the wallet unless nodeAddress is set, so the wallet doesn't need to be on this machine;
+// transactions are sent from the node address unless sender is set (e.g. to the node's withdrawal address).
+func (w *Wallet) SetUnsignedMode(unsigned bool, nodeAddress *common.Address, sender *common.Address) {
+	w.unsigned = unsigned
+	w.unsignedNodeAddress = nodeAddress
+	w.unsignedSender = sender
+}
+
+// Check if the wallet builds transactions for offline signing and knows the node address without being initialized
+func (w *Wallet) IsAddressOnly() bool {
+	return w.unsigned && w.unsignedNodeAddress != nil
+}
+
 // Add a keystore to the wallet
 func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
 }
 
+// Get one of the wallet's keystores by name, or nil if it doesn't have one with that name
+func (w *Wallet) GetKeystore(name string) keystore.Keystore {
+	return w.keystores[name]
+}
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
@@ -323,6 +355,29 @@ func (w *Wallet) Reload() error {
 	return err
 }
 
+// Check that a password can decrypt the wallet store on disk
+func (w *Wallet) VerifyPassword(password string) error {
+
+	// Read wallet store from disk
+	wsBytes, err := os.ReadFile(w.walletPath)
+	if err != nil {
+		return fmt.Errorf("Could not read wallet: %w", err)
+	}
+
+	// Decode wallet store
+	ws := new(walletStore)
+	if err = json.Unmarshal(wsBytes, ws); err != nil {
+		return fmt.Errorf("Could not decode wallet: %w", err)
+	}
+
+	// Decrypt seed
+	if _, err = w.encryptor.Decrypt(ws.Crypto, password); err != nil {
+		return errors.New("Incorrect wallet password")
+	}
+	return nil
+
+}
+
 // Load the wallet store from disk and decrypt it
 func (w *Wallet) loadStore() (bool, error) {
 
@@ -343,8 +398,11 @@ func (w *Wallet) loadStore() (bool, error) {
 		w.ws.DerivationPath = DefaultNodeKeyPath
 	}
 
-	// Get wallet password
+	// Get wallet password; the wallet can't be loaded until it's unlocked
 	password, err := w.pm.GetPassword()
+	if errors.Is(err, passwords.ErrWalletLocked) {
+		return false, nil
+	}
 	if err != nil {
 		return false, fmt.Errorf("Could not get wallet password: %w", err)
 	}
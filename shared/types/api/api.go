@@ -1,6 +1,132 @@
 package api
 
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
 type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// The HTTP path that the API server accepts calls on
+const APIServerCallPath string = "/call"
+
+// The HTTP path that long-running processes accept wallet unlock requests on
+const WalletUnlockPath string = "/unlock"
+
+// A call to the API server, holding the global flags and the arguments of an API command
+type APIServerRequest struct {
+	MaxFee          float64  `json:"maxFee"`
+	MaxPrioFee      float64  `json:"maxPrioFee"`
+	GasLimit        uint64   `json:"gasLimit"`
+	Nonce           string   `json:"nonce,omitempty"`
+	IgnoreSyncCheck bool     `json:"ignoreSyncCheck"`
+	ForceFallbacks  bool     `json:"forceFallbacks"`
+	DryRun          bool     `json:"dryRun,omitempty"`
+	Unsigned        bool     `json:"unsigned,omitempty"`
+	UnsignedNode    string   `json:"unsignedNode,omitempty"`
+	UnsignedFrom    string   `json:"unsignedFrom,omitempty"`
+	Args            []string `json:"args"`
+}
+
+// The status of an API response for a transaction that was simulated instead of submitted
+const DryRunStatus string = "dry-run"
+
+// The result of simulating a transaction at the pending block instead of submitting it
+type TransactionSimulation struct {
+	From           common.Address           `json:"from"`
+	To             *common.Address          `json:"to,omitempty"`
+	ToLabel        string                   `json:"toLabel,omitempty"`
+	Method         string                   `json:"method,omitempty"`
+	Value          *big.Int                 `json:"value"`
+	GasLimit       uint64                   `json:"gasLimit"`
+	Reverted       bool                     `json:"reverted"`
+	RevertReason   string                   `json:"revertReason,omitempty"`
+	ReturnData     hexutil.Bytes            `json:"returnData,omitempty"`
+	ReturnValues   []string                 `json:"returnValues,omitempty"`
+	TraceError     string                   `json:"traceError,omitempty"`
+	BalanceChanges []SimulatedBalanceChange `json:"balanceChanges"`
+	Events         []SimulatedEvent         `json:"events"`
+}
+type SimulatedBalanceChange struct {
+	Address common.Address `json:"address"`
+	Label   string         `json:"label,omitempty"`
+	Before  *big.Int       `json:"before"`
+	After   *big.Int       `json:"after"`
+}
+type SimulatedEvent struct {
+	Address   common.Address    `json:"address"`
+	Contract  string            `json:"contract,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+	Topics    []common.Hash     `json:"topics"`
+	Data      hexutil.Bytes     `json:"data"`
+}
+type DryRunResponse struct {
+	Status     string                `json:"status"`
+	Error      string                `json:"error"`
+	Simulation TransactionSimulation `json:"simulation"`
+}
+
+// Returned instead of submitting a transaction when the API is running in dry-run mode
+type DryRunError struct {
+	Simulation TransactionSimulation
+}
+
+func (e *DryRunError) Error() string {
+	if e.Simulation.Reverted {
+		return fmt.Sprintf("dry run: the transaction would revert (%s)", e.Simulation.RevertReason)
+	}
+	return "dry run: the transaction was simulated and not submitted"
+}
+
+// The status of an API response for a transaction that was exported unsigned instead of submitted
+const UnsignedTransactionStatus string = "unsigned"
+
+// The version of the offline transaction file format
+const OfflineTransactionVersion int = 1
+
+// An unsigned EIP-1559 transaction that was exported to be signed on an offline machine.
+// The payload is the canonical encoding of the transaction; the other fields are only there so it can be reviewed.
+type UnsignedTransaction struct {
+	Version        int             `json:"version"`
+	ChainID        *big.Int        `json:"chainId"`
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to,omitempty"`
+	Nonce          uint64          `json:"nonce"`
+	Value          *big.Int        `json:"value"`
+	Data           hexutil.Bytes   `json:"data"`
+	GasLimit       uint64          `json:"gasLimit"`
+	MaxFee         *big.Int        `json:"maxFeePerGas"`
+	MaxPriorityFee *big.Int        `json:"maxPriorityFeePerGas"`
+	Payload        hexutil.Bytes   `json:"payload"`
+}
+type UnsignedTransactionResponse struct {
+	Status      string              `json:"status"`
+	Error       string              `json:"error"`
+	Transaction UnsignedTransaction `json:"transaction"`
+}
+
+// A transaction that was signed on an offline machine and is ready to be broadcast
+type SignedTransaction struct {
+	Version int            `json:"version"`
+	ChainID *big.Int       `json:"chainId"`
+	From    common.Address `json:"from"`
+	Nonce   uint64         `json:"nonce"`
+	Hash    common.Hash    `json:"hash"`
+	Payload hexutil.Bytes  `json:"payload"`
+}
+
+// Returned instead of submitting a transaction when the API is exporting unsigned transactions
+type UnsignedTransactionError struct {
+	Transaction UnsignedTransaction
+}
+
+func (e *UnsignedTransactionError) Error() string {
+	return "the transaction was exported unsigned and not submitted"
+}
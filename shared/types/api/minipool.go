@@ -101,6 +101,32 @@ type ExitMinipoolResponse struct {
 	Error  string `json:"error"`
 }
 
+// A signed voluntary exit in the format used by the Beacon API
+type SignedVoluntaryExit struct {
+	Message struct {
+		Epoch          string `json:"epoch"`
+		ValidatorIndex string `json:"validator_index"`
+	} `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// A signed voluntary exit for a minipool's validator, along with the details needed to verify it
+type SignedMinipoolExit struct {
+	Minipool common.Address        `json:"minipool"`
+	Pubkey   types.ValidatorPubkey `json:"pubkey"`
+	Domain   string                `json:"domain"`
+	Exit     SignedVoluntaryExit   `json:"exit"`
+}
+type GetSignedExitsResponse struct {
+	Status string               `json:"status"`
+	Error  string               `json:"error"`
+	Exits  []SignedMinipoolExit `json:"exits"`
+}
+type BroadcastExitResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
 type CanChangeWithdrawalCredentialsResponse struct {
 	Status    string `json:"status"`
 	Error     string `json:"error"`
@@ -112,8 +138,9 @@ type ChangeWithdrawalCredentialsResponse struct {
 }
 
 type ImportKeyResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status          string `json:"status"`
+	Error           string `json:"error"`
+	ValidatorLoaded bool   `json:"validatorLoaded"`
 }
 
 type CanProcessWithdrawalResponse struct {
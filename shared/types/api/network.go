@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 )
 
 type NodeFeeResponse struct {
@@ -66,6 +67,27 @@ type NetworkGenerateRewardsTreeResponse struct {
 	Error  string `json:"error"`
 }
 
+type CanNetworkVerifyRewardsTreeResponse struct {
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	CurrentIndex   uint64 `json:"currentIndex"`
+	TreeFileExists bool   `json:"treeFileExists"`
+	ReportExists   bool   `json:"reportExists"`
+}
+
+type NetworkVerifyRewardsTreeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type NetworkRewardsTreeVerificationResponse struct {
+	Status       string                                 `json:"status"`
+	Error        string                                 `json:"error"`
+	ReportExists bool                                   `json:"reportExists"`
+	ReportPath   string                                 `json:"reportPath"`
+	Report       *rewards.RewardsTreeVerificationReport `json:"report"`
+}
+
 type SnapshotResponseStruct struct {
 	Error                   string                 `json:"error"`
 	ProposalVotes           []SnapshotProposalVote `json:"proposalVotes"`
@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/history"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -660,3 +663,98 @@ type NodeAlertsResponse struct {
 	// TODO: change to GettableAlerts
 	Message string `json:"message"`
 }
+
+// A transaction sent by the node that hasn't been included in a block yet
+type PendingTransaction struct {
+	Hash           common.Hash     `json:"hash"`
+	ReplacedHashes []common.Hash   `json:"replacedHashes,omitempty"`
+	Owner          string          `json:"owner"`
+	Nonce          uint64          `json:"nonce"`
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to,omitempty"`
+	Value          *big.Int        `json:"value"`
+	Data           hexutil.Bytes   `json:"data"`
+	GasLimit       uint64          `json:"gasLimit"`
+	MaxFee         *big.Int        `json:"maxFee"`
+	MaxPriorityFee *big.Int        `json:"maxPriorityFee"`
+	FirstSubmitted time.Time       `json:"firstSubmitted"`
+	LastSubmitted  time.Time       `json:"lastSubmitted"`
+}
+type PendingTransactionsResponse struct {
+	Status       string               `json:"status"`
+	Error        string               `json:"error"`
+	LatestNonce  uint64               `json:"latestNonce"`
+	PendingNonce uint64               `json:"pendingNonce"`
+	Transactions []PendingTransaction `json:"transactions"`
+}
+type CancelTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type BroadcastTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+// The kinds of node income that can be exported
+const (
+	IncomeType_RewardsRpl           string = "rewards-rpl"
+	IncomeType_SmoothingPoolEth     string = "smoothing-pool-eth"
+	IncomeType_MinipoolDistribution string = "minipool-distribution"
+	IncomeType_FeeDistributor       string = "fee-distributor"
+)
+
+// A single ETH or RPL inflow to the node
+type NodeIncomeEvent struct {
+	Type      string         `json:"type"`
+	Source    common.Address `json:"source"`
+	Interval  *uint64        `json:"interval,omitempty"`
+	Block     uint64         `json:"block"`
+	Timestamp time.Time      `json:"timestamp"`
+	TxHash    common.Hash    `json:"txHash"`
+	Token     string         `json:"token"`
+	Amount    *big.Int       `json:"amount"`
+	RplPrice  *big.Int       `json:"rplPrice"`
+	EthValue  *big.Int       `json:"ethValue"`
+	Note      string         `json:"note,omitempty"`
+}
+type ExportIncomeResponse struct {
+	Status    string            `json:"status"`
+	Error     string            `json:"error"`
+	FromBlock uint64            `json:"fromBlock"`
+	ToBlock   uint64            `json:"toBlock"`
+	Events    []NodeIncomeEvent `json:"events"`
+	Warnings  []string          `json:"warnings"`
+}
+
+type NodeHistoryResponse struct {
+	Status        string           `json:"status"`
+	Error         string           `json:"error"`
+	RetentionDays uint64           `json:"retentionDays"`
+	Samples       []history.Sample `json:"samples"`
+}
+
+type NodeDashboardResponse struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error"`
+	Enabled  bool           `json:"enabled"`
+	Port     uint16         `json:"port"`
+	PortMode config.RPCMode `json:"portMode"`
+	Token    string         `json:"token"`
+}
+
+type ResetDashboardTokenResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Token  string `json:"token"`
+}
+
+type NodeMinipoolPubkeysResponse struct {
+	Status  string                    `json:"status"`
+	Error   string                    `json:"error"`
+	Exists  bool                      `json:"exists"`
+	Pubkeys []rptypes.ValidatorPubkey `json:"pubkeys"`
+}
@@ -1,6 +1,12 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -20,6 +26,7 @@ type ClientStatus struct {
 	IsSynced     bool    `json:"isSynced"`
 	SyncProgress float64 `json:"syncProgress"`
 	NetworkId    uint    `json:"networkId"`
+	PeerCount    *uint64 `json:"peerCount,omitempty"`
 	Error        string  `json:"error"`
 }
 
@@ -41,3 +48,65 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type SetGraffitiResponse struct {
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	Graffiti       string `json:"graffiti"`
+	ValidatorCount int    `json:"validatorCount"`
+}
+
+type ServiceDoctorResponse struct {
+	Status            string                   `json:"status"`
+	Error             string                   `json:"error"`
+	EcManagerStatus   ClientManagerStatus      `json:"ecManagerStatus"`
+	BcManagerStatus   ClientManagerStatus      `json:"bcManagerStatus"`
+	Clock             DoctorClockStatus        `json:"clock"`
+	WalletInitialized bool                     `json:"walletInitialized"`
+	WalletLocked      bool                     `json:"walletLocked"`
+	AccountAddress    common.Address           `json:"accountAddress"`
+	Registered        bool                     `json:"registered"`
+	ChainError        string                   `json:"chainError"`
+	FeeRecipient      DoctorFeeRecipientStatus `json:"feeRecipient"`
+	ValidatorKeys     DoctorValidatorKeyStatus `json:"validatorKeys"`
+	Gas               DoctorGasStatus          `json:"gas"`
+}
+
+// The daemon's clock compared with an NTP server's, and with the Beacon chain's slot clock.
+// TimeServerOffset is in seconds, and is positive if the daemon's clock is behind.
+type DoctorClockStatus struct {
+	SystemTime       time.Time `json:"systemTime"`
+	TimeServer       string    `json:"timeServer"`
+	TimeServerOffset float64   `json:"timeServerOffset"`
+	TimeServerError  string    `json:"timeServerError"`
+	GenesisTime      uint64    `json:"genesisTime"`
+	SecondsPerSlot   uint64    `json:"secondsPerSlot"`
+	HeadSlot         uint64    `json:"headSlot"`
+	Error            string    `json:"error"`
+}
+
+// The fee recipient file compared with the one the node's smoothing pool state requires
+type DoctorFeeRecipientStatus struct {
+	IsInSmoothingPool  bool           `json:"isInSmoothingPool"`
+	IsInOptOutCooldown bool           `json:"isInOptOutCooldown"`
+	ExpectedRecipient  common.Address `json:"expectedRecipient"`
+	FileExists         bool           `json:"fileExists"`
+	FileCorrect        bool           `json:"fileCorrect"`
+	Error              string         `json:"error"`
+}
+
+// The keys available to the validator client compared with the node's validating minipools.
+// Source describes where they were found, e.g. the remote signer or the selected client's keystore.
+type DoctorValidatorKeyStatus struct {
+	MinipoolCount int                     `json:"minipoolCount"`
+	MissingKeys   []types.ValidatorPubkey `json:"missingKeys"`
+	Source        string                  `json:"source"`
+	Error         string                  `json:"error"`
+}
+
+// The node wallet's balance compared with the cost of transactions
+type DoctorGasStatus struct {
+	NodeBalance *big.Int `json:"nodeBalance"`
+	BaseFee     *big.Int `json:"baseFee"`
+	Error       string   `json:"error"`
+}
@@ -21,6 +21,7 @@ type WalletStatusResponse struct {
 	Status            string         `json:"status"`
 	Error             string         `json:"error"`
 	PasswordSet       bool           `json:"passwordSet"`
+	WalletLocked      bool           `json:"walletLocked"`
 	WalletInitialized bool           `json:"walletInitialized"`
 	AccountAddress    common.Address `json:"accountAddress"`
 }
@@ -30,6 +31,16 @@ type SetPasswordResponse struct {
 	Error  string `json:"error"`
 }
 
+// A request to unlock the node wallet of a long-running process
+type UnlockWalletRequest struct {
+	Password string `json:"password"`
+}
+
+type UnlockWalletResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
 type InitWalletResponse struct {
 	Status         string         `json:"status"`
 	Error          string         `json:"error"`
@@ -55,9 +66,17 @@ type SearchAndRecoverWalletResponse struct {
 }
 
 type RebuildWalletResponse struct {
-	Status        string                  `json:"status"`
-	Error         string                  `json:"error"`
-	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+	Status           string                  `json:"status"`
+	Error            string                  `json:"error"`
+	ValidatorKeys    []types.ValidatorPubkey `json:"validatorKeys"`
+	ValidatorsLoaded bool                    `json:"validatorsLoaded"`
+}
+
+type DeleteValidatorKeysResponse struct {
+	Status                 string                  `json:"status"`
+	Error                  string                  `json:"error"`
+	ValidatorKeys          []types.ValidatorPubkey `json:"validatorKeys"`
+	SlashingProtectionFile string                  `json:"slashingProtectionFile"`
 }
 
 type ExportWalletResponse struct {
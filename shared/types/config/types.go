@@ -11,6 +11,9 @@ type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
 type PBSubmissionRef int
+type AlertSeverity string
+type ChatWebhookFormat string
+type PushService string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -113,6 +116,25 @@ const (
 	NimbusPruningMode_Prune   NimbusPruningMode = "prune"
 )
 
+// Enum to describe the severity of a Smartnode alert
+const (
+	AlertSeverity_Info     AlertSeverity = "info"
+	AlertSeverity_Warning  AlertSeverity = "warning"
+	AlertSeverity_Critical AlertSeverity = "critical"
+)
+
+// Enum to describe the payload format of a chat webhook
+const (
+	ChatWebhookFormat_Discord ChatWebhookFormat = "discord"
+	ChatWebhookFormat_Slack   ChatWebhookFormat = "slack"
+)
+
+// Enum to describe the push notification service
+const (
+	PushService_Ntfy   PushService = "ntfy"
+	PushService_Gotify PushService = "gotify"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
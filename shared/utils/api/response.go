@@ -3,7 +3,9 @@ package api
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"os"
 	"reflect"
 
 	"github.com/goccy/go-json"
@@ -11,6 +13,15 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// The writer that API responses are printed to
+var responseOutput io.Writer = os.Stdout
+
+// Set the writer that API responses are printed to
+// The API server uses this to capture the response of each call instead of printing it to stdout
+func SetResponseOutput(w io.Writer) {
+	responseOutput = w
+}
+
 func ZeroIfNil(in **big.Int) {
 	if *in == nil {
 		*in = big.NewInt(0)
@@ -21,6 +32,20 @@ func ZeroIfNil(in **big.Int) {
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
 
+	// Transactions simulated in dry-run mode or exported unsigned come back as errors, so print them instead
+	status := ""
+	var dryRunErr *api.DryRunError
+	var unsignedErr *api.UnsignedTransactionError
+	if errors.As(responseError, &dryRunErr) {
+		response = &api.DryRunResponse{Simulation: dryRunErr.Simulation}
+		responseError = nil
+		status = api.DryRunStatus
+	} else if errors.As(responseError, &unsignedErr) {
+		response = &api.UnsignedTransactionResponse{Transaction: unsignedErr.Transaction}
+		responseError = nil
+		status = api.UnsignedTransactionStatus
+	}
+
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
@@ -48,7 +73,9 @@ func PrintResponse(response interface{}, responseError error) {
 	}
 
 	// Set status
-	if ef.String() == "" {
+	if status != "" {
+		sf.SetString(status)
+	} else if ef.String() == "" {
 		sf.SetString("success")
 	} else {
 		sf.SetString("error")
@@ -62,7 +89,7 @@ func PrintResponse(response interface{}, responseError error) {
 	}
 
 	// Print
-	fmt.Println(string(responseBytes))
+	fmt.Fprintln(responseOutput, string(responseBytes))
 
 }
 
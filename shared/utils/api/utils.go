@@ -14,6 +14,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/txmanager"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -85,6 +86,28 @@ func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash,
 
 }
 
+// Print a TX's details to the logger and wait for it to be validated, replacing it with higher fees if it gets stuck.
+func PrintAndWaitForManagedTransaction(cfg *config.RocketPoolConfig, hash common.Hash, txm *txmanager.Manager, logger *log.ColorLogger) error {
+
+	txWatchUrl := cfg.Smartnode.GetTxWatchUrl()
+	hashString := hash.String()
+
+	logger.Printlnf("Transaction has been submitted with hash %s.", hashString)
+	if txWatchUrl != "" {
+		logger.Printlnf("You may follow its progress by visiting:")
+		logger.Printlnf("%s/%s\n", txWatchUrl, hashString)
+	}
+	logger.Println("Waiting for the transaction to be validated...")
+
+	// Wait for the TX to be included in a block
+	if _, err := txm.WaitForTransaction(hash, logger); err != nil {
+		return fmt.Errorf("Error waiting for transaction: %w", err)
+	}
+
+	return nil
+
+}
+
 // True if a transaction is due and needs to bypass the gas threshold
 func IsTransactionDue(rp *rocketpool.RocketPool, startTime time.Time) (bool, time.Duration, error) {
 
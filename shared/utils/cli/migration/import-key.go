@@ -33,13 +33,19 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 
 	// Import the key
 	fmt.Printf("Importing validator key... ")
-	_, err := rp.ImportKey(minipoolAddress, mnemonic)
+	response, err := rp.ImportKey(minipoolAddress, mnemonic)
 	if err != nil {
 		fmt.Printf("error importing validator key: %s\n", err.Error())
 		return false
 	}
 	fmt.Println("done!")
 
+	// The VC already has the key if it was loaded through its keymanager API
+	if response.ValidatorLoaded {
+		fmt.Println("The key has been loaded into the Smartnode's Validator Client without restarting it.")
+		return true
+	}
+
 	// Restart the VC if necessary
 	if c.Bool("no-restart") {
 		return true
@@ -0,0 +1,348 @@
+// Package output implements the CLI's global --output flag.
+//
+// In the table format (the default), commands print their usual human-readable text.
+// In the json and yaml formats that text is suppressed, interactive prompts are disabled,
+// and each command prints exactly one document when it finishes instead. Its schema is:
+//
+//	version    the schema version, currently 1
+//	command    the command that was run, e.g. "node status"
+//	status     "success" or "error"
+//	error      the error message if the command failed
+//	data       values the command computed from the API responses; these depend on the command and may be empty
+//	responses  every API call the command made, in order, as {call, response} where call is the API command
+//	           (e.g. "node status") and response is its response type from shared/types/api
+//
+// Big integers (e.g. wei amounts) are JSON numbers; in YAML, integers too big for 64 bits are quoted strings.
+// A command that would need to ask a question fails instead, so pass its flags (such as --yes) to answer them.
+//
+// Commands that don't compute anything beyond their API responses have an empty data object. The others set:
+//
+//	node status
+//	  network                         the network the node is configured for, e.g. "mainnet"
+//	  activeMinipools                 the number of minipools that haven't been finalised
+//	  undercollateralized             true if the node has borrowed ETH but less than its minimum RPL stake
+//	  primaryWithdrawalAddressIsNode  true if the primary withdrawal address is still the node address
+//	  activeAlerts                    the number of Alertmanager alerts that are firing
+//	  houstonHotfixDeployed           true if the Houston 1.3.1 hotfix is active
+//	  remaining8EthMinipools          before the hotfix, how many more 8-ETH minipools the node's RPL allows
+//	  remaining16EthMinipools         before the hotfix, how many more 16-ETH minipools the node's RPL allows
+//	minipool status
+//	  statusCounts                    the number of unfinalised minipools in each status, keyed by status name
+//	  finalisedCount                  the number of finalised minipools
+//	  refundableMinipools             the addresses of the minipools with a refund available
+//	  closeableMinipools              the addresses of the minipools that can be closed
+//	node history                      samples: the samples in the requested range, from shared/services/history
+//	node dashboard                    url: the web dashboard's address
+//	service version                   network, clientVersion, serviceVersion, nativeMode, executionClient, consensusClient, mevBoost
+//	service doctor                    checks: each check's name, result ("pass", "warn", "fail" or "skip"), message and fix
+//	service backup                    file: the path of the backup that was written
+//	service restore                   manifest: the manifest of the backup that was restored
+//	service config apply              valid, errors, and the changes and containers to restart
+//	service config history            snapshots: the saved config snapshots
+//	service config diff / rollback    changes and containers: the settings that differ and the containers they affect
+//	fleet list / status / exec        nodes: one entry per node in the fleet
+//	fleet minipool status / version   nodes: one entry per node in the fleet
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// An output format
+type Format string
+
+const (
+	Format_Table Format = "table"
+	Format_Json  Format = "json"
+	Format_Yaml  Format = "yaml"
+)
+
+// The version of the document schema; it changes whenever a field is removed or changes meaning
+const SchemaVersion int = 1
+
+// Document statuses
+const (
+	Status_Success string = "success"
+	Status_Error   string = "error"
+)
+
+// An API call made by a command
+type APICall struct {
+	Call     string          `json:"call"`
+	Response json.RawMessage `json:"response"`
+}
+
+// The document printed by a command in the json and yaml formats
+type Document struct {
+	Version   int            `json:"version"`
+	Command   string         `json:"command"`
+	Status    string         `json:"status"`
+	Error     string         `json:"error,omitempty"`
+	Data      map[string]any `json:"data"`
+	Responses []APICall      `json:"responses"`
+}
+
+var (
+	lock     sync.Mutex
+	format   Format = Format_Table
+	stdout   *os.File
+	finished bool
+	document = Document{
+		Version:   SchemaVersion,
+		Data:      map[string]any{},
+		Responses: []APICall{},
+	}
+)
+
+// Matches terminal color codes, which are removed from error messages
+var colorCodeRegex = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// Parse an output format
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case Format_Table, "":
+		return Format_Table, nil
+	case Format_Json:
+		return Format_Json, nil
+	case Format_Yaml:
+		return Format_Yaml, nil
+	}
+	return "", fmt.Errorf("Invalid output format '%s': it must be 'table', 'json' or 'yaml'", value)
+}
+
+// Set the output format for the command being run.
+// In the structured formats, anything the command prints to stdout is discarded until Finish is called.
+func Init(value string, command string) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	parsedFormat, err := ParseFormat(value)
+	if err != nil {
+		return err
+	}
+	format = parsedFormat
+	document.Command = command
+	if format == Format_Table {
+		return nil
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", os.DevNull, err)
+	}
+	stdout = os.Stdout
+	os.Stdout = devNull
+	return nil
+}
+
+// Check if the command should print a json or yaml document instead of text
+func IsStructured() bool {
+	lock.Lock()
+	defer lock.Unlock()
+	return format != Format_Table
+}
+
+// Get the output format
+func GetFormat() Format {
+	lock.Lock()
+	defer lock.Unlock()
+	return format
+}
+
+// Record the response of an API call
+func RecordResponse(call string, response []byte) {
+	lock.Lock()
+	defer lock.Unlock()
+	if format == Format_Table {
+		return
+	}
+
+	// Responses are JSON, but keep anything else as a string so the document stays valid
+	raw := json.RawMessage(bytes.TrimSpace(response))
+	if !json.Valid(raw) {
+		raw, _ = json.Marshal(string(response))
+	}
+	document.Responses = append(document.Responses, APICall{
+		Call:     call,
+		Response: raw,
+	})
+}
+
+// Set a computed value in the document's data
+func Set(key string, value any) {
+	lock.Lock()
+	defer lock.Unlock()
+	if format == Format_Table {
+		return
+	}
+	document.Data[key] = value
+}
+
+// Print the document for the command that was run, with the error it failed with if any.
+// This does nothing in the table format.
+func Finish(err error) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if format == Format_Table || finished {
+		return nil
+	}
+	finished = true
+
+	// Restore stdout
+	_ = os.Stdout.Close()
+	os.Stdout = stdout
+
+	// Build the document
+	document.Status = Status_Success
+	if err != nil {
+		document.Status = Status_Error
+		document.Error = colorCodeRegex.ReplaceAllString(err.Error(), "")
+	}
+	bytes, serializeErr := json.MarshalIndent(document, "", "  ")
+	if serializeErr != nil {
+		return fmt.Errorf("error serializing command output: %w", serializeErr)
+	}
+	if format == Format_Yaml {
+		bytes, serializeErr = jsonToYaml(bytes)
+		if serializeErr != nil {
+			return fmt.Errorf("error serializing command output: %w", serializeErr)
+		}
+	}
+	if len(bytes) == 0 || bytes[len(bytes)-1] != '\n' {
+		bytes = append(bytes, '\n')
+	}
+	_, writeErr := stdout.Write(bytes)
+	return writeErr
+}
+
+// Print the document and exit; used by code paths that end the command early
+func Exit(err error) {
+	if finishErr := Finish(err); finishErr != nil {
+		fmt.Fprintln(os.Stderr, finishErr.Error())
+	}
+	os.Exit(ExitCode(err))
+}
+
+// Get the code to exit with after a command returns; commands can return a cli.ExitCoder to pick their own
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitCoder cli.ExitCoder
+	if errors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
+	}
+	return 1
+}
+
+// Get an error for a prompt that can't be shown because the output is structured
+func PromptError(prompt string) error {
+	prompt = strings.TrimSpace(colorCodeRegex.ReplaceAllString(prompt, ""))
+	if lines := strings.Split(prompt, "\n"); len(lines) > 0 {
+		prompt = lines[len(lines)-1]
+	}
+	return fmt.Errorf("this command needs to ask \"%s\", but prompts are disabled with --output %s; provide the answer with the command's flags (e.g. --yes) instead", prompt, GetFormat())
+}
+
+// Get the full name of the command the CLI args will run (e.g. "node status"), following aliases
+func CommandName(app *cli.App, args []string) string {
+	names := []string{}
+	commands := app.Commands
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		var match *cli.Command
+		for i := range commands {
+			if commands[i].HasName(arg) {
+				match = &commands[i]
+				break
+			}
+		}
+		if match == nil {
+			break
+		}
+		names = append(names, match.Name)
+		commands = match.Subcommands
+	}
+	return strings.Join(names, " ")
+}
+
+// Convert a JSON document to YAML, keeping the order of its fields and the precision of its numbers
+func jsonToYaml(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(value)
+}
+
+// Decode the next JSON value into a YAML-friendly value
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		switch token {
+		case '{':
+			object := yaml.MapSlice{}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeOrdered(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object = append(object, yaml.MapItem{Key: keyToken, Value: value})
+			}
+			_, err = decoder.Token()
+			return object, err
+		case '[':
+			array := []any{}
+			for decoder.More() {
+				value, err := decodeOrdered(decoder)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			}
+			_, err = decoder.Token()
+			return array, err
+		}
+		return nil, fmt.Errorf("unexpected delimiter %s", token)
+
+	case json.Number:
+		if value, err := strconv.ParseInt(string(token), 10, 64); err == nil {
+			return value, nil
+		}
+		if value, err := strconv.ParseUint(string(token), 10, 64); err == nil {
+			return value, nil
+		}
+		if strings.ContainsAny(string(token), ".eE") {
+			if value, err := strconv.ParseFloat(string(token), 64); err == nil {
+				return value, nil
+			}
+		}
+		return string(token), nil
+	}
+
+	return token, nil
+}
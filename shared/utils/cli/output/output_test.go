@@ -0,0 +1,195 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+)
+
+// Run a command in the given output format, returning what it printed to stdout
+func runCommand(t *testing.T, value string, command func()) string {
+	t.Helper()
+
+	// Start from a fresh document
+	format = Format_Table
+	finished = false
+	document = Document{
+		Version:   SchemaVersion,
+		Data:      map[string]any{},
+		Responses: []APICall{},
+	}
+
+	originalStdout := os.Stdout
+	defer func() {
+		os.Stdout = originalStdout
+	}()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	os.Stdout = writer
+
+	if err := Init(value, "node status"); err != nil {
+		t.Fatal(err)
+	}
+	command()
+	writer.Close()
+	printed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(printed)
+}
+
+func TestDocument(t *testing.T) {
+	printed := runCommand(t, "json", func() {
+		fmt.Println("Some human-readable text")
+		RecordResponse("node status", []byte(`{"status":"success","rplStake":1000000000000000000000000000}`+"\n"))
+		RecordResponse("node sync", []byte("not json"))
+		Set("activeMinipools", 2)
+		if err := Finish(errors.New("\033[31mSomething went wrong\033[0m")); err != nil {
+			t.Fatal(err)
+		}
+		if err := Finish(nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Only the document is printed, once
+	var doc struct {
+		Version   int            `json:"version"`
+		Command   string         `json:"command"`
+		Status    string         `json:"status"`
+		Error     string         `json:"error"`
+		Data      map[string]int `json:"data"`
+		Responses []struct {
+			Call     string          `json:"call"`
+			Response json.RawMessage `json:"response"`
+		} `json:"responses"`
+	}
+	if err := json.Unmarshal([]byte(printed), &doc); err != nil {
+		t.Fatalf("expected a single JSON document, got %q: %s", printed, err.Error())
+	}
+	if doc.Version != SchemaVersion || doc.Command != "node status" || doc.Status != Status_Error {
+		t.Errorf("unexpected header: version %d, command %q, status %q", doc.Version, doc.Command, doc.Status)
+	}
+	if doc.Error != "Something went wrong" {
+		t.Errorf("expected the error without its color codes, got %q", doc.Error)
+	}
+	if doc.Data["activeMinipools"] != 2 {
+		t.Errorf("expected the data to be set, got %v", doc.Data)
+	}
+
+	// Responses are kept as they were, and anything that isn't JSON becomes a string
+	if len(doc.Responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(doc.Responses))
+	}
+	response := &bytes.Buffer{}
+	if err := json.Compact(response, doc.Responses[0].Response); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Responses[0].Call != "node status" || response.String() != `{"status":"success","rplStake":1000000000000000000000000000}` {
+		t.Errorf("unexpected first response: %s %s", doc.Responses[0].Call, response.String())
+	}
+	if string(doc.Responses[1].Response) != `"not json"` {
+		t.Errorf("expected an invalid response to be a string, got %s", doc.Responses[1].Response)
+	}
+}
+
+func TestYamlDocument(t *testing.T) {
+	printed := runCommand(t, "YAML", func() {
+		RecordResponse("node status", []byte(`{"status":"success"}`))
+		if err := Finish(nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	expected := strings.Join([]string{
+		"version: 1",
+		"command: node status",
+		"status: success",
+		"data: {}",
+		"responses:",
+		"- call: node status",
+		"  response:",
+		"    status: success",
+		"",
+	}, "\n")
+	if printed != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, printed)
+	}
+}
+
+func TestTableFormat(t *testing.T) {
+	printed := runCommand(t, "", func() {
+		fmt.Println("Some human-readable text")
+		RecordResponse("node status", []byte(`{"status":"success"}`))
+		Set("activeMinipools", 2)
+		if err := Finish(nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if printed != "Some human-readable text\n" {
+		t.Errorf("expected only the command's text, got %q", printed)
+	}
+	if len(document.Responses) != 0 || len(document.Data) != 0 {
+		t.Errorf("expected nothing to be recorded, got %d responses and %d values", len(document.Responses), len(document.Data))
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if format, err := ParseFormat("Json"); err != nil || format != Format_Json {
+		t.Errorf("expected json, got %q (%v)", format, err)
+	}
+}
+
+func TestJsonToYaml(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{"field order", `{"z":1,"a":{"y":true,"b":null}}`, "z: 1\na:\n  \"y\": true\n  b: null\n"},
+		{"arrays", `{"list":[1,"two",[3]],"empty":[]}`, "list:\n- 1\n- two\n- - 3\nempty: []\n"},
+		{"64-bit integers", `{"max":18446744073709551615,"min":-9223372036854775808}`, "max: 18446744073709551615\nmin: -9223372036854775808\n"},
+		{"big integers", `{"wei":1000000000000000000000000000}`, "wei: \"1000000000000000000000000000\"\n"},
+		{"floats", `{"ratio":0.5,"exponent":1e-7}`, "ratio: 0.5\nexponent: 1e-07\n"},
+		{"strings", `{"address":"0x0123","number":"12"}`, "address: \"0x0123\"\nnumber: \"12\"\n"},
+	}
+	for _, test := range tests {
+		yaml, err := jsonToYaml([]byte(test.json))
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err.Error())
+			continue
+		}
+		if string(yaml) != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, string(yaml))
+		}
+	}
+
+	if _, err := jsonToYaml([]byte(`{"unterminated":`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if code := ExitCode(nil); code != 0 {
+		t.Errorf("expected 0 without an error, got %d", code)
+	}
+	if code := ExitCode(errors.New("failed")); code != 1 {
+		t.Errorf("expected 1 for an error, got %d", code)
+	}
+	if code := ExitCode(fmt.Errorf("wrapped: %w", cli.NewExitError("drift", 2))); code != 2 {
+		t.Errorf("expected the exit error's code, got %d", code)
+	}
+}
@@ -3,20 +3,33 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 // Prompt for user input
 func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
+	return PromptFrom(os.Stdin, initialPrompt, expectedFormat, incorrectFormatPrompt)
+}
+
+// Prompt for user input, reading it from the given input instead of stdin
+func PromptFrom(input io.Reader, initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
+
+	// Prompts can't be answered when the output is machine-readable
+	if output.IsStructured() {
+		output.Exit(output.PromptError(initialPrompt))
+	}
 
 	// Print initial prompt
 	fmt.Println(initialPrompt)
 
 	// Get valid user input
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(input)
 	for scanner.Scan(); !regexp.MustCompile(expectedFormat).MatchString(scanner.Text()); scanner.Scan() {
 		fmt.Println("")
 		fmt.Println(incorrectFormatPrompt)
@@ -30,7 +43,12 @@ func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt s
 
 // Prompt for confirmation
 func Confirm(initialPrompt string) bool {
-	response := Prompt(fmt.Sprintf("%s [y/n]", initialPrompt), "(?i)^(y|yes|n|no)$", "Please answer 'y' or 'n'")
+	return ConfirmFrom(os.Stdin, initialPrompt)
+}
+
+// Prompt for confirmation, reading the answer from the given input instead of stdin
+func ConfirmFrom(input io.Reader, initialPrompt string) bool {
+	response := PromptFrom(input, fmt.Sprintf("%s [y/n]", initialPrompt), "(?i)^(y|yes|n|no)$", "Please answer 'y' or 'n'")
 	return (strings.ToLower(response[:1]) == "y")
 }
 
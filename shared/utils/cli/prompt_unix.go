@@ -9,11 +9,18 @@ import (
 	"syscall"
 
 	"golang.org/x/term"
+
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 // Prompt for password input
 func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
 
+	// Prompts can't be answered when the output is machine-readable
+	if output.IsStructured() {
+		output.Exit(output.PromptError(initialPrompt))
+	}
+
 	// Print initial prompt
 	fmt.Println(initialPrompt)
 
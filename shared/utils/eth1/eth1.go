@@ -39,8 +39,9 @@ func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 			return fmt.Errorf("Could not get next available nonce: %w", err)
 		}
 
+		// Exported transactions that follow one another get the nonces after the next available one, since none have been submitted yet
 		nextNonce := big.NewInt(0).SetUint64(nextNonceUint)
-		if customNonce.Cmp(nextNonce) == 1 {
+		if customNonce.Cmp(nextNonce) == 1 && !c.GlobalBool("unsigned") {
 			return fmt.Errorf("Can't use nonce %s because it's greater than the next available nonce (%d).", customNonceString, nextNonceUint)
 		}
 
@@ -0,0 +1,90 @@
+package net
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Config
+const (
+	DefaultNtpServer string = "pool.ntp.org"
+	ntpPort          string = "123"
+	ntpPacketSize    int    = 48
+	ntpEpochOffset   int64  = 2208988800 // Seconds between the NTP epoch (1900) and the Unix epoch (1970)
+)
+
+// Get the offset of the local clock from an NTP server's, which is positive if the local clock is behind.
+// This is a single SNTP query, which is accurate to within half of the round trip time.
+func GetNtpOffset(server string, timeout time.Duration) (time.Duration, error) {
+
+	conn, err := net.DialTimeout("udp", DefaultPort(server, ntpPort), timeout)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to %s: %w", server, err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, fmt.Errorf("error setting deadline: %w", err)
+	}
+
+	// Send a client request (version 3, mode 3) with the send time as its transmit timestamp
+	request := make([]byte, ntpPacketSize)
+	request[0] = 0x1B
+	sendTime := time.Now()
+	binary.BigEndian.PutUint64(request[40:], toNtpTime(sendTime))
+	if _, err := conn.Write(request); err != nil {
+		return 0, fmt.Errorf("error sending request to %s: %w", server, err)
+	}
+
+	// Read the response
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	receiveTime := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("error reading response from %s: %w", server, err)
+	}
+	return parseNtpResponse(response[:n], request[40:48], sendTime, receiveTime)
+
+}
+
+// Get the clock offset from an NTP response to a request with the given transmit timestamp
+func parseNtpResponse(response []byte, originTimestamp []byte, sendTime time.Time, receiveTime time.Time) (time.Duration, error) {
+
+	if len(response) < ntpPacketSize {
+		return 0, fmt.Errorf("response is %d bytes, but it should be at least %d", len(response), ntpPacketSize)
+	}
+	leap := response[0] >> 6
+	mode := response[0] & 0x07
+	stratum := response[1]
+	if mode != 4 {
+		return 0, fmt.Errorf("response has mode %d instead of 4 (server)", mode)
+	}
+	if leap == 3 || stratum == 0 {
+		return 0, fmt.Errorf("server isn't synchronized")
+	}
+	if string(response[24:32]) != string(originTimestamp) {
+		return 0, fmt.Errorf("response isn't for this request")
+	}
+
+	// The offset is the average of the differences between the server and local times in each direction
+	serverReceiveTime := fromNtpTime(binary.BigEndian.Uint64(response[32:40]))
+	serverTransmitTime := fromNtpTime(binary.BigEndian.Uint64(response[40:48]))
+	offset := (serverReceiveTime.Sub(sendTime) + serverTransmitTime.Sub(receiveTime)) / 2
+	return offset, nil
+
+}
+
+// Convert a time to a 64-bit NTP timestamp, with 32 bits of seconds and 32 bits of fractional seconds
+func toNtpTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+// Convert a 64-bit NTP timestamp to a time
+func fromNtpTime(timestamp uint64) time.Time {
+	seconds := int64(timestamp>>32) - ntpEpochOffset
+	nanoseconds := (int64(timestamp&0xFFFFFFFF) * int64(time.Second)) >> 32
+	return time.Unix(seconds, nanoseconds)
+}
@@ -0,0 +1,75 @@
+package net
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// Run an NTP server on localhost whose clock is offset from the local one, returning its address
+func startNtpServer(t *testing.T, offset time.Duration, stratum byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		request := make([]byte, ntpPacketSize)
+		for {
+			_, addr, err := conn.ReadFrom(request)
+			if err != nil {
+				return
+			}
+			response := make([]byte, ntpPacketSize)
+			response[0] = 0x1C // Version 3, mode 4 (server)
+			response[1] = stratum
+			copy(response[24:32], request[40:48])
+			binary.BigEndian.PutUint64(response[32:40], toNtpTime(time.Now().Add(offset)))
+			binary.BigEndian.PutUint64(response[40:48], toNtpTime(time.Now().Add(offset)))
+			conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestNtpTimeRoundTrip(t *testing.T) {
+	now := time.Unix(1700000000, 123456789)
+	converted := fromNtpTime(toNtpTime(now))
+	if diff := converted.Sub(now); diff < -time.Nanosecond || diff > time.Nanosecond {
+		t.Fatalf("expected %s, got %s", now, converted)
+	}
+}
+
+func TestGetNtpOffset(t *testing.T) {
+	for _, expected := range []time.Duration{0, 300 * time.Millisecond, -2 * time.Second} {
+		server := startNtpServer(t, expected, 2)
+		offset, err := GetNtpOffset(server, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := offset - expected; diff < -50*time.Millisecond || diff > 50*time.Millisecond {
+			t.Errorf("expected an offset of about %s, got %s", expected, offset)
+		}
+	}
+}
+
+func TestGetNtpOffsetFromUnsynchronizedServer(t *testing.T) {
+	server := startNtpServer(t, 0, 0)
+	if _, err := GetNtpOffset(server, time.Second); err == nil {
+		t.Fatal("expected an error for a server with stratum 0")
+	}
+}
+
+func TestParseNtpResponseForAnotherRequest(t *testing.T) {
+	response := make([]byte, ntpPacketSize)
+	response[0] = 0x1C
+	response[1] = 2
+	binary.BigEndian.PutUint64(response[24:32], 1)
+	origin := make([]byte, 8)
+	binary.BigEndian.PutUint64(origin, 2)
+	if _, err := parseNtpResponse(response, origin, time.Now(), time.Now()); err == nil {
+		t.Fatal("expected an error for a response to a different request")
+	}
+}
@@ -0,0 +1,212 @@
+package rp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ConfigHistoryFolder  string = "config-history"
+	configSnapshotFormat string = "%06d.yml"
+	configHistoryLimit   int    = 100
+	configSummaryNames   int    = 3
+)
+
+// A saved version of the settings file
+type ConfigSnapshot struct {
+	ID       uint64                       `yaml:"id" json:"id"`
+	Time     time.Time                    `yaml:"time" json:"time"`
+	Version  string                       `yaml:"version" json:"version"`
+	Summary  string                       `yaml:"summary" json:"summary"`
+	Settings map[string]map[string]string `yaml:"settings" json:"-"`
+}
+
+// Get the config that a snapshot holds
+func (s *ConfigSnapshot) GetConfig() (*config.RocketPoolConfig, error) {
+	rootParams := s.Settings["root"]
+	isNative, err := strconv.ParseBool(rootParams["isNative"])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing isNative in config snapshot %d: %w", s.ID, err)
+	}
+	cfg := config.NewRocketPoolConfig(rootParams["rpDir"], isNative)
+	err = cfg.Deserialize(s.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("error loading config snapshot %d: %w", s.ID, err)
+	}
+	return cfg, nil
+}
+
+// Load every snapshot in the config history, oldest first.
+// Snapshots that can't be read are skipped so one bad file doesn't break the history; the errors for them are returned separately.
+func LoadConfigHistory(configDir string) ([]ConfigSnapshot, []error, error) {
+	paths, err := filepath.Glob(filepath.Join(configDir, ConfigHistoryFolder, "*.yml"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing config history: %w", err)
+	}
+
+	snapshots := []ConfigSnapshot{}
+	skipped := []error{}
+	for _, path := range paths {
+		snapshot, err := loadConfigSnapshotFile(path)
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].ID < snapshots[j].ID
+	})
+	return snapshots, skipped, nil
+}
+
+// Load a snapshot from the config history
+func LoadConfigSnapshot(configDir string, id uint64) (*ConfigSnapshot, error) {
+	path := filepath.Join(configDir, ConfigHistoryFolder, fmt.Sprintf(configSnapshotFormat, id))
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("config snapshot %d does not exist", id)
+	}
+	return loadConfigSnapshotFile(path)
+}
+
+// Record a newly saved config in the history, summarizing how it differs from the one it replaced.
+// Saves that don't change anything aren't recorded.
+func AddConfigSnapshot(configDir string, oldCfg *config.RocketPoolConfig, cfg *config.RocketPoolConfig) error {
+
+	historyFolder := filepath.Join(configDir, ConfigHistoryFolder)
+	err := os.MkdirAll(historyFolder, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating config history folder: %w", err)
+	}
+
+	// Get the next ID from the file names, so snapshots that can't be read are never overwritten
+	ids, err := getConfigSnapshotIDs(historyFolder)
+	if err != nil {
+		return err
+	}
+	var nextID uint64 = 1
+	if len(ids) > 0 {
+		nextID = ids[len(ids)-1] + 1
+	}
+
+	// Record the config from before there was a history, so the first change can be rolled back
+	if len(ids) == 0 && oldCfg != nil {
+		err = writeConfigSnapshot(historyFolder, nextID, oldCfg, "Configuration before history was recorded")
+		if err != nil {
+			return err
+		}
+		nextID++
+	}
+
+	// Summarize the changes
+	summary := "Initial configuration"
+	if oldCfg != nil {
+		summary = SummarizeConfigChanges(oldCfg, cfg)
+		if summary == "" {
+			return nil
+		}
+	}
+	err = writeConfigSnapshot(historyFolder, nextID, cfg, summary)
+	if err != nil {
+		return err
+	}
+
+	// Remove the oldest snapshots
+	ids, err = getConfigSnapshotIDs(historyFolder)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(ids)-configHistoryLimit; i++ {
+		err = os.Remove(filepath.Join(historyFolder, fmt.Sprintf(configSnapshotFormat, ids[i])))
+		if err != nil {
+			return fmt.Errorf("error removing config snapshot %d: %w", ids[i], err)
+		}
+	}
+	return nil
+
+}
+
+// Describe the settings that changed between two configs in one line, or return an empty string if nothing changed
+func SummarizeConfigChanges(oldCfg *config.RocketPoolConfig, cfg *config.RocketPoolConfig) string {
+	changedSettings, _, _ := cfg.GetChanges(oldCfg)
+	names := []string{}
+	for _, settings := range changedSettings {
+		for _, setting := range settings {
+			names = append(names, setting.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	summary := fmt.Sprintf("Changed %d setting(s): ", len(names))
+	if len(names) > configSummaryNames {
+		return summary + fmt.Sprintf("%s (+%d more)", strings.Join(names[:configSummaryNames], ", "), len(names)-configSummaryNames)
+	}
+	return summary + strings.Join(names, ", ")
+}
+
+// Save a config to the history
+func writeConfigSnapshot(historyFolder string, id uint64, cfg *config.RocketPoolConfig, summary string) error {
+	settings := cfg.Serialize()
+	snapshot := ConfigSnapshot{
+		ID:       id,
+		Time:     time.Now(),
+		Version:  settings["root"]["version"],
+		Summary:  summary,
+		Settings: settings,
+	}
+	bytes, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("could not serialize config snapshot: %w", err)
+	}
+	path := filepath.Join(historyFolder, fmt.Sprintf(configSnapshotFormat, id))
+	err = os.WriteFile(path, bytes, 0664)
+	if err != nil {
+		return fmt.Errorf("error saving config snapshot to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the IDs of the snapshot files in the history folder in ascending order, whether or not they can be read
+func getConfigSnapshotIDs(historyFolder string) ([]uint64, error) {
+	paths, err := filepath.Glob(filepath.Join(historyFolder, "*.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing config history: %w", err)
+	}
+	ids := []uint64{}
+	for _, path := range paths {
+		id, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(path), ".yml"), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids, nil
+}
+
+// Load a snapshot file
+func loadConfigSnapshotFile(path string) (*ConfigSnapshot, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config snapshot %s: %w", path, err)
+	}
+	var snapshot ConfigSnapshot
+	err = yaml.Unmarshal(bytes, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing config snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,124 @@
+package rp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Create a config with the given manual max fee; the paths in it are all under "/rocketpool"
+func newTestConfig(maxFee float64) *config.RocketPoolConfig {
+	cfg := config.NewRocketPoolConfig("/rocketpool", false)
+	cfg.Smartnode.ManualMaxFee.Value = maxFee
+	return cfg
+}
+
+func TestSummarizeConfigChanges(t *testing.T) {
+	oldCfg := newTestConfig(0)
+	if summary := SummarizeConfigChanges(oldCfg, oldCfg.CreateCopy()); summary != "" {
+		t.Errorf("expected no summary for identical configs, got %q", summary)
+	}
+
+	cfg := oldCfg.CreateCopy()
+	cfg.Smartnode.ManualMaxFee.Value = float64(20)
+	cfg.Smartnode.PriorityFee.Value = float64(3)
+	expected := "Changed 2 setting(s): Manual Max Fee, Priority Fee"
+	if summary := SummarizeConfigChanges(oldCfg, cfg); summary != expected {
+		t.Errorf("expected %q, got %q", expected, summary)
+	}
+
+	// Only the first few names are listed
+	cfg.Smartnode.EnableDashboard.Value = !oldCfg.Smartnode.EnableDashboard.Value.(bool)
+	cfg.Smartnode.DashboardPort.Value = uint16(1234)
+	expected = "Changed 4 setting(s): Enable Web Dashboard, Manual Max Fee, Priority Fee (+1 more)"
+	if summary := SummarizeConfigChanges(oldCfg, cfg); summary != expected {
+		t.Errorf("expected %q, got %q", expected, summary)
+	}
+}
+
+func TestAddConfigSnapshot(t *testing.T) {
+	configDir := t.TempDir()
+
+	// The first save of an existing config also records the config it replaced
+	oldCfg := newTestConfig(0)
+	if err := AddConfigSnapshot(configDir, oldCfg, newTestConfig(1)); err != nil {
+		t.Fatal(err)
+	}
+	snapshots, skipped, err := LoadConfigHistory(configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || len(skipped) != 0 {
+		t.Fatalf("expected 2 snapshots, got %d (%d skipped)", len(snapshots), len(skipped))
+	}
+	if snapshots[0].ID != 1 || snapshots[1].ID != 2 || snapshots[1].Summary != "Changed 1 setting(s): Manual Max Fee" {
+		t.Errorf("unexpected snapshots: %d %q, %d %q", snapshots[0].ID, snapshots[0].Summary, snapshots[1].ID, snapshots[1].Summary)
+	}
+	cfg, err := snapshots[1].GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Smartnode.ManualMaxFee.Value != float64(1) {
+		t.Errorf("expected the snapshot to hold the saved config, got a max fee of %v", cfg.Smartnode.ManualMaxFee.Value)
+	}
+
+	// Saves that don't change anything aren't recorded
+	if err := AddConfigSnapshot(configDir, newTestConfig(1), newTestConfig(1)); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, _, _ := LoadConfigHistory(configDir); len(snapshots) != 2 {
+		t.Errorf("expected an unchanged save to be skipped, got %d snapshots", len(snapshots))
+	}
+}
+
+func TestAddConfigSnapshotPrunesOldest(t *testing.T) {
+	configDir := t.TempDir()
+	saves := configHistoryLimit + 5
+	for i := 1; i <= saves; i++ {
+		if err := AddConfigSnapshot(configDir, newTestConfig(float64(i-1)), newTestConfig(float64(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The pre-history snapshot plus one per save were written, and only the newest are kept
+	snapshots, _, err := LoadConfigHistory(configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != configHistoryLimit {
+		t.Fatalf("expected %d snapshots, got %d", configHistoryLimit, len(snapshots))
+	}
+	firstID := uint64(saves + 1 - configHistoryLimit + 1)
+	if snapshots[0].ID != firstID || snapshots[len(snapshots)-1].ID != uint64(saves+1) {
+		t.Errorf("expected snapshots %d to %d, got %d to %d", firstID, saves+1, snapshots[0].ID, snapshots[len(snapshots)-1].ID)
+	}
+}
+
+func TestConfigHistorySkipsBadSnapshots(t *testing.T) {
+	configDir := t.TempDir()
+	if err := AddConfigSnapshot(configDir, nil, newTestConfig(1)); err != nil {
+		t.Fatal(err)
+	}
+	badPath := filepath.Join(configDir, ConfigHistoryFolder, fmt.Sprintf(configSnapshotFormat, 2))
+	if err := os.WriteFile(badPath, []byte("settings: [not, a, map"), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving still works, and doesn't overwrite the bad snapshot
+	if err := AddConfigSnapshot(configDir, newTestConfig(1), newTestConfig(2)); err != nil {
+		t.Fatalf("expected a bad snapshot not to break saving, got %s", err.Error())
+	}
+	snapshots, skipped, err := LoadConfigHistory(configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || len(skipped) != 1 {
+		t.Fatalf("expected 2 snapshots and 1 skipped, got %d and %d", len(snapshots), len(skipped))
+	}
+	if snapshots[1].ID != 3 {
+		t.Errorf("expected the new snapshot to be 3, got %d", snapshots[1].ID)
+	}
+}
@@ -21,6 +21,14 @@ type FeeRecipientInfo struct {
 	OptOutEpoch           uint64         `json:"optOutEpoch"`
 }
 
+// Get the fee recipient the node's validators should currently use
+func (info *FeeRecipientInfo) GetCorrectFeeRecipient() common.Address {
+	if info.IsInSmoothingPool || info.IsInOptOutCooldown {
+		return info.SmoothingPoolAddress
+	}
+	return info.FeeDistributorAddress
+}
+
 func GetFeeRecipientInfo(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, state *state.NetworkState) (*FeeRecipientInfo, error) {
 
 	info := &FeeRecipientInfo{
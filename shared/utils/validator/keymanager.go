@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Load validator keys into the running VC through its keymanager API and give them the fee recipient the node should currently use.
+// Falls back to restarting the VC so it loads them and the fee recipient file from disk if km is nil or the API can't be used.
+func LoadValidatorKeys(cfg *config.RocketPoolConfig, km *keymanager.Client, pubkeys []types.ValidatorPubkey, loadKey func(types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error), feeRecipient common.Address, bc beacon.Client, log *log.ColorLogger, d *client.Client) error {
+
+	if km != nil {
+		err := ImportValidatorKeys(cfg, km, pubkeys, loadKey, feeRecipient)
+		if err == nil {
+			if log != nil {
+				log.Printlnf("Loaded %d validator key(s) into the validator through its keymanager API.", len(pubkeys))
+			}
+			return nil
+		}
+		if log != nil {
+			log.Printlnf("Couldn't load validator keys through the keymanager API, restarting the validator instead: %s", err.Error())
+		}
+	}
+
+	return RestartValidator(cfg, bc, log, d)
+
+}
+
+// Import validator keys into the running VC through its keymanager API, then set their fee recipient.
+// Keys loaded this way start with the fee recipient the VC read at startup, which is stale if it was changed live since then.
+// loadKey gets the private key for a validator; it isn't used if the keys are held by the remote signer.
+func ImportValidatorKeys(cfg *config.RocketPoolConfig, km *keymanager.Client, pubkeys []types.ValidatorPubkey, loadKey func(types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error), feeRecipient common.Address) error {
+
+	err := importValidatorKeys(cfg, km, pubkeys, loadKey)
+	if err != nil {
+		return err
+	}
+	for _, pubkey := range pubkeys {
+		err := km.SetFeeRecipient(pubkey, feeRecipient)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Import validator keys into the running VC through its keymanager API
+func importValidatorKeys(cfg *config.RocketPoolConfig, km *keymanager.Client, pubkeys []types.ValidatorPubkey, loadKey func(types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error)) error {
+
+	// Keys in the remote signer are imported by reference
+	if cfg.EnableRemoteSigner.Value.(bool) {
+		return km.ImportRemoteKeys(pubkeys, cfg.RemoteSigner.Url.Value.(string))
+	}
+
+	keys := make([]*eth2types.BLSPrivateKey, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		key, err := loadKey(pubkey)
+		if err != nil {
+			return fmt.Errorf("error loading validator key %s: %w", pubkey.Hex(), err)
+		}
+		if key == nil {
+			return fmt.Errorf("validator key %s not found", pubkey.Hex())
+		}
+		keys[i] = key
+	}
+	return km.ImportKeys(keys, nil)
+
+}
+
+// Set the fee recipient for every validator in the running VC through its keymanager API.
+// Falls back to restarting the VC so it loads the fee recipient file if km is nil or the API can't be used.
+func ApplyFeeRecipient(cfg *config.RocketPoolConfig, km *keymanager.Client, feeRecipient common.Address, bc beacon.Client, log *log.ColorLogger, d *client.Client) error {
+
+	if km != nil {
+		count, err := SetFeeRecipient(cfg, km, feeRecipient)
+		if err == nil {
+			if log != nil {
+				log.Printlnf("Set the fee recipient for %d validator(s) to %s through the keymanager API.", count, feeRecipient.Hex())
+			}
+			return nil
+		}
+		if log != nil {
+			log.Printlnf("Couldn't set the fee recipient through the keymanager API, restarting the validator instead: %s", err.Error())
+		}
+	}
+
+	return RestartValidator(cfg, bc, log, d)
+
+}
+
+// Set the fee recipient for every validator in the running VC through its keymanager API, returning how many were updated
+func SetFeeRecipient(cfg *config.RocketPoolConfig, km *keymanager.Client, feeRecipient common.Address) (int, error) {
+
+	pubkeys, err := listLoadedKeys(cfg, km)
+	if err != nil {
+		return 0, err
+	}
+	for _, pubkey := range pubkeys {
+		err := km.SetFeeRecipient(pubkey, feeRecipient)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(pubkeys), nil
+
+}
+
+// Set the graffiti for every validator in the running VC through its keymanager API, returning how many were updated
+func SetGraffiti(cfg *config.RocketPoolConfig, km *keymanager.Client, graffiti string) (int, error) {
+
+	pubkeys, err := listLoadedKeys(cfg, km)
+	if err != nil {
+		return 0, err
+	}
+	for _, pubkey := range pubkeys {
+		err := km.SetGraffiti(pubkey, graffiti)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(pubkeys), nil
+
+}
+
+// Delete every validator key from the running VC through its keymanager API so it stops validating with them.
+// Returns the deleted keys and their EIP-3076 slashing protection data, which is blank if the keys are held by the remote signer.
+func DeleteValidatorKeys(cfg *config.RocketPoolConfig, km *keymanager.Client) ([]types.ValidatorPubkey, string, error) {
+
+	pubkeys, err := listLoadedKeys(cfg, km)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(pubkeys) == 0 {
+		return pubkeys, "", nil
+	}
+
+	// The remote signer keeps the slashing protection for its keys
+	if cfg.EnableRemoteSigner.Value.(bool) {
+		return pubkeys, "", km.DeleteRemoteKeys(pubkeys)
+	}
+	slashingProtection, err := km.DeleteKeys(pubkeys)
+	if err != nil {
+		return nil, "", err
+	}
+	return pubkeys, slashingProtection, nil
+
+}
+
+// Get the keys that are loaded in the running VC
+func listLoadedKeys(cfg *config.RocketPoolConfig, km *keymanager.Client) ([]types.ValidatorPubkey, error) {
+	if cfg.EnableRemoteSigner.Value.(bool) {
+		return km.ListRemoteKeys()
+	}
+	return km.ListKeys()
+}
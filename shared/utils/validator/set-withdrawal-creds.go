@@ -26,11 +26,16 @@ func GetWithdrawalKey(mnemonic string, index uint, validatorKeyPath string) (*et
 
 // Get a voluntary exit message signature for a given validator key and index
 func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKey, validatorIndex string, newWithdrawalAddress common.Address, signatureDomain []byte) (types.ValidatorSignature, error) {
+	return SignWithdrawalCredsChangeMessage(NewLocalSigner(withdrawalKey), validatorIndex, newWithdrawalAddress, signatureDomain)
+}
+
+// Get a withdrawal credentials change message signature for a given validator index from the signer holding its withdrawal key
+func SignWithdrawalCredsChangeMessage(withdrawalSigner Signer, validatorIndex string, newWithdrawalAddress common.Address, signatureDomain []byte) (types.ValidatorSignature, error) {
 
 	// Get the withdrawal pubkey
-	withdrawalPubkey := withdrawalKey.PublicKey().Marshal()
+	withdrawalPubkey := withdrawalSigner.GetPubkey()
 	withdrawalPubkeyBuffer := [48]byte{}
-	copy(withdrawalPubkeyBuffer[:], withdrawalPubkey)
+	copy(withdrawalPubkeyBuffer[:], withdrawalPubkey.Bytes())
 
 	// Convert the validator index to a uint
 	indexNum, err := strconv.ParseUint(validatorIndex, 10, 64)
@@ -63,9 +68,6 @@ func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKe
 	}
 
 	// Sign message
-	signature := withdrawalKey.Sign(srHash[:]).Marshal()
-
-	// Return
-	return types.BytesToValidatorSignature(signature), nil
+	return withdrawalSigner.SignWithdrawalCredsChange(message, srHash)
 
 }
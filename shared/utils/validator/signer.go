@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Signs messages with a single BLS key, which may be held locally or by a remote signer
+type Signer interface {
+	// Get the public key of the key that signs the messages
+	GetPubkey() types.ValidatorPubkey
+
+	// Sign a voluntary exit with the given signing root
+	SignVoluntaryExit(exit eth2.VoluntaryExit, signingRoot [32]byte) (types.ValidatorSignature, error)
+
+	// Sign a withdrawal credentials change with the given signing root
+	SignWithdrawalCredsChange(change eth2.WithdrawalCredentialsChange, signingRoot [32]byte) (types.ValidatorSignature, error)
+}
+
+// Signs messages with a private key held in memory
+type LocalSigner struct {
+	key *eth2types.BLSPrivateKey
+}
+
+// Create a new signer for a private key
+func NewLocalSigner(key *eth2types.BLSPrivateKey) *LocalSigner {
+	return &LocalSigner{
+		key: key,
+	}
+}
+
+func (s *LocalSigner) GetPubkey() types.ValidatorPubkey {
+	return types.BytesToValidatorPubkey(s.key.PublicKey().Marshal())
+}
+
+func (s *LocalSigner) SignVoluntaryExit(exit eth2.VoluntaryExit, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	return types.BytesToValidatorSignature(s.key.Sign(signingRoot[:]).Marshal()), nil
+}
+
+func (s *LocalSigner) SignWithdrawalCredsChange(change eth2.WithdrawalCredentialsChange, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	return types.BytesToValidatorSignature(s.key.Sign(signingRoot[:]).Marshal()), nil
+}
@@ -11,6 +11,11 @@ import (
 
 // Get a voluntary exit message signature for a given validator key and index
 func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex string, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, error) {
+	return SignExitMessage(NewLocalSigner(validatorKey), validatorIndex, epoch, signatureDomain)
+}
+
+// Get a voluntary exit message signature for a given validator index from the signer holding its key
+func SignExitMessage(signer Signer, validatorIndex string, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, error) {
 
 	// Parse the validator index
 	indexNum, err := strconv.ParseUint(validatorIndex, 10, 64)
@@ -42,9 +47,6 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 	}
 
 	// Sign message
-	signature := validatorKey.Sign(srHash[:]).Marshal()
-
-	// Return
-	return types.BytesToValidatorSignature(signature), nil
+	return signer.SignVoluntaryExit(exitMessage, srHash)
 
 }